@@ -3,7 +3,9 @@ package main
 import (
 	"LoanGuard/internal/delivery/controllers"
 	"LoanGuard/internal/delivery/routers"
+	"LoanGuard/internal/domain/models"
 	"LoanGuard/internal/infrastructures/database"
+	"LoanGuard/internal/infrastructures/jobs"
 	"LoanGuard/internal/infrastructures/middlewares"
 	"LoanGuard/internal/infrastructures/services"
 	"LoanGuard/internal/infrastructures/services/email_service"
@@ -13,6 +15,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 
 	"LoanGuard/internal/repository/implementations"
 
@@ -37,6 +40,62 @@ func main() {
 	passWord := os.Getenv("PASSWORD")
 	cachePort := os.Getenv("CACHE_PORT")
 	cacheHost := os.Getenv("CACHE_HOST")
+	integrationApiKey := os.Getenv("INTEGRATION_API_KEY")
+	if currency := os.Getenv("DEFAULT_CURRENCY"); currency != "" {
+		models.DefaultCurrency = currency
+	}
+	assignmentStrategy := os.Getenv("LOAN_ASSIGNMENT_STRATEGY")
+	switch assignmentStrategy {
+	case "", models.AssignmentManual, models.AssignmentRoundRobin, models.AssignmentLeastLoaded:
+	default:
+		log.Fatalf("Invalid LOAN_ASSIGNMENT_STRATEGY: %s", assignmentStrategy)
+	}
+	reviewSLAHours := 48
+	if hours := os.Getenv("REVIEW_SLA_HOURS"); hours != "" {
+		reviewSLAHours, err = strconv.Atoi(hours)
+		if err != nil || reviewSLAHours <= 0 {
+			log.Fatalf("Invalid REVIEW_SLA_HOURS: %s", hours)
+		}
+	}
+	delinquencyRunAt := "01:00"
+	if at := os.Getenv("DELINQUENCY_RUN_AT"); at != "" {
+		delinquencyRunAt = at
+	}
+	delinquencyTime, err := time.Parse("15:04", delinquencyRunAt)
+	if err != nil {
+		log.Fatalf("Invalid DELINQUENCY_RUN_AT: %s", delinquencyRunAt)
+	}
+	reminderRunAt := "08:00"
+	if at := os.Getenv("REMINDER_RUN_AT"); at != "" {
+		reminderRunAt = at
+	}
+	reminderTime, err := time.Parse("15:04", reminderRunAt)
+	if err != nil {
+		log.Fatalf("Invalid REMINDER_RUN_AT: %s", reminderRunAt)
+	}
+	accrualRunAt := "00:30"
+	if at := os.Getenv("ACCRUAL_RUN_AT"); at != "" {
+		accrualRunAt = at
+	}
+	accrualTime, err := time.Parse("15:04", accrualRunAt)
+	if err != nil {
+		log.Fatalf("Invalid ACCRUAL_RUN_AT: %s", accrualRunAt)
+	}
+	interestDayCount := os.Getenv("INTEREST_DAY_COUNT")
+	if interestDayCount == "" {
+		interestDayCount = models.DayCountActual365
+	}
+	validDayCount := false
+	for _, convention := range models.DayCountConventions {
+		validDayCount = validDayCount || convention == interestDayCount
+	}
+	if !validDayCount {
+		log.Fatalf("Invalid INTEREST_DAY_COUNT: %s", interestDayCount)
+	}
+	reportingCurrency := os.Getenv("REPORTING_CURRENCY")
+	if reportingCurrency == "" {
+		reportingCurrency = models.DefaultCurrency
+	}
 	
 	smtpPort, err := strconv.Atoi(smtpPortStr)
 	if err != nil {
@@ -49,35 +108,127 @@ func main() {
 	}
 	fmt.Println("Connected to MongoDB!", dbClient.Db.Name())
 
+	// balance updates match on minor-unit money, so loans still holding bare
+	// numbers must be migrated before the server takes requests
+	legacyMoney, err := implementations.NewMongoMigrationRepository(dbClient.Db).CountLegacyMoney()
+	if err != nil {
+		log.Fatalf("Error checking for unmigrated money fields: %v", err)
+	}
+	if len(legacyMoney) > 0 {
+		log.Fatalf("Loans still store amounts as plain numbers %v, run cmd/migrate before starting the server", legacyMoney)
+	}
+
 	//services
 	emailSvc := email_service.NewEmailService(smtpHost, smtpPort, userName, passWord)
 	passSvc := services.NewPasswordService()
 	validationSvc := services.NewValidationService()
 	jwtSvc := services.NewJWTService(accessSecretKey, refreshSecretKey, verificationSecretKey)
 	cacheSvc := services.NewCacheService(cacheHost + ":" + cachePort , "", 0)
+	scheduleSvc := services.NewScheduleService()
 	cloudSvc := services.NewCloudinaryService(os.Getenv("CLOUDINARY_NAME"), os.Getenv("CLOUDINARY_API_KEY"), os.Getenv("CLOUDINARY_API_SECRET"), os.Getenv("CLOUDINARY_UPLOAD_FOLDER"),)
+	var documentStorage services.IFileStorage
+	switch os.Getenv("DOCUMENT_STORAGE") {
+	case "cloudinary":
+		documentStorage, err = services.NewCloudinaryFileStorage(os.Getenv("CLOUDINARY_NAME"), os.Getenv("CLOUDINARY_API_KEY"), os.Getenv("CLOUDINARY_API_SECRET"), os.Getenv("CLOUDINARY_DOCUMENT_FOLDER"))
+		if err != nil {
+			log.Fatalf("Failed to initialize document storage: %v", err)
+		}
+	case "", "local":
+		documentPath := os.Getenv("DOCUMENT_STORAGE_PATH")
+		if documentPath == "" {
+			documentPath = "uploads"
+		}
+		documentStorage = services.NewLocalFileStorage(documentPath)
+	default:
+		log.Fatalf("Invalid DOCUMENT_STORAGE: %s", os.Getenv("DOCUMENT_STORAGE"))
+	}
+	var payoutProvider services.IPayoutProvider
+	switch os.Getenv("PAYOUT_PROVIDER") {
+	case "http":
+		webhookURL := os.Getenv("PAYOUT_WEBHOOK_URL")
+		if webhookURL == "" {
+			log.Fatal("PAYOUT_WEBHOOK_URL is required for the http payout provider")
+		}
+		payoutProvider = services.NewHTTPPayoutProvider(webhookURL, os.Getenv("PAYOUT_WEBHOOK_SECRET"))
+	case "fake":
+		payoutProvider = services.NewFakePayoutProvider(0)
+	case "":
+		log.Fatal("PAYOUT_PROVIDER is required: http, or fake for development only")
+	default:
+		log.Fatalf("Invalid PAYOUT_PROVIDER: %s", os.Getenv("PAYOUT_PROVIDER"))
+	}
 
 	//repo implementations
 	userRepo := implementations.NewMongoUserRepository(dbClient.Db, cacheSvc)
 	otpRepo := implementations.NewMongoOtpRepository(dbClient.Db)
 	loanRepo := implementations.NewMongoLoanRepository(dbClient.Db)
 	logRepo := implementations.NewMongoLogRepository(dbClient.Db)
+	scheduleRepo := implementations.NewMongoScheduleRepository(dbClient.Db)
+	repaymentRepo := implementations.NewMongoRepaymentRepository(dbClient.Db)
+	ledgerRepo := implementations.NewMongoLedgerRepository(dbClient.Db)
+	productRepo := implementations.NewMongoProductRepository(dbClient.Db)
+	fxRateRepo := implementations.NewMongoFxRateRepository(dbClient.Db)
+	scorecardRepo := implementations.NewMongoScorecardRepository(dbClient.Db)
+	policyRepo := implementations.NewMongoDecisionPolicyRepository(dbClient.Db)
+	reasonCodeRepo := implementations.NewMongoReasonCodeRepository(dbClient.Db)
+	commentRepo := implementations.NewMongoCommentRepository(dbClient.Db)
+	collateralRepo := implementations.NewMongoCollateralRepository(dbClient.Db)
+	disbursementRepo := implementations.NewMongoDisbursementRepository(dbClient.Db)
+	documentRepo := implementations.NewMongoDocumentRepository(dbClient.Db)
+	reminderRepo := implementations.NewMongoReminderRepository(dbClient.Db)
+	restructureRepo := implementations.NewMongoRestructureRepository(dbClient.Db)
 
 	//middlewares
-	authMiddleware := middlewares.NewAuthMiddleware(jwtSvc, cacheSvc)
+	authMiddleware := middlewares.NewAuthMiddleware(jwtSvc, cacheSvc, integrationApiKey)
+	loanAccessMiddleware := middlewares.NewLoanAccessMiddleware(loanRepo)
 
 
 	//usecases
 	userUsecase := usecases.NewUserUsecase(userRepo, passSvc, validationSvc, emailSvc, jwtSvc, cloudSvc, "http://localhost:8080")
 	otpUsecase := usecases.NewOtpUseCase(otpRepo, userRepo, emailSvc, passSvc, "http://localhost:8080", validationSvc)
-	loanUsecase := usecases.NewLoanUsecase(loanRepo, logRepo)
-	adminUsecase := usecases.NewAdminUsecase(loanRepo, logRepo)	
+	scorer := usecases.NewScorecardScorer(userRepo, loanRepo, scorecardRepo)
+	loanUsecase := usecases.NewLoanUsecase(loanRepo, logRepo, scheduleRepo, productRepo, userRepo, policyRepo, documentRepo, collateralRepo, disbursementRepo, scheduleSvc, emailSvc, scorer, assignmentStrategy)
+	adminUsecase := usecases.NewAdminUsecase(loanRepo, logRepo, fxRateRepo, productRepo, userRepo, reasonCodeRepo, documentRepo, collateralRepo, emailSvc, reportingCurrency)
+	repaymentUsecase := usecases.NewRepaymentUsecase(loanRepo, scheduleRepo, repaymentRepo, ledgerRepo, collateralRepo, productRepo, scheduleSvc, logRepo)
+	productUsecase := usecases.NewProductUsecase(productRepo, logRepo)
+	fxUsecase := usecases.NewFxUsecase(fxRateRepo, logRepo, reportingCurrency)
+	scorecardUsecase := usecases.NewScorecardUsecase(scorecardRepo, logRepo)
+	policyUsecase := usecases.NewDecisionPolicyUsecase(policyRepo, loanRepo, userRepo, logRepo, scorer)
+	reasonCodeUsecase := usecases.NewReasonCodeUsecase(reasonCodeRepo, logRepo)
+	assignmentUsecase := usecases.NewAssignmentUsecase(loanRepo, userRepo, logRepo, time.Duration(reviewSLAHours)*time.Hour)
+	commentUsecase := usecases.NewCommentUsecase(commentRepo, userRepo, logRepo, emailSvc)
+	partyUsecase := usecases.NewLoanPartyUsecase(loanRepo, userRepo, logRepo, emailSvc)
+	documentUsecase := usecases.NewDocumentUsecase(documentRepo, loanRepo, productRepo, logRepo, documentStorage)
+	collateralUsecase := usecases.NewCollateralUsecase(collateralRepo, loanRepo, productRepo, documentRepo, logRepo)
+	disbursementUsecase := usecases.NewDisbursementUsecase(disbursementRepo, loanRepo, scheduleRepo, ledgerRepo, logRepo, scheduleSvc, payoutProvider)
+	delinquencyUsecase := usecases.NewDelinquencyUsecase(loanRepo, scheduleRepo, productRepo, ledgerRepo, userRepo, logRepo)
+	reminderUsecase := usecases.NewReminderUsecase(reminderRepo, loanRepo, scheduleRepo, productRepo, userRepo, logRepo, emailSvc)
+	restructureUsecase := usecases.NewRestructureUsecase(restructureRepo, loanRepo, scheduleRepo, ledgerRepo, userRepo, logRepo, scheduleSvc, emailSvc, "http://localhost:8080")
+	accrualUsecase := usecases.NewAccrualUsecase(loanRepo, scheduleRepo, productRepo, ledgerRepo, logRepo, interestDayCount)
+	kycUsecase := usecases.NewKYCUsecase(userRepo, logRepo)
 
 	// controllers
 	userController := controllers.NewUserController(userUsecase)
 	otpController := controllers.NewOTPController(otpUsecase)
 	loanController := controllers.NewLoanController(loanUsecase)
 	adminController := controllers.NewAdminController(userUsecase, adminUsecase)
+	repaymentController := controllers.NewRepaymentController(repaymentUsecase)
+	productController := controllers.NewProductController(productUsecase)
+	fxController := controllers.NewFxController(fxUsecase)
+	scorecardController := controllers.NewScorecardController(scorecardUsecase)
+	policyController := controllers.NewDecisionPolicyController(policyUsecase)
+	reasonCodeController := controllers.NewReasonCodeController(reasonCodeUsecase)
+	officerController := controllers.NewOfficerController(assignmentUsecase)
+	commentController := controllers.NewCommentController(commentUsecase)
+	partyController := controllers.NewLoanPartyController(partyUsecase)
+	documentController := controllers.NewDocumentController(documentUsecase)
+	collateralController := controllers.NewCollateralController(collateralUsecase)
+	disbursementController := controllers.NewDisbursementController(disbursementUsecase)
+	delinquencyController := controllers.NewDelinquencyController(delinquencyUsecase)
+	reminderController := controllers.NewReminderController(reminderUsecase)
+	restructureController := controllers.NewRestructureController(restructureUsecase)
+	accrualController := controllers.NewAccrualController(accrualUsecase)
+	kycController := controllers.NewKYCController(kycUsecase)
 	
 
 	//gin engine initialization
@@ -88,7 +239,35 @@ func main() {
 	// routers
 	routers.CreateUserRouter(router, userController, otpController, authMiddleware)
 	routers.CreateAdminRouter(router, adminController, authMiddleware)
-	routers.CreateLoanRouter(router, loanController, authMiddleware)
+	routers.CreateLoanRouter(router, loanController, repaymentController, authMiddleware, loanAccessMiddleware)
+	routers.CreateProductRouter(router, productController, authMiddleware)
+	routers.CreateFxRouter(router, fxController, authMiddleware)
+	routers.CreateScorecardRouter(router, scorecardController, authMiddleware)
+	routers.CreateDecisionPolicyRouter(router, policyController, authMiddleware)
+	routers.CreateReasonCodeRouter(router, reasonCodeController, authMiddleware)
+	routers.CreateOfficerRouter(router, officerController, authMiddleware)
+	routers.CreateCommentRouter(router, commentController, authMiddleware, loanAccessMiddleware)
+	routers.CreateLoanPartyRouter(router, partyController, authMiddleware, loanAccessMiddleware)
+	routers.CreateDocumentRouter(router, documentController, authMiddleware, loanAccessMiddleware)
+	routers.CreateCollateralRouter(router, collateralController, authMiddleware, loanAccessMiddleware)
+	routers.CreateDisbursementRouter(router, disbursementController, authMiddleware)
+	routers.CreateDelinquencyRouter(router, delinquencyController, authMiddleware)
+	routers.CreateReminderRouter(router, reminderController, authMiddleware, loanAccessMiddleware)
+	routers.CreateAccrualRouter(router, accrualController, authMiddleware)
+	routers.CreateRestructureRouter(router, restructureController, authMiddleware)
+	routers.CreateKYCRouter(router, kycController, authMiddleware)
+
+	// background jobs
+	jobs.Every(context.Background(), "disbursement retries", time.Minute, disbursementUsecase.RetryDueDisbursements)
+	jobs.Daily(context.Background(), "delinquency", delinquencyTime.Hour(), delinquencyTime.Minute(), func() error {
+		return delinquencyUsecase.RunDelinquency(time.Now())
+	})
+	jobs.Daily(context.Background(), "payment reminders", reminderTime.Hour(), reminderTime.Minute(), func() error {
+		return reminderUsecase.RunReminders(time.Now())
+	})
+	jobs.Daily(context.Background(), "interest accrual", accrualTime.Hour(), accrualTime.Minute(), func() error {
+		return accrualUsecase.RunAccrual(time.Now().UTC().AddDate(0, 0, -1))
+	})
 
 	if err := router.Run(":" + os.Getenv("PORT")); err!= nil{
 		log.Fatal(err)
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/infrastructures/database"
+	"LoanGuard/internal/repository/implementations"
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+)
+
+// migrate upgrades documents written by older versions of LoanGuard. It is
+// safe to run repeatedly.
+func main() {
+	err := godotenv.Load(".env")
+	if err != nil {
+		log.Fatalf("Error loading .env file: %v", err)
+	}
+	if currency := os.Getenv("DEFAULT_CURRENCY"); currency != "" {
+		models.DefaultCurrency = currency
+	}
+
+	dbClient, err := database.NewMongoDB(context.Background(), os.Getenv("DB_NAME"))
+	if err != nil {
+		log.Fatalf("Error connecting to MongoDB: %v", err)
+	}
+
+	migrationRepo := implementations.NewMongoMigrationRepository(dbClient.Db)
+	counts, err := migrationRepo.MigrateLegacyMoney()
+	for collection, count := range counts {
+		fmt.Printf("%s: %d documents migrated to minor-unit money\n", collection, count)
+	}
+	if err != nil {
+		log.Fatalf("Money migration failed: %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"LoanGuard/internal/usecases"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IAccrualController interface {
+	RunAccrual(ctx *gin.Context)
+}
+
+type AccrualController struct {
+	accrualUsecase usecases.IAccrualUsecase
+}
+
+func NewAccrualController(accrualUsecase usecases.IAccrualUsecase) IAccrualController {
+	return &AccrualController{
+		accrualUsecase: accrualUsecase,
+	}
+}
+
+// RunAccrual accrues interest up to an optional ?through= date, yesterday by
+// default.
+func (ac *AccrualController) RunAccrual(ctx *gin.Context) {
+	through := time.Now().UTC().AddDate(0, 0, -1)
+	if param := ctx.Query("through"); param != "" {
+		var err error
+		if through, err = time.Parse("2006-01-02", param); err != nil {
+			ctx.JSON(400, gin.H{"error": "through must be in YYYY-MM-DD format"})
+			return
+		}
+	}
+	if err := ac.accrualUsecase.RunAccrual(through); err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"message": "Interest accrued through " + through.Format("2006-01-02")})
+}
@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/domain/models"
 	"LoanGuard/internal/usecases"
 	"github.com/gin-gonic/gin"
 )
@@ -11,6 +13,7 @@ type IAdminController interface{
 	DeleteUser(ctx *gin.Context)
 	GetLoans(ctx *gin.Context)
 	AcceptOrRejectLoan(ctx *gin.Context)
+	TransitionLoan(ctx *gin.Context)
 	DeleteLoan(ctx *gin.Context)
 	GetSystemLogs(ctx *gin.Context)
 }
@@ -29,13 +32,18 @@ func NewAdminController(user_usecase usecases.IUserUsecase, admin_usecase usecas
 
 
 func (uc *AdminController) GetUsers(ctx *gin.Context){
+	query, err := parseListQuery(ctx)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
 	
-	users, err := uc.user_usecase.GetUsers()
+	users, nextCursor, err := uc.user_usecase.GetUsers(query)
 	if err != nil {
-		ctx.JSON(500, gin.H{"error": err.Error()})
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
-	ctx.JSON(200, gin.H{"users": users})
+	ctx.JSON(200, gin.H{"users": users, "next_cursor": nextCursor})
 }
 
 func (uc *AdminController) DeleteUser(ctx *gin.Context){
@@ -49,23 +57,75 @@ func (uc *AdminController) DeleteUser(ctx *gin.Context){
 }
 
 func (uc *AdminController) GetLoans(ctx *gin.Context){
-	status := ctx.DefaultQuery("status", "all")
-	order := ctx.DefaultQuery("order", "asc")
+	query, err := parseListQuery(ctx)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	// order is the older way of sorting by creation date
+	if len(query.Sort) == 0 {
+		query.Sort = []models.SortField{{Field: "created_at", Desc: ctx.DefaultQuery("order", "asc") == "desc"}}
+	}
 	
-	loans, err := uc.admin_usecase.GetLoans(status, order)
+	asOf, err := parseAsOf(ctx.Query("as_of"))
 	if err != nil {
-		ctx.JSON(500, gin.H{"error": err.Error()})
+		ctx.JSON(400, gin.H{"error": err.Error()})
 		return
 	}
-	ctx.JSON(200, gin.H{"loans": loans})
+	
+	loans, nextCursor, err := uc.admin_usecase.GetLoans(query)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	response := gin.H{"loans": loans, "next_cursor": nextCursor}
+
+	// the portfolio summary covers every page, so it comes with the first one
+	if query.Cursor == "" {
+		summary, err := uc.admin_usecase.GetLoanSummary(query.Status, asOf)
+		if err != nil {
+			ctx.JSON(500, gin.H{"error": err.Error()})
+			return
+		}
+		response["summary"] = summary
+	}
+	ctx.JSON(200, response)
 }
 
 func (uc *AdminController) AcceptOrRejectLoan(ctx *gin.Context){	
 	loanID := ctx.Param("id")
-	status := ctx.Query("status")
-	err := uc.admin_usecase.AcceptOrRejectLoan(loanID, status)
+	var decision dtos.LoanDecisionDTO
+	if err := ctx.ShouldBindJSON(&decision); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	adminID, _, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+	err := uc.admin_usecase.AcceptOrRejectLoan(loanID, adminID, decision)
 	if err != nil {
-		ctx.JSON(500, gin.H{"error": err.Error()})
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"message": "Loan status updated"})
+}
+
+func (uc *AdminController) TransitionLoan(ctx *gin.Context){
+	var req dtos.LoanTransitionDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	adminID, _, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+	err := uc.admin_usecase.TransitionLoan(ctx.Param("id"), req.Status, adminID, req.Reason)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	ctx.JSON(200, gin.H{"message": "Loan status updated"})
@@ -75,17 +135,23 @@ func (uc *AdminController) DeleteLoan(ctx *gin.Context){
 	loanID := ctx.Param("id")
 	err := uc.admin_usecase.DeleteLoan(loanID)
 	if err != nil {
-		ctx.JSON(500, gin.H{"error": err.Error()})
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	ctx.JSON(200, gin.H{"message": "Loan successfully deleted"})
 }
 
 func (uc *AdminController) GetSystemLogs(ctx *gin.Context){
-	logs, err := uc.admin_usecase.GetSystemLogs()
+	query, err := parseListQuery(ctx)
 	if err != nil {
-		ctx.JSON(500, gin.H{"error": err.Error()})
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	logs, nextCursor, err := uc.admin_usecase.GetSystemLogs(query)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
-	ctx.JSON(200, gin.H{"logs": logs})
+	ctx.JSON(200, gin.H{"logs": logs, "next_cursor": nextCursor})
 }
\ No newline at end of file
@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ICollateralController interface {
+	AddCollateral(ctx *gin.Context)
+	GetCollateral(ctx *gin.Context)
+	UpdateCollateral(ctx *gin.Context)
+	RemoveCollateral(ctx *gin.Context)
+}
+
+type CollateralController struct {
+	collateralUsecase usecases.ICollateralUsecase
+}
+
+func NewCollateralController(collateralUsecase usecases.ICollateralUsecase) ICollateralController {
+	return &CollateralController{
+		collateralUsecase: collateralUsecase,
+	}
+}
+
+func (cc *CollateralController) AddCollateral(ctx *gin.Context) {
+	var req dtos.CollateralDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	userID, role, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+
+	collateral, err := cc.collateralUsecase.AddCollateral(ctx.Param("id"), userID, role, req)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(201, gin.H{"collateral": collateral})
+}
+
+func (cc *CollateralController) GetCollateral(ctx *gin.Context) {
+	collateral, err := cc.collateralUsecase.GetCollateral(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, collateral)
+}
+
+func (cc *CollateralController) UpdateCollateral(ctx *gin.Context) {
+	var req dtos.CollateralDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	adminID, _, _ := getClaims(ctx)
+
+	collateral, err := cc.collateralUsecase.UpdateCollateral(ctx.Param("id"), ctx.Param("collateralId"), adminID, req)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"collateral": collateral})
+}
+
+func (cc *CollateralController) RemoveCollateral(ctx *gin.Context) {
+	userID, _, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+
+	if err := cc.collateralUsecase.RemoveCollateral(ctx.Param("id"), ctx.Param("collateralId"), userID); err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"message": "collateral removed"})
+}
@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ICommentController interface {
+	AddComment(ctx *gin.Context)
+	GetComments(ctx *gin.Context)
+	EditComment(ctx *gin.Context)
+}
+
+type CommentController struct {
+	commentUsecase usecases.ICommentUsecase
+}
+
+func NewCommentController(commentUsecase usecases.ICommentUsecase) ICommentController {
+	return &CommentController{
+		commentUsecase: commentUsecase,
+	}
+}
+
+func (cc *CommentController) AddComment(ctx *gin.Context) {
+	var req dtos.CommentDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	userID, role, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+
+	comment, err := cc.commentUsecase.AddComment(ctx.Param("id"), userID, role, req)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(201, gin.H{"comment": comment})
+}
+
+func (cc *CommentController) GetComments(ctx *gin.Context) {
+	_, role, _ := getClaims(ctx)
+
+	comments, err := cc.commentUsecase.GetComments(ctx.Param("id"), role)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"comments": comments})
+}
+
+func (cc *CommentController) EditComment(ctx *gin.Context) {
+	var req dtos.CommentDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	userID, role, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+
+	comment, err := cc.commentUsecase.EditComment(ctx.Param("id"), ctx.Param("commentId"), userID, role, req)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"comment": comment})
+}
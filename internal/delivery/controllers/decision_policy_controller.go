@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IDecisionPolicyController interface {
+	GetPolicy(ctx *gin.Context)
+	UpdatePolicy(ctx *gin.Context)
+	DryRun(ctx *gin.Context)
+}
+
+type DecisionPolicyController struct {
+	policyUsecase usecases.IDecisionPolicyUsecase
+}
+
+func NewDecisionPolicyController(policyUsecase usecases.IDecisionPolicyUsecase) IDecisionPolicyController {
+	return &DecisionPolicyController{
+		policyUsecase: policyUsecase,
+	}
+}
+
+func (dc *DecisionPolicyController) GetPolicy(ctx *gin.Context) {
+	policy, err := dc.policyUsecase.GetPolicy()
+	if err != nil {
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"policy": policy})
+}
+
+func (dc *DecisionPolicyController) UpdatePolicy(ctx *gin.Context) {
+	var policy models.DecisionPolicy
+	if err := ctx.ShouldBindJSON(&policy); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	adminID, _, _ := getClaims(ctx)
+
+	result, err := dc.policyUsecase.UpdatePolicy(adminID, &policy)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"policy": result})
+}
+
+// DryRun takes a draft policy as the body and the loan list parameters of
+// GET /admin/loans to pick the historical loans to run it against.
+func (dc *DecisionPolicyController) DryRun(ctx *gin.Context) {
+	var policy models.DecisionPolicy
+	if err := ctx.ShouldBindJSON(&policy); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	query, err := parseListQuery(ctx)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := dc.policyUsecase.DryRun(&policy, query)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, result)
+}
@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"LoanGuard/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IDelinquencyController interface {
+	GetDelinquentLoans(ctx *gin.Context)
+}
+
+type DelinquencyController struct {
+	delinquencyUsecase usecases.IDelinquencyUsecase
+}
+
+func NewDelinquencyController(delinquencyUsecase usecases.IDelinquencyUsecase) IDelinquencyController {
+	return &DelinquencyController{
+		delinquencyUsecase: delinquencyUsecase,
+	}
+}
+
+// GetDelinquentLoans takes an optional ?bucket= of 1-30, 31-60, 61-90 or 90+.
+func (dc *DelinquencyController) GetDelinquentLoans(ctx *gin.Context) {
+	report, err := dc.delinquencyUsecase.GetDelinquentLoans(ctx.Query("bucket"))
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, report)
+}
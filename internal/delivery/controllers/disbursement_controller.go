@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IDisbursementController interface {
+	DisburseLoan(ctx *gin.Context)
+	GetDisbursements(ctx *gin.Context)
+	RetryDisbursement(ctx *gin.Context)
+}
+
+type DisbursementController struct {
+	disbursementUsecase usecases.IDisbursementUsecase
+}
+
+func NewDisbursementController(disbursementUsecase usecases.IDisbursementUsecase) IDisbursementController {
+	return &DisbursementController{
+		disbursementUsecase: disbursementUsecase,
+	}
+}
+
+// DisburseLoan needs an Idempotency-Key header. Repeating the request with
+// the same key answers 200 with the original disbursement instead of 201.
+func (dc *DisbursementController) DisburseLoan(ctx *gin.Context) {
+	var req dtos.DisbursementDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	adminID, _, _ := getClaims(ctx)
+
+	disbursement, created, err := dc.disbursementUsecase.DisburseLoan(ctx.Param("id"), adminID, ctx.GetHeader("Idempotency-Key"), req)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	status := 200
+	if created {
+		status = 201
+	}
+	ctx.JSON(status, gin.H{"disbursement": disbursement})
+}
+
+func (dc *DisbursementController) GetDisbursements(ctx *gin.Context) {
+	disbursements, err := dc.disbursementUsecase.GetDisbursements(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"disbursements": disbursements})
+}
+
+func (dc *DisbursementController) RetryDisbursement(ctx *gin.Context) {
+	adminID, _, _ := getClaims(ctx)
+
+	disbursement, err := dc.disbursementUsecase.RetryDisbursement(ctx.Param("disbursementId"), adminID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"disbursement": disbursement})
+}
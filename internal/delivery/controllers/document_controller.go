@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/usecases"
+	"mime"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IDocumentController interface {
+	UploadDocument(ctx *gin.Context)
+	GetDocuments(ctx *gin.Context)
+	ReviewDocument(ctx *gin.Context)
+	DownloadDocument(ctx *gin.Context)
+}
+
+type DocumentController struct {
+	documentUsecase usecases.IDocumentUsecase
+}
+
+func NewDocumentController(documentUsecase usecases.IDocumentUsecase) IDocumentController {
+	return &DocumentController{
+		documentUsecase: documentUsecase,
+	}
+}
+
+// UploadDocument takes a multipart form with the document "type" and the
+// "file" itself.
+func (dc *DocumentController) UploadDocument(ctx *gin.Context) {
+	userID, _, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": "a file is required"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": "Failed to process the file"})
+		return
+	}
+	defer file.Close()
+
+	document, err := dc.documentUsecase.UploadDocument(ctx.Param("id"), userID, ctx.PostForm("type"), header.Filename, header.Size, file)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(201, gin.H{"document": document})
+}
+
+func (dc *DocumentController) GetDocuments(ctx *gin.Context) {
+	documents, err := dc.documentUsecase.GetDocuments(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, documents)
+}
+
+func (dc *DocumentController) ReviewDocument(ctx *gin.Context) {
+	var req dtos.DocumentReviewDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	adminID, _, _ := getClaims(ctx)
+
+	document, err := dc.documentUsecase.ReviewDocument(ctx.Param("id"), ctx.Param("documentId"), adminID, req)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"document": document})
+}
+
+func (dc *DocumentController) DownloadDocument(ctx *gin.Context) {
+	actorID, _, _ := getClaims(ctx)
+
+	document, file, err := dc.documentUsecase.OpenDocument(ctx.Param("id"), ctx.Param("documentId"), actorID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+	ctx.DataFromReader(200, document.Size, document.ContentType, file, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": document.FileName}),
+	})
+}
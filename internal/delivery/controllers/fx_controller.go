@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IFxController interface {
+	UploadRates(ctx *gin.Context)
+	GetRates(ctx *gin.Context)
+}
+
+type FxController struct {
+	fxUsecase usecases.IFxUsecase
+}
+
+func NewFxController(fxUsecase usecases.IFxUsecase) IFxController {
+	return &FxController{
+		fxUsecase: fxUsecase,
+	}
+}
+
+func (fc *FxController) UploadRates(ctx *gin.Context) {
+	var upload dtos.FXRateUploadDTO
+	if err := ctx.ShouldBindJSON(&upload); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	adminID, _, _ := getClaims(ctx)
+
+	rates, err := fc.fxUsecase.UploadRates(adminID, upload)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(201, gin.H{"rates": rates})
+}
+
+func (fc *FxController) GetRates(ctx *gin.Context) {
+	asOf, err := parseAsOf(ctx.Query("as_of"))
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	rates, err := fc.fxUsecase.GetRates(asOf)
+	if err != nil {
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"as_of": asOf, "rates": rates})
+}
@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"LoanGuard/internal/domain/models"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// getClaims returns the caller's user id and role as set by the
+// authentication middleware.
+func getClaims(ctx *gin.Context) (string, string, bool) {
+	claims, _ := ctx.Get("claims")
+	jwtClaims, ok := claims.(jwt.MapClaims)
+	if !ok {
+		return "", "", false
+	}
+	userID, _ := jwtClaims["user_id"].(string)
+	role, _ := jwtClaims["role"].(string)
+	return userID, role, true
+}
+
+// errorStatus maps domain errors to the HTTP status they should surface as.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrInvalidTransition), errors.Is(err, models.ErrStatusConflict),
+		errors.Is(err, models.ErrNoOfficers), errors.Is(err, models.ErrDocumentsIncomplete),
+		errors.Is(err, models.ErrPartiesPending), errors.Is(err, models.ErrLTVExceeded),
+		errors.Is(err, models.ErrDisbursementExists), errors.Is(err, models.ErrIdempotencyConflict),
+		errors.Is(err, models.ErrDuplicateRepayment),
+		errors.Is(err, models.ErrQuoteExpired):
+		return http.StatusConflict
+	case errors.Is(err, models.ErrSameApprover), errors.Is(err, models.ErrCommentEditClosed),
+		errors.Is(err, models.ErrKYCNotVerified):
+		return http.StatusForbidden
+	case errors.Is(err, models.ErrLoanNotFound), errors.Is(err, models.ErrCommentNotFound),
+		errors.Is(err, models.ErrDocumentNotFound), errors.Is(err, models.ErrPartyNotFound),
+		errors.Is(err, models.ErrCollateralNotFound), errors.Is(err, models.ErrDisbursementNotFound),
+		errors.Is(err, models.ErrRestructureNotFound), errors.Is(err, models.ErrScheduleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrInvalidQuery), errors.Is(err, models.ErrInvalidReasonCode),
+		errors.Is(err, models.ErrInvalidAssignment), errors.Is(err, models.ErrInvalidComment),
+		errors.Is(err, models.ErrInvalidDocument), errors.Is(err, models.ErrInvalidKYC),
+		errors.Is(err, models.ErrInvalidParty), errors.Is(err, models.ErrInvalidCollateral),
+		errors.Is(err, models.ErrInvalidDisbursement), errors.Is(err, models.ErrInvalidPrepayment),
+		errors.Is(err, models.ErrInvalidRestructure):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// parseAsOf reads a YYYY-MM-DD reporting date, defaulting to now.
+func parseAsOf(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now().UTC(), nil
+	}
+	asOf, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, errors.New("as_of must be a date in YYYY-MM-DD format")
+	}
+	return asOf, nil
+}
+
+// parseListQuery reads the paging, sorting and filtering parameters shared by
+// the admin list endpoints: cursor, limit, sort (e.g. "-created_at,amount"),
+// status, currency, min_amount and max_amount (both need currency), from and to (YYYY-MM-DD, to is
+// inclusive) and q for text search.
+func parseListQuery(ctx *gin.Context) (models.ListQuery, error) {
+	query := models.ListQuery{
+		Cursor:   ctx.Query("cursor"),
+		Sort:     models.ParseSort(ctx.Query("sort")),
+		Status:   ctx.Query("status"),
+		Currency: strings.ToUpper(ctx.Query("currency")),
+		Search:   strings.TrimSpace(ctx.Query("q")),
+	}
+	if limit := ctx.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return query, fmt.Errorf("%w: limit must be a number", models.ErrInvalidQuery)
+		}
+		query.Limit = n
+	}
+
+	for param, target := range map[string]**models.Money{"min_amount": &query.MinAmount, "max_amount": &query.MaxAmount} {
+		if value := ctx.Query(param); value != "" {
+			// amounts in different currencies are not comparable
+			if query.Currency == "" {
+				return query, fmt.Errorf("%w: %s needs a currency", models.ErrInvalidQuery, param)
+			}
+			amount, err := models.ParseMoney(value, query.Currency)
+			if err != nil {
+				return query, fmt.Errorf("%w: %s: %v", models.ErrInvalidQuery, param, err)
+			}
+			*target = &amount
+		}
+	}
+	var err error
+	if from := ctx.Query("from"); from != "" {
+		if query.CreatedFrom, err = time.Parse("2006-01-02", from); err != nil {
+			return query, fmt.Errorf("%w: from must be a date in YYYY-MM-DD format", models.ErrInvalidQuery)
+		}
+	}
+	if to := ctx.Query("to"); to != "" {
+		if query.CreatedTo, err = time.Parse("2006-01-02", to); err != nil {
+			return query, fmt.Errorf("%w: to must be a date in YYYY-MM-DD format", models.ErrInvalidQuery)
+		}
+		query.CreatedTo = query.CreatedTo.AddDate(0, 0, 1)
+	}
+	return query, nil
+}
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IKYCController interface {
+	SubmitKYC(ctx *gin.Context)
+	GetMyKYC(ctx *gin.Context)
+	GetKYCQueue(ctx *gin.Context)
+	ReviewKYC(ctx *gin.Context)
+}
+
+type KYCController struct {
+	kycUsecase usecases.IKYCUsecase
+}
+
+func NewKYCController(kycUsecase usecases.IKYCUsecase) IKYCController {
+	return &KYCController{
+		kycUsecase: kycUsecase,
+	}
+}
+
+func (kc *KYCController) SubmitKYC(ctx *gin.Context) {
+	var req dtos.KYCSubmissionDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	userID, _, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+
+	kyc, err := kc.kycUsecase.SubmitKYC(userID, req)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"kyc": kyc})
+}
+
+func (kc *KYCController) GetMyKYC(ctx *gin.Context) {
+	userID, _, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+
+	kyc, err := kc.kycUsecase.GetKYC(userID)
+	if err != nil {
+		ctx.JSON(404, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"kyc": kyc})
+}
+
+func (kc *KYCController) GetKYCQueue(ctx *gin.Context) {
+	users, err := kc.kycUsecase.GetKYCByStatus(ctx.Query("status"))
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"users": users})
+}
+
+func (kc *KYCController) ReviewKYC(ctx *gin.Context) {
+	var req dtos.KYCReviewDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	adminID, _, _ := getClaims(ctx)
+
+	kyc, err := kc.kycUsecase.ReviewKYC(ctx.Param("id"), adminID, req)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"kyc": kyc})
+}
@@ -1,8 +1,11 @@
 package controllers
 
 import (
-	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/domain/dtos"
 	"LoanGuard/internal/usecases"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
@@ -10,7 +13,12 @@ import (
 
 type ILoanController interface{
 	RequestLoan(ctx *gin.Context)
+	SubmitLoan(ctx *gin.Context)
+	CancelLoan(ctx *gin.Context)
 	ViewLoanStatus(ctx *gin.Context)
+	GetMyLoans(ctx *gin.Context)
+	GetLoanSchedule(ctx *gin.Context)
+	GetLoanSchedules(ctx *gin.Context)
 }
 
 type LoanController struct {
@@ -24,8 +32,8 @@ func NewLoanController(loanUsecase usecases.ILoanUsecase) ILoanController{
 }
 
 func (lc *LoanController) RequestLoan(ctx *gin.Context){
-	var loan *models.Loan
-	err := ctx.ShouldBindJSON(&loan)
+	var req dtos.LoanRequestDTO
+	err := ctx.ShouldBindJSON(&req)
 	if err != nil {
 		ctx.JSON(400, gin.H{"message": "invalid json format"})
 		return
@@ -39,20 +47,125 @@ func (lc *LoanController) RequestLoan(ctx *gin.Context){
     }
     userID, _ := jwtClaims["user_id"].(string)
 
-	result, err := lc.loanUsecase.RequestLoan(userID, loan)
+	draft := ctx.Query("draft") == "true"
+	result, err := lc.loanUsecase.RequestLoan(userID, req, draft)
 	if err != nil {
-		ctx.JSON(500, gin.H{"error": err.Error()})
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	ctx.JSON(200, gin.H{"loan_request": result})
 }
 
+func (lc *LoanController) SubmitLoan(ctx *gin.Context){
+	userID, _, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+
+	err := lc.loanUsecase.SubmitLoan(ctx.Param("id"), userID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"message": "Loan application submitted"})
+}
+
+func (lc *LoanController) CancelLoan(ctx *gin.Context){
+	userID, _, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+
+	var req dtos.LoanTransitionDTO
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(400, gin.H{"message": "invalid json format"})
+			return
+		}
+	}
+
+	err := lc.loanUsecase.CancelLoan(ctx.Param("id"), userID, req.Reason)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"message": "Loan application cancelled"})
+}
+
+// ViewLoanStatus shows admins the whole loan and its borrower and parties
+// their view of it.
 func (lc *LoanController) ViewLoanStatus(ctx *gin.Context){
+	userID, role, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+
 	loanId := ctx.Param("id")
-	loanStatus, err := lc.loanUsecase.ViewLoanStatus(loanId)
+	var loan interface{}
+	var err error
+	if strings.EqualFold(role, "ADMIN") || strings.EqualFold(role, "OFFICER") {
+		loan, err = lc.loanUsecase.GetLoanDetail(loanId)
+	} else {
+		loan, err = lc.loanUsecase.GetBorrowerLoanDetail(loanId, userID)
+	}
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"loan": loan})
+}
+
+func (lc *LoanController) GetMyLoans(ctx *gin.Context){
+	userID, _, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+
+	query := dtos.MyLoansQueryDTO{Status: ctx.Query("status")}
+	var err error
+	if from := ctx.Query("from"); from != "" {
+		if query.CreatedFrom, err = time.Parse("2006-01-02", from); err != nil {
+			ctx.JSON(400, gin.H{"error": "from must be a date in YYYY-MM-DD format"})
+			return
+		}
+	}
+	if to := ctx.Query("to"); to != "" {
+		if query.CreatedTo, err = time.Parse("2006-01-02", to); err != nil {
+			ctx.JSON(400, gin.H{"error": "to must be a date in YYYY-MM-DD format"})
+			return
+		}
+		// the to date is inclusive
+		query.CreatedTo = query.CreatedTo.AddDate(0, 0, 1)
+	}
+	query.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	query.Limit, _ = strconv.Atoi(ctx.Query("limit"))
+
+	page, err := lc.loanUsecase.GetMyLoans(userID, query)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, page)
+}
+
+func (lc *LoanController) GetLoanSchedule(ctx *gin.Context){
+	schedule, err := lc.loanUsecase.GetLoanSchedule(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(404, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"schedule": schedule})
+}
+
+func (lc *LoanController) GetLoanSchedules(ctx *gin.Context){
+	schedules, err := lc.loanUsecase.GetLoanSchedules(ctx.Param("id"))
 	if err != nil {
 		ctx.JSON(500, gin.H{"error": err.Error()})
 		return
 	}
-	ctx.JSON(200, gin.H{"loanStatus": loanStatus})
-}
\ No newline at end of file
+	ctx.JSON(200, gin.H{"schedules": schedules})
+}
@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ILoanPartyController interface {
+	InviteParty(ctx *gin.Context)
+	WithdrawParty(ctx *gin.Context)
+	AcceptInvitation(ctx *gin.Context)
+	DeclineInvitation(ctx *gin.Context)
+}
+
+type LoanPartyController struct {
+	partyUsecase usecases.ILoanPartyUsecase
+}
+
+func NewLoanPartyController(partyUsecase usecases.ILoanPartyUsecase) ILoanPartyController {
+	return &LoanPartyController{
+		partyUsecase: partyUsecase,
+	}
+}
+
+func (pc *LoanPartyController) InviteParty(ctx *gin.Context) {
+	var req dtos.PartyInvitationDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	userID, _, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+
+	party, err := pc.partyUsecase.InviteParty(ctx.Param("id"), userID, req)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(201, gin.H{"party": party})
+}
+
+func (pc *LoanPartyController) WithdrawParty(ctx *gin.Context) {
+	userID, _, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+
+	if err := pc.partyUsecase.WithdrawParty(ctx.Param("id"), ctx.Param("partyId"), userID); err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"message": "invitation withdrawn"})
+}
+
+func (pc *LoanPartyController) AcceptInvitation(ctx *gin.Context) {
+	var req dtos.PartyResponseDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	pc.respond(ctx, true, req)
+}
+
+func (pc *LoanPartyController) DeclineInvitation(ctx *gin.Context) {
+	pc.respond(ctx, false, dtos.PartyResponseDTO{})
+}
+
+func (pc *LoanPartyController) respond(ctx *gin.Context, accept bool, req dtos.PartyResponseDTO) {
+	userID, _, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+
+	party, err := pc.partyUsecase.RespondToInvitation(ctx.Param("id"), userID, accept, req)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"party": party})
+}
@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/usecases"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IOfficerController interface {
+	GetQueue(ctx *gin.Context)
+	GetOfficers(ctx *gin.Context)
+	AssignLoan(ctx *gin.Context)
+	AssignPendingLoans(ctx *gin.Context)
+	ReassignOfficerLoans(ctx *gin.Context)
+	UpdateUserRole(ctx *gin.Context)
+}
+
+type OfficerController struct {
+	assignmentUsecase usecases.IAssignmentUsecase
+}
+
+func NewOfficerController(assignmentUsecase usecases.IAssignmentUsecase) IOfficerController {
+	return &OfficerController{
+		assignmentUsecase: assignmentUsecase,
+	}
+}
+
+// GetQueue returns the caller's review queue. Admins can look at any
+// officer's queue with officer_id.
+func (oc *OfficerController) GetQueue(ctx *gin.Context) {
+	userID, role, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+	officerID := userID
+	if strings.EqualFold(role, models.RoleAdmin) && ctx.Query("officer_id") != "" {
+		officerID = ctx.Query("officer_id")
+	}
+
+	queue, err := oc.assignmentUsecase.GetQueue(officerID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"loans": queue})
+}
+
+func (oc *OfficerController) GetOfficers(ctx *gin.Context) {
+	officers, err := oc.assignmentUsecase.GetOfficers()
+	if err != nil {
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"officers": officers})
+}
+
+func (oc *OfficerController) AssignLoan(ctx *gin.Context) {
+	var req dtos.LoanAssignmentDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	adminID, _, _ := getClaims(ctx)
+
+	assignment, err := oc.assignmentUsecase.AssignLoan(ctx.Param("id"), adminID, req)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"assignment": assignment})
+}
+
+func (oc *OfficerController) AssignPendingLoans(ctx *gin.Context) {
+	var req dtos.LoanAssignmentDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	adminID, _, _ := getClaims(ctx)
+
+	assigned, err := oc.assignmentUsecase.AssignPendingLoans(adminID, req.Strategy)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error(), "assigned": assigned})
+		return
+	}
+	ctx.JSON(200, gin.H{"assigned": assigned})
+}
+
+func (oc *OfficerController) ReassignOfficerLoans(ctx *gin.Context) {
+	var req dtos.LoanAssignmentDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	adminID, _, _ := getClaims(ctx)
+
+	assigned, err := oc.assignmentUsecase.ReassignOfficerLoans(ctx.Param("id"), adminID, req.Strategy)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error(), "reassigned": assigned})
+		return
+	}
+	ctx.JSON(200, gin.H{"reassigned": assigned})
+}
+
+func (oc *OfficerController) UpdateUserRole(ctx *gin.Context) {
+	var req dtos.RoleUpdateDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	adminID, _, _ := getClaims(ctx)
+
+	if err := oc.assignmentUsecase.UpdateUserRole(ctx.Param("id"), adminID, req.Role); err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"message": "User role updated"})
+}
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IProductController interface {
+	CreateProduct(ctx *gin.Context)
+	GetProducts(ctx *gin.Context)
+	GetActiveProducts(ctx *gin.Context)
+	GetProduct(ctx *gin.Context)
+	UpdateProduct(ctx *gin.Context)
+	ArchiveProduct(ctx *gin.Context)
+}
+
+type ProductController struct {
+	productUsecase usecases.IProductUsecase
+}
+
+func NewProductController(productUsecase usecases.IProductUsecase) IProductController {
+	return &ProductController{
+		productUsecase: productUsecase,
+	}
+}
+
+func (pc *ProductController) CreateProduct(ctx *gin.Context) {
+	var product models.LoanProduct
+	if err := ctx.ShouldBindJSON(&product); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	adminID, _, _ := getClaims(ctx)
+
+	result, err := pc.productUsecase.CreateProduct(adminID, &product)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(201, gin.H{"product": result})
+}
+
+func (pc *ProductController) GetProducts(ctx *gin.Context) {
+	products, err := pc.productUsecase.GetProducts(ctx.Query("active") == "true")
+	if err != nil {
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"products": products})
+}
+
+func (pc *ProductController) GetActiveProducts(ctx *gin.Context) {
+	products, err := pc.productUsecase.GetProducts(true)
+	if err != nil {
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"products": products})
+}
+
+func (pc *ProductController) GetProduct(ctx *gin.Context) {
+	product, err := pc.productUsecase.GetProduct(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(404, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"product": product})
+}
+
+func (pc *ProductController) UpdateProduct(ctx *gin.Context) {
+	var product models.LoanProduct
+	if err := ctx.ShouldBindJSON(&product); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	adminID, _, _ := getClaims(ctx)
+
+	result, err := pc.productUsecase.UpdateProduct(adminID, ctx.Param("id"), &product)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"product": result})
+}
+
+func (pc *ProductController) ArchiveProduct(ctx *gin.Context) {
+	adminID, _, _ := getClaims(ctx)
+
+	if err := pc.productUsecase.ArchiveProduct(adminID, ctx.Param("id")); err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"message": "Product archived"})
+}
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IReasonCodeController interface {
+	CreateReasonCode(ctx *gin.Context)
+	GetReasonCodes(ctx *gin.Context)
+	UpdateReasonCode(ctx *gin.Context)
+	DeactivateReasonCode(ctx *gin.Context)
+}
+
+type ReasonCodeController struct {
+	reasonCodeUsecase usecases.IReasonCodeUsecase
+}
+
+func NewReasonCodeController(reasonCodeUsecase usecases.IReasonCodeUsecase) IReasonCodeController {
+	return &ReasonCodeController{
+		reasonCodeUsecase: reasonCodeUsecase,
+	}
+}
+
+func (rc *ReasonCodeController) CreateReasonCode(ctx *gin.Context) {
+	var reasonCode models.ReasonCode
+	if err := ctx.ShouldBindJSON(&reasonCode); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	adminID, _, _ := getClaims(ctx)
+
+	result, err := rc.reasonCodeUsecase.CreateReasonCode(adminID, &reasonCode)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(201, gin.H{"reason_code": result})
+}
+
+func (rc *ReasonCodeController) GetReasonCodes(ctx *gin.Context) {
+	reasonCodes, err := rc.reasonCodeUsecase.GetReasonCodes(ctx.Query("active") == "true")
+	if err != nil {
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"reason_codes": reasonCodes})
+}
+
+func (rc *ReasonCodeController) UpdateReasonCode(ctx *gin.Context) {
+	var reasonCode models.ReasonCode
+	if err := ctx.ShouldBindJSON(&reasonCode); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	adminID, _, _ := getClaims(ctx)
+
+	result, err := rc.reasonCodeUsecase.UpdateReasonCode(adminID, ctx.Param("code"), &reasonCode)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"reason_code": result})
+}
+
+func (rc *ReasonCodeController) DeactivateReasonCode(ctx *gin.Context) {
+	adminID, _, _ := getClaims(ctx)
+
+	if err := rc.reasonCodeUsecase.DeactivateReasonCode(adminID, ctx.Param("code")); err != nil {
+		ctx.JSON(404, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"message": "Reason code deactivated"})
+}
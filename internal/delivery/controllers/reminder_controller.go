@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"LoanGuard/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IReminderController interface {
+	GetReminders(ctx *gin.Context)
+}
+
+type ReminderController struct {
+	reminderUsecase usecases.IReminderUsecase
+}
+
+func NewReminderController(reminderUsecase usecases.IReminderUsecase) IReminderController {
+	return &ReminderController{
+		reminderUsecase: reminderUsecase,
+	}
+}
+
+func (rc *ReminderController) GetReminders(ctx *gin.Context) {
+	userID, role, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+	reminders, err := rc.reminderUsecase.GetReminders(ctx.Param("id"), userID, role)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, reminders)
+}
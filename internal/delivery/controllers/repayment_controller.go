@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/usecases"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IRepaymentController interface {
+	RecordRepayment(ctx *gin.Context)
+	GetRepayments(ctx *gin.Context)
+	GetLedger(ctx *gin.Context)
+	GetPayoffQuote(ctx *gin.Context)
+}
+
+type RepaymentController struct {
+	repaymentUsecase usecases.IRepaymentUsecase
+}
+
+func NewRepaymentController(repaymentUsecase usecases.IRepaymentUsecase) IRepaymentController {
+	return &RepaymentController{
+		repaymentUsecase: repaymentUsecase,
+	}
+}
+
+func (rc *RepaymentController) RecordRepayment(ctx *gin.Context) {
+	var repayment models.Repayment
+	if err := ctx.ShouldBindJSON(&repayment); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+
+	userID, role, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+
+	channel := models.RepaymentChannelAdmin
+	if strings.EqualFold(role, "INTEGRATION") {
+		channel = models.RepaymentChannelIntegration
+		userID = models.RepaymentChannelIntegration
+	}
+
+	result, err := rc.repaymentUsecase.RecordRepayment(ctx.Param("id"), userID, channel, &repayment)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(201, gin.H{"repayment": result})
+}
+
+func (rc *RepaymentController) GetRepayments(ctx *gin.Context) {
+	repayments, err := rc.repaymentUsecase.GetRepayments(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"repayments": repayments})
+}
+
+func (rc *RepaymentController) GetLedger(ctx *gin.Context) {
+	entries, err := rc.repaymentUsecase.GetLedger(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"ledger": entries})
+}
+
+// GetPayoffQuote takes an optional ?date= in YYYY-MM-DD format, today when it
+// is left out.
+func (rc *RepaymentController) GetPayoffQuote(ctx *gin.Context) {
+	var date time.Time
+	if param := ctx.Query("date"); param != "" {
+		var err error
+		if date, err = time.Parse("2006-01-02", param); err != nil {
+			ctx.JSON(400, gin.H{"error": "date must be in YYYY-MM-DD format"})
+			return
+		}
+	}
+	quote, err := rc.repaymentUsecase.GetPayoffQuote(ctx.Param("id"), date)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, quote)
+}
@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/usecases"
+	"bytes"
+	"html/template"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type IRestructureController interface {
+	ProposeRestructure(ctx *gin.Context)
+	GetRestructures(ctx *gin.Context)
+	ReviewRestructure(ctx *gin.Context)
+	AcceptRestructure(ctx *gin.Context)
+	DeclineRestructure(ctx *gin.Context)
+}
+
+type RestructureController struct {
+	restructureUsecase usecases.IRestructureUsecase
+}
+
+func NewRestructureController(restructureUsecase usecases.IRestructureUsecase) IRestructureController {
+	return &RestructureController{
+		restructureUsecase: restructureUsecase,
+	}
+}
+
+func (rc *RestructureController) ProposeRestructure(ctx *gin.Context) {
+	var req dtos.RestructureDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	userID, _, ok := getClaims(ctx)
+	if !ok {
+		ctx.JSON(400, gin.H{"error": "Failed to parse claims"})
+		return
+	}
+
+	restructure, err := rc.restructureUsecase.ProposeRestructure(ctx.Param("id"), userID, req)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(201, restructure)
+}
+
+func (rc *RestructureController) GetRestructures(ctx *gin.Context) {
+	restructures, err := rc.restructureUsecase.GetRestructures(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"restructures": restructures})
+}
+
+// ReviewRestructure is the page the emailed link opens. It shows the offer
+// to the holder of the token and lets them accept or decline it, which posts
+// the token back; opening the link changes nothing.
+func (rc *RestructureController) ReviewRestructure(ctx *gin.Context) {
+	token := ctx.Query("token")
+	restructure, err := rc.restructureUsecase.GetRestructureOffer(ctx.Param("restructureId"), token)
+	if err != nil {
+		renderRestructurePage(ctx, errorStatus(err), restructurePage{Message: err.Error()})
+		return
+	}
+	page := restructurePage{Restructure: restructure, Token: token}
+	if restructure.Status != models.RestructureProposed {
+		page.Message = "This offer was already " + restructure.Status + "."
+	}
+	renderRestructurePage(ctx, 200, page)
+}
+
+// AcceptRestructure and DeclineRestructure take the token from the emailed
+// link in the body, where it stands in for logging in. Answers posted from
+// the review page get a page back, others JSON.
+func (rc *RestructureController) AcceptRestructure(ctx *gin.Context) {
+	rc.respond(ctx, true)
+}
+
+func (rc *RestructureController) DeclineRestructure(ctx *gin.Context) {
+	rc.respond(ctx, false)
+}
+
+func (rc *RestructureController) respond(ctx *gin.Context, accept bool) {
+	fromPage := ctx.ContentType() == binding.MIMEPOSTForm
+	var req dtos.RestructureResponseDTO
+	if err := ctx.ShouldBind(&req); err != nil || req.Token == "" {
+		if fromPage {
+			renderRestructurePage(ctx, 400, restructurePage{Message: "Missing token"})
+			return
+		}
+		ctx.JSON(400, gin.H{"error": "Missing token"})
+		return
+	}
+	restructure, err := rc.restructureUsecase.RespondToRestructure(ctx.Param("restructureId"), req.Token, accept)
+	if fromPage {
+		if err != nil {
+			renderRestructurePage(ctx, errorStatus(err), restructurePage{Message: err.Error()})
+			return
+		}
+		renderRestructurePage(ctx, 200, restructurePage{Message: "Thank you, the offer was " + restructure.Status + "."})
+		return
+	}
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, restructure)
+}
+
+type restructurePage struct {
+	Restructure *models.Restructure
+	Token       string
+	Message     string
+}
+
+func renderRestructurePage(ctx *gin.Context, status int, page restructurePage) {
+	var body bytes.Buffer
+	if err := restructurePageTemplate.Execute(&body, page); err != nil {
+		ctx.JSON(500, gin.H{"error": "Failed to render page"})
+		return
+	}
+	ctx.Data(status, "text/html; charset=utf-8", body.Bytes())
+}
+
+var restructurePageTemplate = template.Must(template.New("restructure").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <meta name="referrer" content="no-referrer">
+    <title>Loan Restructure Offer</title>
+</head>
+<body>
+    <h1>Loan Restructure Offer</h1>
+    {{if .Message}}<p>{{.Message}}</p>{{end}}
+    {{with .Restructure}}{{if eq .Status "proposed"}}
+    <table>
+        <tr><th></th><th>Current</th><th>Proposed</th></tr>
+        <tr><td>Installment</td><td>{{.Before.Installment}}</td><td>{{.After.Installment}}</td></tr>
+        <tr><td>Installments left</td><td>{{.Before.RemainingInstallments}}</td><td>{{.After.RemainingInstallments}}</td></tr>
+        <tr><td>Final payment</td><td>{{.Before.MaturityDate.Format "2006-01-02"}}</td><td>{{.After.MaturityDate.Format "2006-01-02"}}</td></tr>
+    </table>
+    <p>The offer is open until {{.ExpiresAt.Format "2006-01-02 15:04 MST"}}.</p>
+    <form method="post" action="{{.ID.Hex}}/accept">
+        <input type="hidden" name="token" value="{{$.Token}}">
+        <button type="submit">Accept New Terms</button>
+    </form>
+    <form method="post" action="{{.ID.Hex}}/decline">
+        <input type="hidden" name="token" value="{{$.Token}}">
+        <button type="submit">Decline</button>
+    </form>
+    {{end}}{{end}}
+</body>
+</html>
+`))
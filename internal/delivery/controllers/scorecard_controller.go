@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IScorecardController interface {
+	GetScorecard(ctx *gin.Context)
+	UpdateScorecard(ctx *gin.Context)
+}
+
+type ScorecardController struct {
+	scorecardUsecase usecases.IScorecardUsecase
+}
+
+func NewScorecardController(scorecardUsecase usecases.IScorecardUsecase) IScorecardController {
+	return &ScorecardController{
+		scorecardUsecase: scorecardUsecase,
+	}
+}
+
+func (sc *ScorecardController) GetScorecard(ctx *gin.Context) {
+	scorecard, err := sc.scorecardUsecase.GetScorecard()
+	if err != nil {
+		ctx.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"scorecard": scorecard})
+}
+
+func (sc *ScorecardController) UpdateScorecard(ctx *gin.Context) {
+	var scorecard models.Scorecard
+	if err := ctx.ShouldBindJSON(&scorecard); err != nil {
+		ctx.JSON(400, gin.H{"message": "invalid json format"})
+		return
+	}
+	adminID, _, _ := getClaims(ctx)
+
+	result, err := sc.scorecardUsecase.UpdateScorecard(adminID, &scorecard)
+	if err != nil {
+		ctx.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(200, gin.H{"scorecard": result})
+}
@@ -4,7 +4,6 @@ import (
 	"LoanGuard/internal/domain/dtos"
 	"LoanGuard/internal/domain/models"
 	"LoanGuard/internal/usecases"
-	"strconv"
 
 	"net/http"
 	"github.com/gin-gonic/gin"
@@ -137,14 +136,6 @@ func (uc *UserController) UpdateProfile(ctx *gin.Context){
 	if bio := ctx.PostForm("bio"); bio != "" {
 		updateData.Bio = bio
 	}
-	if ageStr := ctx.PostForm("age"); ageStr != "" {
-		age, err := strconv.Atoi(ageStr)
-		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid age format"})
-			return
-		}
-		updateData.Age = age
-	}
 
 	image, _, err := ctx.Request.FormFile("profile_picture")
 	if err != nil && err != http.ErrMissingFile {
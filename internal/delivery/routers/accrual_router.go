@@ -0,0 +1,12 @@
+package routers
+
+import (
+	"LoanGuard/internal/delivery/controllers"
+	"LoanGuard/internal/infrastructures/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CreateAccrualRouter(router *gin.Engine, accrualController controllers.IAccrualController, authMiddleware middlewares.IAuthMiddleware) {
+	router.POST("/admin/accruals/run", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), accrualController.RunAccrual)
+}
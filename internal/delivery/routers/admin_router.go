@@ -12,6 +12,7 @@ func CreateAdminRouter(router *gin.Engine, adminController controllers.IAdminCon
 	router.DELETE("/admin/users/:id", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), adminController.DeleteUser)
 	router.GET("/admin/loans", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), adminController.GetLoans)
 	router.PATCH("/admin/:id/status", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), adminController.AcceptOrRejectLoan)
+	router.PATCH("/admin/loans/:id/transition", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), adminController.TransitionLoan)
 	router.DELETE("/admin/loans/:id", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), adminController.DeleteLoan)
 	router.GET("/admin/logs", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), adminController.GetSystemLogs)
 }
@@ -0,0 +1,15 @@
+package routers
+
+import (
+	"LoanGuard/internal/delivery/controllers"
+	"LoanGuard/internal/infrastructures/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CreateCollateralRouter(router *gin.Engine, collateralController controllers.ICollateralController, authMiddleware middlewares.IAuthMiddleware, loanAccess middlewares.ILoanAccessMiddleware) {
+	router.POST("/loan/:id/collateral", authMiddleware.Authentication(), loanAccess.OwnerOrAdmin(), collateralController.AddCollateral)
+	router.GET("/loan/:id/collateral", authMiddleware.Authentication(), loanAccess.PartyOr("ADMIN", "OFFICER"), collateralController.GetCollateral)
+	router.DELETE("/loan/:id/collateral/:collateralId", authMiddleware.Authentication(), loanAccess.OwnerOrAdmin(), collateralController.RemoveCollateral)
+	router.PUT("/admin/loans/:id/collateral/:collateralId", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN", "OFFICER"), collateralController.UpdateCollateral)
+}
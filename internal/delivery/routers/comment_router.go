@@ -0,0 +1,14 @@
+package routers
+
+import (
+	"LoanGuard/internal/delivery/controllers"
+	"LoanGuard/internal/infrastructures/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CreateCommentRouter(router *gin.Engine, commentController controllers.ICommentController, authMiddleware middlewares.IAuthMiddleware, loanAccess middlewares.ILoanAccessMiddleware) {
+	router.GET("/loan/:id/comments", authMiddleware.Authentication(), loanAccess.PartyOr("ADMIN", "OFFICER"), commentController.GetComments)
+	router.POST("/loan/:id/comments", authMiddleware.Authentication(), loanAccess.OwnerOr("ADMIN", "OFFICER"), commentController.AddComment)
+	router.PATCH("/loan/:id/comments/:commentId", authMiddleware.Authentication(), loanAccess.OwnerOr("ADMIN", "OFFICER"), commentController.EditComment)
+}
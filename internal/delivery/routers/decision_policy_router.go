@@ -0,0 +1,14 @@
+package routers
+
+import (
+	"LoanGuard/internal/delivery/controllers"
+	"LoanGuard/internal/infrastructures/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CreateDecisionPolicyRouter(router *gin.Engine, policyController controllers.IDecisionPolicyController, authMiddleware middlewares.IAuthMiddleware) {
+	router.GET("/admin/decision-policy", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), policyController.GetPolicy)
+	router.PUT("/admin/decision-policy", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), policyController.UpdatePolicy)
+	router.POST("/admin/decision-policy/dry-run", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), policyController.DryRun)
+}
@@ -0,0 +1,12 @@
+package routers
+
+import (
+	"LoanGuard/internal/delivery/controllers"
+	"LoanGuard/internal/infrastructures/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CreateDelinquencyRouter(router *gin.Engine, delinquencyController controllers.IDelinquencyController, authMiddleware middlewares.IAuthMiddleware) {
+	router.GET("/admin/loans/delinquent", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN", "OFFICER"), delinquencyController.GetDelinquentLoans)
+}
@@ -0,0 +1,14 @@
+package routers
+
+import (
+	"LoanGuard/internal/delivery/controllers"
+	"LoanGuard/internal/infrastructures/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CreateDisbursementRouter(router *gin.Engine, disbursementController controllers.IDisbursementController, authMiddleware middlewares.IAuthMiddleware) {
+	router.POST("/admin/loans/:id/disburse", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), disbursementController.DisburseLoan)
+	router.GET("/admin/loans/:id/disbursements", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), disbursementController.GetDisbursements)
+	router.POST("/admin/disbursements/:disbursementId/retry", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), disbursementController.RetryDisbursement)
+}
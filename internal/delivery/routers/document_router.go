@@ -0,0 +1,15 @@
+package routers
+
+import (
+	"LoanGuard/internal/delivery/controllers"
+	"LoanGuard/internal/infrastructures/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CreateDocumentRouter(router *gin.Engine, documentController controllers.IDocumentController, authMiddleware middlewares.IAuthMiddleware, loanAccess middlewares.ILoanAccessMiddleware) {
+	router.POST("/loan/:id/documents", authMiddleware.Authentication(), loanAccess.OwnerOrAdmin(), documentController.UploadDocument)
+	router.GET("/loan/:id/documents", authMiddleware.Authentication(), loanAccess.PartyOr("ADMIN", "OFFICER"), documentController.GetDocuments)
+	router.PATCH("/admin/loans/:id/documents/:documentId", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), documentController.ReviewDocument)
+	router.GET("/admin/loans/:id/documents/:documentId/file", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN", "OFFICER"), documentController.DownloadDocument)
+}
@@ -0,0 +1,13 @@
+package routers
+
+import (
+	"LoanGuard/internal/delivery/controllers"
+	"LoanGuard/internal/infrastructures/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CreateFxRouter(router *gin.Engine, fxController controllers.IFxController, authMiddleware middlewares.IAuthMiddleware) {
+	router.GET("/admin/fx-rates", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), fxController.GetRates)
+	router.POST("/admin/fx-rates", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), fxController.UploadRates)
+}
@@ -0,0 +1,16 @@
+package routers
+
+import (
+	"LoanGuard/internal/delivery/controllers"
+	"LoanGuard/internal/infrastructures/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CreateKYCRouter(router *gin.Engine, kycController controllers.IKYCController, authMiddleware middlewares.IAuthMiddleware) {
+	router.GET("/users/kyc", authMiddleware.Authentication(), kycController.GetMyKYC)
+	router.PUT("/users/kyc", authMiddleware.Authentication(), kycController.SubmitKYC)
+
+	router.GET("/admin/kyc", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), kycController.GetKYCQueue)
+	router.PATCH("/admin/users/:id/kyc", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), kycController.ReviewKYC)
+}
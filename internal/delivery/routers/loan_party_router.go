@@ -0,0 +1,18 @@
+package routers
+
+import (
+	"LoanGuard/internal/delivery/controllers"
+	"LoanGuard/internal/infrastructures/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateLoanPartyRouter registers guarantor and co-borrower routes. Nominees
+// are not on the loan yet, so the invitation routes only need a login and the
+// usecase matches the caller's email against the invitation.
+func CreateLoanPartyRouter(router *gin.Engine, partyController controllers.ILoanPartyController, authMiddleware middlewares.IAuthMiddleware, loanAccess middlewares.ILoanAccessMiddleware) {
+	router.POST("/loan/:id/parties", authMiddleware.Authentication(), loanAccess.OwnerOrAdmin(), partyController.InviteParty)
+	router.DELETE("/loan/:id/parties/:partyId", authMiddleware.Authentication(), loanAccess.OwnerOrAdmin(), partyController.WithdrawParty)
+	router.POST("/loan/:id/invitation/accept", authMiddleware.Authentication(), partyController.AcceptInvitation)
+	router.POST("/loan/:id/invitation/decline", authMiddleware.Authentication(), partyController.DeclineInvitation)
+}
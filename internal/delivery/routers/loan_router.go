@@ -7,7 +7,18 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func CreateLoanRouter(router *gin.Engine, loanController controllers.ILoanController, authMiddleware middlewares.IAuthMiddleware) {
+func CreateLoanRouter(router *gin.Engine, loanController controllers.ILoanController, repaymentController controllers.IRepaymentController, authMiddleware middlewares.IAuthMiddleware, loanAccess middlewares.ILoanAccessMiddleware) {
 	router.POST("/loan", authMiddleware.Authentication(),loanController.RequestLoan)
-	router.GET("/loan/:id", authMiddleware.Authentication(), loanController.ViewLoanStatus)
+	router.GET("/loans/me", authMiddleware.Authentication(), loanController.GetMyLoans)
+	router.GET("/loan/:id", authMiddleware.Authentication(), loanAccess.PartyOr("ADMIN", "OFFICER"), loanController.ViewLoanStatus)
+	router.POST("/loan/:id/submit", authMiddleware.Authentication(), loanAccess.OwnerOrAdmin(), loanController.SubmitLoan)
+	router.POST("/loan/:id/cancel", authMiddleware.Authentication(), loanAccess.OwnerOrAdmin(), loanController.CancelLoan)
+	router.GET("/loan/:id/schedule", authMiddleware.Authentication(), loanAccess.PartyOr("ADMIN", "OFFICER"), loanController.GetLoanSchedule)
+	router.GET("/loan/:id/schedules", authMiddleware.Authentication(), loanAccess.PartyOr("ADMIN", "OFFICER"), loanController.GetLoanSchedules)
+
+	//repayments
+	router.POST("/loan/:id/repayments", authMiddleware.IntegrationAuth(), authMiddleware.RoleAuth("ADMIN", "INTEGRATION"), loanAccess.OwnerOr("ADMIN", "INTEGRATION"), repaymentController.RecordRepayment)
+	router.GET("/loan/:id/repayments", authMiddleware.Authentication(), loanAccess.PartyOr("ADMIN"), repaymentController.GetRepayments)
+	router.GET("/loan/:id/ledger", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), repaymentController.GetLedger)
+	router.GET("/loan/:id/payoff-quote", authMiddleware.Authentication(), loanAccess.PartyOr("ADMIN", "OFFICER"), repaymentController.GetPayoffQuote)
 }
@@ -0,0 +1,18 @@
+package routers
+
+import (
+	"LoanGuard/internal/delivery/controllers"
+	"LoanGuard/internal/infrastructures/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CreateOfficerRouter(router *gin.Engine, officerController controllers.IOfficerController, authMiddleware middlewares.IAuthMiddleware) {
+	router.GET("/officer/queue", authMiddleware.Authentication(), authMiddleware.RoleAuth("OFFICER", "ADMIN"), officerController.GetQueue)
+
+	router.GET("/admin/officers", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), officerController.GetOfficers)
+	router.POST("/admin/officers/:id/reassign", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), officerController.ReassignOfficerLoans)
+	router.POST("/admin/loans/assign", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), officerController.AssignPendingLoans)
+	router.PUT("/admin/loans/:id/assignment", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), officerController.AssignLoan)
+	router.PATCH("/admin/users/:id/role", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), officerController.UpdateUserRole)
+}
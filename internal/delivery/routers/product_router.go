@@ -0,0 +1,18 @@
+package routers
+
+import (
+	"LoanGuard/internal/delivery/controllers"
+	"LoanGuard/internal/infrastructures/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CreateProductRouter(router *gin.Engine, productController controllers.IProductController, authMiddleware middlewares.IAuthMiddleware) {
+	router.GET("/products", authMiddleware.Authentication(), productController.GetActiveProducts)
+
+	router.GET("/admin/products", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), productController.GetProducts)
+	router.POST("/admin/products", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), productController.CreateProduct)
+	router.GET("/admin/products/:id", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), productController.GetProduct)
+	router.PUT("/admin/products/:id", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), productController.UpdateProduct)
+	router.DELETE("/admin/products/:id", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), productController.ArchiveProduct)
+}
@@ -0,0 +1,15 @@
+package routers
+
+import (
+	"LoanGuard/internal/delivery/controllers"
+	"LoanGuard/internal/infrastructures/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CreateReasonCodeRouter(router *gin.Engine, reasonCodeController controllers.IReasonCodeController, authMiddleware middlewares.IAuthMiddleware) {
+	router.GET("/admin/reason-codes", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), reasonCodeController.GetReasonCodes)
+	router.POST("/admin/reason-codes", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), reasonCodeController.CreateReasonCode)
+	router.PUT("/admin/reason-codes/:code", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), reasonCodeController.UpdateReasonCode)
+	router.DELETE("/admin/reason-codes/:code", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), reasonCodeController.DeactivateReasonCode)
+}
@@ -0,0 +1,12 @@
+package routers
+
+import (
+	"LoanGuard/internal/delivery/controllers"
+	"LoanGuard/internal/infrastructures/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CreateReminderRouter(router *gin.Engine, reminderController controllers.IReminderController, authMiddleware middlewares.IAuthMiddleware, loanAccess middlewares.ILoanAccessMiddleware) {
+	router.GET("/loan/:id/reminders", authMiddleware.Authentication(), loanAccess.PartyOr("ADMIN", "OFFICER"), reminderController.GetReminders)
+}
@@ -0,0 +1,16 @@
+package routers
+
+import (
+	"LoanGuard/internal/delivery/controllers"
+	"LoanGuard/internal/infrastructures/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CreateRestructureRouter(router *gin.Engine, restructureController controllers.IRestructureController, authMiddleware middlewares.IAuthMiddleware) {
+	router.POST("/admin/loans/:id/restructures", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), restructureController.ProposeRestructure)
+	router.GET("/admin/loans/:id/restructures", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN", "OFFICER"), restructureController.GetRestructures)
+	router.GET("/restructures/:restructureId", restructureController.ReviewRestructure)
+	router.POST("/restructures/:restructureId/accept", restructureController.AcceptRestructure)
+	router.POST("/restructures/:restructureId/decline", restructureController.DeclineRestructure)
+}
@@ -0,0 +1,13 @@
+package routers
+
+import (
+	"LoanGuard/internal/delivery/controllers"
+	"LoanGuard/internal/infrastructures/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CreateScorecardRouter(router *gin.Engine, scorecardController controllers.IScorecardController, authMiddleware middlewares.IAuthMiddleware) {
+	router.GET("/admin/scorecard", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), scorecardController.GetScorecard)
+	router.PUT("/admin/scorecard", authMiddleware.Authentication(), authMiddleware.RoleAuth("ADMIN"), scorecardController.UpdateScorecard)
+}
@@ -0,0 +1,27 @@
+package dtos
+
+import (
+	"LoanGuard/internal/domain/models"
+	"time"
+)
+
+// LoanAssignmentDTO assigns a loan to OfficerID, or to an officer picked by
+// Strategy when no officer is named.
+type LoanAssignmentDTO struct {
+	OfficerID string `json:"officer_id"`
+	Strategy  string `json:"strategy"`
+	Reason    string `json:"reason"`
+}
+
+type RoleUpdateDTO struct {
+	Role string `json:"role"`
+}
+
+// QueueItemDTO is a loan in an officer's review queue with its review
+// deadline.
+type QueueItemDTO struct {
+	*models.Loan
+	SubmittedAt time.Time `json:"submitted_at"`
+	SLADueAt    time.Time `json:"sla_due_at"`
+	Overdue     bool      `json:"overdue"`
+}
@@ -0,0 +1,25 @@
+package dtos
+
+import "LoanGuard/internal/domain/models"
+
+// CollateralDTO records or re-appraises an asset pledged for a loan. The
+// appraisal date is YYYY-MM-DD and documents are ids of the loan's documents.
+type CollateralDTO struct {
+	Type           string       `json:"type"`
+	Description    string       `json:"description"`
+	AppraisedValue models.Money `json:"appraised_value"`
+	AppraisalDate  string       `json:"appraisal_date"`
+	Documents      []string     `json:"documents"`
+}
+
+// LoanCollateralDTO lists a loan's collateral with its loan-to-value ratio
+// against the product maximum. TotalValue and LTV count only appraised
+// collateral. LTV is omitted while nothing appraised is pledged and MaxLTV
+// for unsecured products.
+type LoanCollateralDTO struct {
+	Collateral  []models.Collateral `json:"collateral"`
+	TotalValue  models.Money        `json:"total_value"`
+	LTV         *float64            `json:"ltv,omitempty"`
+	MaxLTV      *float64            `json:"max_ltv,omitempty"`
+	WithinLimit bool                `json:"within_limit"`
+}
@@ -0,0 +1,6 @@
+package dtos
+
+type CommentDTO struct {
+	Body       string `json:"body"`
+	Visibility string `json:"visibility"`
+}
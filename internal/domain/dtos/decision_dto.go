@@ -0,0 +1,18 @@
+package dtos
+
+// DecisionDryRunDTO reports how a draft decision policy would have decided
+// one page of historical loans.
+type DecisionDryRunDTO struct {
+	Evaluated  int                     `json:"evaluated"`
+	Outcomes   map[string]int          `json:"outcomes"`
+	Loans      []DecisionDryRunLoanDTO `json:"loans"`
+	NextCursor string                  `json:"next_cursor"`
+}
+
+type DecisionDryRunLoanDTO struct {
+	LoanID   string   `json:"loan_id"`
+	Status   string   `json:"status"`
+	Outcome  string   `json:"outcome"`
+	Rule     string   `json:"rule,omitempty"`
+	RulesHit []string `json:"rules_hit"`
+}
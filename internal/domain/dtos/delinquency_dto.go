@@ -0,0 +1,18 @@
+package dtos
+
+import "LoanGuard/internal/domain/models"
+
+// DelinquentLoanDTO is a past-due loan with who to contact about it.
+type DelinquentLoanDTO struct {
+	*models.Loan
+	BorrowerName  string `json:"borrower_name"`
+	BorrowerEmail string `json:"borrower_email"`
+	BorrowerPhone string `json:"borrower_phone"`
+}
+
+// DelinquencyReportDTO lists past-due loans, most overdue first, with how
+// many loans each bucket holds.
+type DelinquencyReportDTO struct {
+	Buckets map[string]int      `json:"buckets"`
+	Loans   []DelinquentLoanDTO `json:"loans"`
+}
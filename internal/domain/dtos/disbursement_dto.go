@@ -0,0 +1,8 @@
+package dtos
+
+import "LoanGuard/internal/domain/models"
+
+// DisbursementDTO is where an approved loan is paid out to.
+type DisbursementDTO struct {
+	Destination models.PayoutDestination `json:"destination"`
+}
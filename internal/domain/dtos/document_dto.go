@@ -0,0 +1,16 @@
+package dtos
+
+import "LoanGuard/internal/domain/models"
+
+// DocumentReviewDTO verifies or rejects a loan document.
+type DocumentReviewDTO struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+// LoanDocumentsDTO lists a loan's documents against its product checklist.
+type LoanDocumentsDTO struct {
+	Checklist []models.ChecklistItem `json:"checklist"`
+	Complete  bool                   `json:"complete"`
+	Documents []models.LoanDocument  `json:"documents"`
+}
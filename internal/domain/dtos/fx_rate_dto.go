@@ -0,0 +1,11 @@
+package dtos
+
+import "time"
+
+// FXRateUploadDTO carries one day's rate table, e.g.
+// {"effective_date": "2024-09-01T00:00:00Z", "rates": {"ETB": "0.0087"}}.
+type FXRateUploadDTO struct {
+	BaseCurrency  string            `json:"base_currency"`
+	EffectiveDate time.Time         `json:"effective_date"`
+	Rates         map[string]string `json:"rates"`
+}
@@ -0,0 +1,31 @@
+package dtos
+
+import (
+	"LoanGuard/internal/domain/models"
+	"time"
+)
+
+// KYCSubmissionDTO is the identity information a borrower submits.
+// DateOfBirth is a YYYY-MM-DD date.
+type KYCSubmissionDTO struct {
+	NationalID    string            `json:"national_id"`
+	DateOfBirth   string            `json:"date_of_birth"`
+	Address       models.Address    `json:"address"`
+	Employment    models.Employment `json:"employment"`
+	MonthlyIncome models.Money      `json:"monthly_income"`
+}
+
+// KYCReviewDTO verifies or rejects a user's KYC details.
+type KYCReviewDTO struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+// KYCDTO shows a user's KYC details.
+type KYCDTO struct {
+	UserID      string            `json:"user_id"`
+	Name        string            `json:"name"`
+	Email       string            `json:"email"`
+	DateOfBirth *time.Time        `json:"date_of_birth,omitempty"`
+	KYC         models.KYCProfile `json:"kyc"`
+}
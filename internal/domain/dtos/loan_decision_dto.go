@@ -0,0 +1,8 @@
+package dtos
+
+// LoanDecisionDTO is an admin's approval or rejection of a loan.
+type LoanDecisionDTO struct {
+	Status     string `json:"status"`
+	ReasonCode string `json:"reason_code"`
+	Note       string `json:"note"`
+}
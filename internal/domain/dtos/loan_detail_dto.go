@@ -0,0 +1,123 @@
+package dtos
+
+import (
+	"LoanGuard/internal/domain/models"
+	"time"
+)
+
+// LoanDetailDTO is the whole loan record with its product and schedule, for
+// admins.
+type LoanDetailDTO struct {
+	*models.Loan
+	Product  *LoanProductSummaryDTO `json:"product,omitempty"`
+	Schedule *ScheduleSummaryDTO    `json:"schedule,omitempty"`
+	NextDue  *NextDueDTO            `json:"next_due,omitempty"`
+}
+
+// BorrowerLoanDetailDTO is what the borrower and accepted guarantors and
+// co-borrowers see about a loan.
+type BorrowerLoanDetailDTO struct {
+	LoanViewDTO
+	Product  *LoanProductSummaryDTO `json:"product,omitempty"`
+	Schedule *ScheduleSummaryDTO    `json:"schedule,omitempty"`
+	NextDue  *NextDueDTO            `json:"next_due,omitempty"`
+}
+
+// LoanViewDTO is the part of a loan shown to its borrower and parties. Review
+// internals such as the credit score, automated decision, approvals and
+// officer, and servicing internals such as delinquency and accrual, are left
+// out.
+type LoanViewDTO struct {
+	ID                   string            `json:"id"`
+	ProductID            string            `json:"product_id"`
+	Amount               models.Money      `json:"amount"`
+	Currency             string            `json:"currency"`
+	Interest             float64           `json:"interest"`
+	Total                models.Money      `json:"total"`
+	OriginationFee       models.Money      `json:"origination_fee"`
+	Term                 int               `json:"term"`
+	RepaymentMethod      string            `json:"repayment_method"`
+	Status               string            `json:"status"`
+	LoanPurpose          string            `json:"loan_purpose"`
+	CreatedAt            time.Time         `json:"created_at"`
+	OutstandingPrincipal models.Money      `json:"outstanding_principal"`
+	OutstandingInterest  models.Money      `json:"outstanding_interest"`
+	OutstandingFees      models.Money      `json:"outstanding_fees"`
+	OutstandingBalance   models.Money      `json:"outstanding_balance"`
+	Decision             *DecisionViewDTO  `json:"decision,omitempty"`
+	Parties              []PartyViewDTO    `json:"parties,omitempty"`
+	StatusHistory        []StatusChangeDTO `json:"status_history"`
+}
+
+// DecisionViewDTO is the decision on a loan without who made it.
+type DecisionViewDTO struct {
+	Status      string    `json:"status"`
+	ReasonCode  string    `json:"reason_code"`
+	ReasonLabel string    `json:"reason_label"`
+	Note        string    `json:"note,omitempty"`
+	DecidedAt   time.Time `json:"decided_at"`
+}
+
+// PartyViewDTO is a guarantor or co-borrower nomination. Email is only shown
+// to the borrower who made it.
+type PartyViewDTO struct {
+	ID          string     `json:"id"`
+	Email       string     `json:"email,omitempty"`
+	Role        string     `json:"role"`
+	Status      string     `json:"status"`
+	InvitedAt   time.Time  `json:"invited_at"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+}
+
+// StatusChangeDTO is a step in the loan's status history without its actor.
+type StatusChangeDTO struct {
+	From string    `json:"from"`
+	To   string    `json:"to"`
+	At   time.Time `json:"at"`
+}
+
+type LoanProductSummaryDTO struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Currency    string `json:"currency"`
+}
+
+type ScheduleSummaryDTO struct {
+	Method           string       `json:"method"`
+	Term             int          `json:"term"`
+	Installments     int          `json:"installments"`
+	PaidInstallments int          `json:"paid_installments"`
+	TotalInterest    models.Money `json:"total_interest"`
+	TotalPayment     models.Money `json:"total_payment"`
+	FinalDueDate     time.Time    `json:"final_due_date"`
+}
+
+type NextDueDTO struct {
+	Number    int          `json:"number"`
+	DueDate   time.Time    `json:"due_date"`
+	AmountDue models.Money `json:"amount_due"`
+}
+
+// MyLoansQueryDTO filters and pages the caller's own loans.
+type MyLoansQueryDTO struct {
+	Status      string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	Page        int
+	Limit       int
+}
+
+type MyLoansPageDTO struct {
+	Loans []MyLoanDTO `json:"loans"`
+	Page  int         `json:"page"`
+	Limit int         `json:"limit"`
+	Total int64       `json:"total"`
+}
+
+// MyLoanDTO is a loan in the caller's list with the role they hold on it:
+// "borrower", "guarantor" or "co_borrower".
+type MyLoanDTO struct {
+	LoanViewDTO
+	Role string `json:"role"`
+}
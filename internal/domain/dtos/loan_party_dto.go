@@ -0,0 +1,12 @@
+package dtos
+
+// PartyInvitationDTO names a guarantor or co-borrower by email.
+type PartyInvitationDTO struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// PartyResponseDTO carries the nominee's explicit consent when accepting.
+type PartyResponseDTO struct {
+	Consent bool `json:"consent"`
+}
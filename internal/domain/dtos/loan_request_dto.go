@@ -0,0 +1,19 @@
+package dtos
+
+import (
+	"LoanGuard/internal/domain/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LoanRequestDTO is what a borrower fills in to apply for a loan. Pricing,
+// status, review and servicing fields are all set by the server, and
+// guarantors and co-borrowers are added by invitation once the loan exists.
+type LoanRequestDTO struct {
+	ProductID             primitive.ObjectID `json:"product_id"`
+	Amount                models.Money       `json:"amount"`
+	Currency              string             `json:"currency"`
+	Term                  int                `json:"term"`
+	LoanPurpose           string             `json:"loan_purpose"`
+	DeclaredMonthlyIncome models.Money       `json:"declared_monthly_income"`
+}
@@ -0,0 +1,6 @@
+package dtos
+
+type LoanTransitionDTO struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
@@ -1,5 +1,6 @@
 package dtos
 
+import "time"
 
 type ProfileDTO struct {
 	ID				string `json:"id" bson:"_id"`
@@ -7,7 +8,8 @@ type ProfileDTO struct {
 	Email			string `json:"email" bson:"email"`
 	Role			string `json:"role" bson:"role"`
 	ProfilePicture	string `json:"profile_picture" bson:"profile_picture"`
-	Age				int    `json:"age" bson:"age"`
+	DateOfBirth		*time.Time `json:"date_of_birth,omitempty" bson:"date_of_birth,omitempty"`
+	KYCStatus		string `json:"kyc_status" bson:"kyc_status"`
 	PhoneNum		string `json:"phone_num" bson:"phone_num"`
 	Bio				string `json:"bio" bson:"bio"`
 }
\ No newline at end of file
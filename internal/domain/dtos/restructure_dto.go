@@ -0,0 +1,18 @@
+package dtos
+
+// RestructureDTO is an admin's restructure offer. A missing annual_rate
+// keeps the loan's current rate.
+type RestructureDTO struct {
+	ExtendMonths      int      `json:"extend_months"`
+	AnnualRate        *float64 `json:"annual_rate"`
+	CapitalizeArrears bool     `json:"capitalize_arrears"`
+	HolidayMonths     int      `json:"holiday_months"`
+	Note              string   `json:"note"`
+}
+
+// RestructureResponseDTO carries the token from the emailed offer link when
+// the borrower accepts or declines, posted from the review page's form or as
+// JSON.
+type RestructureResponseDTO struct {
+	Token string `json:"token" form:"token"`
+}
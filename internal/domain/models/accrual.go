@@ -0,0 +1,50 @@
+package models
+
+import (
+	"math/big"
+	"time"
+)
+
+// Day count conventions: how many days interest runs for between two dates
+// and how many make a year.
+const (
+	DayCountActual365 = "actual/365"
+	DayCountActual360 = "actual/360"
+	DayCount30360     = "30/360"
+)
+
+var DayCountConventions = []string{DayCountActual365, DayCountActual360, DayCount30360}
+
+// AccruingStatuses are the statuses a loan earns interest in. Defaulted
+// loans are non-accrual.
+var AccruingStatuses = []string{LoanStatusDisbursed, LoanStatusActive}
+
+// YearFraction is the part of a year between two dates under the
+// convention. Under 30/360 every month counts as 30 days, with the 31st
+// treated as the 30th.
+func YearFraction(convention string, from, to time.Time) *big.Rat {
+	switch convention {
+	case DayCountActual360:
+		return big.NewRat(int64(DaysBetween(from, to)), 360)
+	case DayCount30360:
+		d1, d2 := from.Day(), to.Day()
+		if d1 == 31 {
+			d1 = 30
+		}
+		if d2 == 31 && d1 == 30 {
+			d2 = 30
+		}
+		days := (to.Year()-from.Year())*360 + (int(to.Month())-int(from.Month()))*30 + d2 - d1
+		return big.NewRat(int64(days), 360)
+	default:
+		return big.NewRat(int64(DaysBetween(from, to)), 365)
+	}
+}
+
+// InterestAccrual is how far the daily accrual has recognised a loan's
+// interest as income.
+type InterestAccrual struct {
+	AccruedThrough  time.Time `json:"accrued_through" bson:"accrued_through"`
+	AccruedInterest Money     `json:"accrued_interest" bson:"accrued_interest"`
+	DayCount        string    `json:"day_count" bson:"day_count"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	RoleAdmin   = "ADMIN"
+	RoleUser    = "USER"
+	RoleOfficer = "OFFICER"
+)
+
+// IsRole reports whether role is one of the roles a user can hold.
+func IsRole(role string) bool {
+	switch strings.ToUpper(role) {
+	case RoleAdmin, RoleUser, RoleOfficer:
+		return true
+	}
+	return false
+}
+
+// IsStaffRole reports whether role belongs to the lender's staff.
+func IsStaffRole(role string) bool {
+	return strings.EqualFold(role, RoleAdmin) || strings.EqualFold(role, RoleOfficer)
+}
+
+// Ways a loan can be given to an officer. Manual assignment names the
+// officer, round robin picks the officer who went longest without a new loan
+// and least loaded the one with the fewest loans under review.
+const (
+	AssignmentManual      = "manual"
+	AssignmentRoundRobin  = "round_robin"
+	AssignmentLeastLoaded = "least_loaded"
+)
+
+var (
+	ErrNoOfficers        = errors.New("no loan officers available")
+	ErrInvalidAssignment = errors.New("invalid assignment")
+)
+
+// ReviewStatuses are the statuses in which a loan waits on its officer.
+var ReviewStatuses = []string{LoanStatusPending, LoanStatusUnderReview, LoanStatusPendingSecondApproval}
+
+// LoanAssignment names the officer working a loan.
+type LoanAssignment struct {
+	OfficerID  string    `json:"officer_id" bson:"officer_id"`
+	Strategy   string    `json:"strategy" bson:"strategy"`
+	AssignedBy string    `json:"assigned_by" bson:"assigned_by"`
+	AssignedAt time.Time `json:"assigned_at" bson:"assigned_at"`
+}
+
+// OfficerWorkload is the number of loans an officer has under review and
+// when they were last given one.
+type OfficerWorkload struct {
+	OfficerID      string    `json:"officer_id" bson:"_id"`
+	Name           string    `json:"name" bson:"-"`
+	OpenLoans      int       `json:"open_loans" bson:"open_loans"`
+	LastAssignedAt time.Time `json:"last_assigned_at" bson:"last_assigned_at"`
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollateralTypeRealEstate = "real_estate"
+	CollateralTypeVehicle    = "vehicle"
+	CollateralTypeEquipment  = "equipment"
+	CollateralTypeDeposit    = "deposit"
+	CollateralTypeSecurities = "securities"
+	CollateralTypeOther      = "other"
+)
+
+// A lien is pending while the loan is applied for, active once the loan is
+// approved and released when the loan closes or is cancelled.
+const (
+	LienStatusPending  = "pending"
+	LienStatusActive   = "active"
+	LienStatusReleased = "released"
+)
+
+var (
+	ErrCollateralNotFound = errors.New("collateral not found")
+	ErrInvalidCollateral  = errors.New("invalid collateral")
+	ErrLTVExceeded        = errors.New("the loan's collateral does not cover it within the product's maximum loan-to-value")
+)
+
+// Collateral is an asset pledged as security for a loan. Documents are the
+// ids of loan documents that evidence it, such as a title deed or an
+// appraisal report.
+type Collateral struct {
+	ID             primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
+	LoanID         primitive.ObjectID   `json:"loan_id" bson:"loan_id"`
+	Type           string               `json:"type" bson:"type"`
+	Description    string               `json:"description" bson:"description"`
+	AppraisedValue Money                `json:"appraised_value" bson:"appraised_value"`
+	AppraisalDate  time.Time            `json:"appraisal_date" bson:"appraisal_date"`
+	Documents      []primitive.ObjectID `json:"documents" bson:"documents"`
+	LienStatus     string               `json:"lien_status" bson:"lien_status"`
+	LienActiveAt   *time.Time           `json:"lien_active_at,omitempty" bson:"lien_active_at,omitempty"`
+	LienReleasedAt *time.Time           `json:"lien_released_at,omitempty" bson:"lien_released_at,omitempty"`
+
+	// set once an admin or officer has appraised the asset; until then the
+	// value is the borrower's own and does not secure the loan
+	AppraisedBy string     `json:"appraised_by,omitempty" bson:"appraised_by,omitempty"`
+	AppraisedAt *time.Time `json:"appraised_at,omitempty" bson:"appraised_at,omitempty"`
+
+	CreatedBy      string               `json:"created_by" bson:"created_by"`
+	CreatedAt      time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func ValidCollateralType(collateralType string) bool {
+	switch collateralType {
+	case CollateralTypeRealEstate, CollateralTypeVehicle, CollateralTypeEquipment,
+		CollateralTypeDeposit, CollateralTypeSecurities, CollateralTypeOther:
+		return true
+	}
+	return false
+}
+
+// Appraised reports whether staff have appraised the asset.
+func (c Collateral) Appraised() bool {
+	return c.AppraisedBy != ""
+}
+
+// CollateralValue sums the appraised value of the collateral that staff have
+// appraised and whose lien has not been released. Every item must be in the
+// currency given.
+func CollateralValue(collateral []Collateral, currency string) Money {
+	total := NewMoney(0, currency)
+	for _, item := range collateral {
+		if item.Appraised() && item.LienStatus != LienStatusReleased {
+			total = total.Add(item.AppraisedValue)
+		}
+	}
+	return total
+}
+
+// LoanToValue is amount divided by value, rounded to four decimal places.
+// It is false when there is no value to secure the amount.
+func LoanToValue(amount Money, value Money) (float64, bool) {
+	if !value.IsNegative() && !value.IsZero() && amount.Currency == value.Currency {
+		return math.Round(float64(amount.Amount)/float64(value.Amount)*10000) / 10000, true
+	}
+	return 0, false
+}
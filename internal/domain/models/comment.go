@@ -0,0 +1,37 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Comment visibilities. Internal comments are only shown to staff.
+const (
+	CommentVisibilityInternal = "internal"
+	CommentVisibilityBorrower = "borrower"
+)
+
+// CommentEditWindow is how long the author can still change a comment.
+const CommentEditWindow = 5 * time.Minute
+
+var (
+	ErrCommentNotFound   = errors.New("comment not found")
+	ErrInvalidComment    = errors.New("invalid comment")
+	ErrCommentEditClosed = errors.New("comments can only be edited by their author within 5 minutes")
+)
+
+// Comment is a note on a loan application. Mentions holds the ids of the
+// staff members mentioned in Body.
+type Comment struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	LoanID     primitive.ObjectID `json:"loan_id" bson:"loan_id"`
+	AuthorID   string             `json:"author_id" bson:"author_id"`
+	AuthorName string             `json:"author_name" bson:"author_name"`
+	Body       string             `json:"body" bson:"body"`
+	Visibility string             `json:"visibility" bson:"visibility"`
+	Mentions   []string           `json:"mentions,omitempty" bson:"mentions,omitempty"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+	EditedAt   *time.Time         `json:"edited_at,omitempty" bson:"edited_at,omitempty"`
+}
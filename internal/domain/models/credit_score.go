@@ -0,0 +1,110 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CreditScore is the outcome of scoring a loan application. Reasons lists
+// every rule that contributed, so reviewers can see how the score came about.
+type CreditScore struct {
+	Score            int           `json:"score" bson:"score"`
+	Band             string        `json:"band" bson:"band"`
+	Scorer           string        `json:"scorer" bson:"scorer"`
+	ScorecardVersion int           `json:"scorecard_version,omitempty" bson:"scorecard_version,omitempty"`
+	Reasons          []ScoreReason `json:"reasons" bson:"reasons"`
+	ScoredAt         time.Time     `json:"scored_at" bson:"scored_at"`
+}
+
+type ScoreReason struct {
+	Rule   string `json:"rule" bson:"rule"`
+	Points int    `json:"points" bson:"points"`
+	Detail string `json:"detail" bson:"detail"`
+}
+
+// ThresholdRule awards Points to values up to and including UpTo. Rules are
+// checked in ascending UpTo order; values above every threshold get the
+// points of the last rule.
+type ThresholdRule struct {
+	UpTo   float64 `json:"up_to" bson:"up_to"`
+	Points int     `json:"points" bson:"points"`
+}
+
+// ScoreBand names the scores from MinScore up to the next band.
+type ScoreBand struct {
+	Name     string `json:"name" bson:"name"`
+	MinScore int    `json:"min_score" bson:"min_score"`
+}
+
+// Scorecard configures the built-in rules scorer. Every change is stored as a
+// new version so a loan's score can be traced back to the card that made it.
+type Scorecard struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Version   int                `json:"version" bson:"version"`
+	BaseScore int                `json:"base_score" bson:"base_score"`
+
+	// applicant age in years; users without an age score nothing here
+	AgeRules []ThresholdRule `json:"age_rules" bson:"age_rules"`
+
+	VerifiedPoints   int `json:"verified_points" bson:"verified_points"`
+	UnverifiedPoints int `json:"unverified_points" bson:"unverified_points"`
+
+	// number of the applicant's loans that are approved and not yet closed
+	OpenLoanRules []ThresholdRule `json:"open_loan_rules" bson:"open_loan_rules"`
+
+	// per closed loan up to MaxClosedLoanPoints, and per defaulted or
+	// written off loan
+	ClosedLoanPoints    int `json:"closed_loan_points" bson:"closed_loan_points"`
+	MaxClosedLoanPoints int `json:"max_closed_loan_points" bson:"max_closed_loan_points"`
+	DefaultedLoanPoints int `json:"defaulted_loan_points" bson:"defaulted_loan_points"`
+
+	// requested amount divided by declared monthly income
+	IncomeRatioRules []ThresholdRule `json:"income_ratio_rules" bson:"income_ratio_rules"`
+	NoIncomePoints   int             `json:"no_income_points" bson:"no_income_points"`
+
+	Bands []ScoreBand `json:"bands" bson:"bands"`
+
+	UpdatedBy string    `json:"updated_by" bson:"updated_by"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// DefaultScorecard is used until an admin saves a scorecard of their own.
+func DefaultScorecard() *Scorecard {
+	return &Scorecard{
+		BaseScore: 500,
+		AgeRules: []ThresholdRule{
+			{UpTo: 17, Points: -200},
+			{UpTo: 24, Points: 10},
+			{UpTo: 34, Points: 30},
+			{UpTo: 54, Points: 40},
+			{UpTo: 64, Points: 20},
+			{UpTo: 150, Points: 0},
+		},
+		VerifiedPoints:   40,
+		UnverifiedPoints: -40,
+		OpenLoanRules: []ThresholdRule{
+			{UpTo: 0, Points: 30},
+			{UpTo: 1, Points: 0},
+			{UpTo: 2, Points: -40},
+			{UpTo: 3, Points: -100},
+		},
+		ClosedLoanPoints:    15,
+		MaxClosedLoanPoints: 60,
+		DefaultedLoanPoints: -150,
+		IncomeRatioRules: []ThresholdRule{
+			{UpTo: 3, Points: 50},
+			{UpTo: 6, Points: 20},
+			{UpTo: 12, Points: -20},
+			{UpTo: 24, Points: -80},
+		},
+		NoIncomePoints: -50,
+		Bands: []ScoreBand{
+			{Name: "A", MinScore: 650},
+			{Name: "B", MinScore: 580},
+			{Name: "C", MinScore: 500},
+			{Name: "D", MinScore: 420},
+			{Name: "E", MinScore: 0},
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DecisionApprove = "approve"
+	DecisionReject  = "reject"
+	DecisionRefer   = "refer"
+)
+
+// DecisionPolicy decides submitted applications automatically. Rules are
+// tried in order and the first one whose conditions all hold sets the
+// outcome; applications no rule matches get DefaultOutcome.
+type DecisionPolicy struct {
+	ID             primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Version        int                `json:"version" bson:"version"`
+	Enabled        bool               `json:"enabled" bson:"enabled"`
+	Rules          []DecisionRule     `json:"rules" bson:"rules"`
+	DefaultOutcome string             `json:"default_outcome" bson:"default_outcome"`
+	UpdatedBy      string             `json:"updated_by" bson:"updated_by"`
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
+}
+
+type DecisionRule struct {
+	Name       string          `json:"name" bson:"name"`
+	Outcome    string          `json:"outcome" bson:"outcome"`
+	Conditions []RuleCondition `json:"conditions" bson:"conditions"`
+}
+
+// RuleCondition compares one application attribute, e.g.
+// {"field": "score.value", "op": "gte", "value": 650}. Supported operators
+// are eq, ne, gt, gte, lt, lte and in (value is a list).
+type RuleCondition struct {
+	Field string      `json:"field" bson:"field"`
+	Op    string      `json:"op" bson:"op"`
+	Value interface{} `json:"value" bson:"value"`
+}
+
+// AutoDecision records what the decision policy concluded for a loan.
+type AutoDecision struct {
+	Outcome       string    `json:"outcome" bson:"outcome"`
+	Rule          string    `json:"rule,omitempty" bson:"rule,omitempty"`
+	RulesHit      []string  `json:"rules_hit" bson:"rules_hit"`
+	PolicyVersion int       `json:"policy_version" bson:"policy_version"`
+	DecidedAt     time.Time `json:"decided_at" bson:"decided_at"`
+}
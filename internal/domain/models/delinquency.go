@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+)
+
+// Delinquency buckets by days past due.
+const (
+	BucketCurrent = "current"
+	Bucket1To30   = "1-30"
+	Bucket31To60  = "31-60"
+	Bucket61To90  = "61-90"
+	Bucket90Plus  = "90+"
+)
+
+// DelinquencyBuckets lists the buckets from least to most overdue.
+var DelinquencyBuckets = []string{BucketCurrent, Bucket1To30, Bucket31To60, Bucket61To90, Bucket90Plus}
+
+// DefaultAfterDays is how many days past due a loan defaults after when its
+// product does not say otherwise.
+const DefaultAfterDays = 90
+
+// Delinquency is how far behind a loan was on its schedule when it was last
+// checked. OverdueAmount is the principal and interest of installments past
+// their due date that is still unpaid.
+type Delinquency struct {
+	DaysPastDue   int        `json:"days_past_due" bson:"days_past_due"`
+	Bucket        string     `json:"bucket" bson:"bucket"`
+	OverdueAmount Money      `json:"overdue_amount" bson:"overdue_amount"`
+	OldestDueDate *time.Time `json:"oldest_due_date,omitempty" bson:"oldest_due_date,omitempty"`
+	AsOf          time.Time  `json:"as_of" bson:"as_of"`
+}
+
+// LateFeePolicy is what a product charges once on each installment still
+// unpaid GraceDays after its due date: a flat fee plus Rate times the
+// overdue part of the installment.
+type LateFeePolicy struct {
+	GraceDays int     `json:"grace_days" bson:"grace_days"`
+	FlatFee   Money   `json:"flat_fee" bson:"flat_fee"`
+	Rate      float64 `json:"rate" bson:"rate"`
+}
+
+// Fee returns the late fee on an installment with overdue left unpaid.
+func (p *LateFeePolicy) Fee(overdue Money) Money {
+	return p.FlatFee.Add(overdue.MulRate(p.Rate))
+}
+
+// DelinquencyBucket returns the bucket a loan daysPastDue behind falls in.
+func DelinquencyBucket(daysPastDue int) string {
+	switch {
+	case daysPastDue <= 0:
+		return BucketCurrent
+	case daysPastDue <= 30:
+		return Bucket1To30
+	case daysPastDue <= 60:
+		return Bucket31To60
+	case daysPastDue <= 90:
+		return Bucket61To90
+	default:
+		return Bucket90Plus
+	}
+}
+
+// DaysBetween counts the calendar days from one date to another, ignoring
+// the time of day. It is negative when to is before from.
+func DaysBetween(from, to time.Time) int {
+	fromDay := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDay.Sub(fromDay).Hours() / 24)
+}
+
+// Overdue is what is still unpaid on the installment.
+func (i *Installment) Overdue() Money {
+	return i.Principal.Sub(i.PaidPrincipal).Add(i.Interest.Sub(i.PaidInterest))
+}
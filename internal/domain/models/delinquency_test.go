@@ -0,0 +1,27 @@
+package models
+
+import "testing"
+
+func TestDelinquencyBucket(t *testing.T) {
+	tests := []struct {
+		days   int
+		bucket string
+	}{
+		{-3, BucketCurrent},
+		{0, BucketCurrent},
+		{1, Bucket1To30},
+		{30, Bucket1To30},
+		{31, Bucket31To60},
+		{60, Bucket31To60},
+		{61, Bucket61To90},
+		{90, Bucket61To90},
+		{91, Bucket90Plus},
+		{400, Bucket90Plus},
+	}
+
+	for _, tt := range tests {
+		if got := DelinquencyBucket(tt.days); got != tt.bucket {
+			t.Errorf("DelinquencyBucket(%d) = %s, want %s", tt.days, got, tt.bucket)
+		}
+	}
+}
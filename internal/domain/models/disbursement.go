@@ -0,0 +1,74 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// A disbursement is pending until the payout provider accepts it, retried
+// while it keeps failing and failed once MaxPayoutAttempts are used up. A
+// succeeded disbursement is booked once the loan it paid out is.
+const (
+	DisbursementStatusPending   = "pending"
+	DisbursementStatusSucceeded = "succeeded"
+	DisbursementStatusFailed    = "failed"
+)
+
+// MaxPayoutAttempts is how often a payout is tried before the disbursement
+// is given up on and needs an admin to retry it.
+const MaxPayoutAttempts = 5
+
+var (
+	ErrDisbursementNotFound = errors.New("disbursement not found")
+	ErrInvalidDisbursement  = errors.New("invalid disbursement")
+	ErrIdempotencyConflict  = errors.New("idempotency key was already used for a different request")
+	ErrDisbursementExists   = errors.New("the loan already has a disbursement in progress or completed")
+)
+
+// PayoutDestination is the account a loan is paid out to.
+type PayoutDestination struct {
+	AccountName   string `json:"account_name" bson:"account_name"`
+	AccountNumber string `json:"account_number" bson:"account_number"`
+	BankCode      string `json:"bank_code" bson:"bank_code"`
+}
+
+// PayoutAttempt is one call to the payout provider.
+type PayoutAttempt struct {
+	At                time.Time `json:"at" bson:"at"`
+	ProviderReference string    `json:"provider_reference,omitempty" bson:"provider_reference,omitempty"`
+	Error             string    `json:"error,omitempty" bson:"error,omitempty"`
+}
+
+// Disbursement pays an approved loan out to the borrower. Requests are
+// deduplicated on IdempotencyKey, which is also sent to the provider, and a
+// loan has at most one disbursement that is pending or succeeded: Active
+// marks it.
+type Disbursement struct {
+	ID                primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	LoanID            primitive.ObjectID `json:"loan_id" bson:"loan_id"`
+	IdempotencyKey    string             `json:"idempotency_key" bson:"idempotency_key"`
+	Amount            Money              `json:"amount" bson:"amount"`
+	Destination       PayoutDestination  `json:"destination" bson:"destination"`
+	Provider          string             `json:"provider" bson:"provider"`
+	Status            string             `json:"status" bson:"status"`
+	Active            bool               `json:"-" bson:"active"`
+	ProviderReference string             `json:"provider_reference,omitempty" bson:"provider_reference,omitempty"`
+	Attempts          []PayoutAttempt    `json:"attempts" bson:"attempts"`
+	NextAttemptAt     *time.Time         `json:"next_attempt_at,omitempty" bson:"next_attempt_at,omitempty"`
+	RequestedBy       string             `json:"requested_by" bson:"requested_by"`
+	CreatedAt         time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at" bson:"updated_at"`
+	DisbursedAt       *time.Time         `json:"disbursed_at,omitempty" bson:"disbursed_at,omitempty"`
+	BookedAt          *time.Time         `json:"booked_at,omitempty" bson:"booked_at,omitempty"`
+}
+
+// PayoutRetryDelay is how long to wait after the given number of failed
+// attempts: one minute, doubling each time.
+func PayoutRetryDelay(failures int) time.Duration {
+	if failures < 1 {
+		failures = 1
+	}
+	return time.Minute << (failures - 1)
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Document types products commonly ask for. Products may require others.
+const (
+	DocumentTypeID            = "id"
+	DocumentTypePayslip       = "payslip"
+	DocumentTypeBankStatement = "bank_statement"
+
+	// evidence of collateral, accepted on every secured product
+	DocumentTypeCollateral = "collateral"
+)
+
+const (
+	DocumentStatusPending  = "pending"
+	DocumentStatusVerified = "verified"
+	DocumentStatusRejected = "rejected"
+)
+
+// MaxDocumentSize is the largest file accepted as a loan document.
+const MaxDocumentSize = 10 << 20
+
+// DocumentContentTypes are the file formats accepted as loan documents, by
+// content type, with the extension they are stored under.
+var DocumentContentTypes = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+}
+
+var (
+	ErrDocumentNotFound    = errors.New("document not found")
+	ErrInvalidDocument     = errors.New("invalid document")
+	ErrDocumentsIncomplete = errors.New("the loan's required documents are not all verified")
+)
+
+// LoanDocument is a file a borrower attached to a loan and its review.
+// Location is the opaque key the file is kept under in document storage.
+type LoanDocument struct {
+	ID              primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	LoanID          primitive.ObjectID `json:"loan_id" bson:"loan_id"`
+	Type            string             `json:"type" bson:"type"`
+	FileName        string             `json:"file_name" bson:"file_name"`
+	ContentType     string             `json:"content_type" bson:"content_type"`
+	Size            int64              `json:"size" bson:"size"`
+	Location        string             `json:"location" bson:"location"`
+	Status          string             `json:"status" bson:"status"`
+	UploadedBy      string             `json:"uploaded_by" bson:"uploaded_by"`
+	UploadedAt      time.Time          `json:"uploaded_at" bson:"uploaded_at"`
+	ReviewedBy      string             `json:"reviewed_by,omitempty" bson:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time         `json:"reviewed_at,omitempty" bson:"reviewed_at,omitempty"`
+	RejectionReason string             `json:"rejection_reason,omitempty" bson:"rejection_reason,omitempty"`
+}
+
+// ChecklistItem is the state of one document a product requires.
+type ChecklistItem struct {
+	Type       string `json:"type"`
+	Status     string `json:"status"`
+	DocumentID string `json:"document_id,omitempty"`
+}
+
+// DocumentChecklist compares the documents a loan's product requires with
+// the latest upload of each type. Status is "missing" for types never
+// uploaded.
+func DocumentChecklist(required []string, documents []LoanDocument) ([]ChecklistItem, bool) {
+	latest := map[string]LoanDocument{}
+	for _, document := range documents {
+		if current, ok := latest[document.Type]; !ok || document.UploadedAt.After(current.UploadedAt) {
+			latest[document.Type] = document
+		}
+	}
+
+	complete := true
+	items := make([]ChecklistItem, 0, len(required))
+	for _, docType := range required {
+		item := ChecklistItem{Type: docType, Status: "missing"}
+		if document, ok := latest[docType]; ok {
+			item.Status = document.Status
+			item.DocumentID = document.ID.Hex()
+		}
+		if item.Status != DocumentStatusVerified {
+			complete = false
+		}
+		items = append(items, item)
+	}
+	return items, complete
+}
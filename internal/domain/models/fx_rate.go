@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FXRate says how many units of BaseCurrency one unit of Currency was worth
+// from EffectiveDate on. Rates are never edited: a new rate gets a new
+// effective date, so reports run for an earlier date keep their figures.
+type FXRate struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	BaseCurrency  string             `json:"base_currency" bson:"base_currency"`
+	Currency      string             `json:"currency" bson:"currency"`
+	Rate          string             `json:"rate" bson:"rate"`
+	EffectiveDate time.Time          `json:"effective_date" bson:"effective_date"`
+	UploadedBy    string             `json:"uploaded_by" bson:"uploaded_by"`
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// LoanTotals aggregates the loans sharing a currency and status.
+type LoanTotals struct {
+	Currency    string `bson:"currency"`
+	Status      string `bson:"status"`
+	Count       int64  `bson:"count"`
+	Amount      int64  `bson:"amount"`
+	Outstanding int64  `bson:"outstanding"`
+}
+
+type LoanSummaryLine struct {
+	Count       int64 `json:"count"`
+	Amount      Money `json:"amount"`
+	Outstanding Money `json:"outstanding"`
+}
+
+// LoanSummary is the portfolio converted into the reporting currency with
+// the rates effective on AsOf.
+type LoanSummary struct {
+	ReportingCurrency string                     `json:"reporting_currency"`
+	AsOf              time.Time                  `json:"as_of"`
+	Total             LoanSummaryLine            `json:"total"`
+	ByStatus          map[string]LoanSummaryLine `json:"by_status"`
+	ByCurrency        map[string]LoanSummaryLine `json:"by_currency"`
+	RatesUsed         map[string]string          `json:"rates_used"`
+	MissingRates      []string                   `json:"missing_rates"`
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// KYC statuses. A user who never submitted their details is unverified.
+const (
+	KYCStatusUnverified = "unverified"
+	KYCStatusPending    = "pending"
+	KYCStatusVerified   = "verified"
+	KYCStatusRejected   = "rejected"
+)
+
+const (
+	EmploymentEmployed     = "employed"
+	EmploymentSelfEmployed = "self_employed"
+	EmploymentUnemployed   = "unemployed"
+	EmploymentRetired      = "retired"
+	EmploymentStudent      = "student"
+)
+
+var (
+	ErrKYCNotVerified = errors.New("identity verification (KYC) must be completed before applying for a loan")
+	ErrInvalidKYC     = errors.New("invalid KYC details")
+)
+
+type Address struct {
+	Line1      string `json:"line1" bson:"line1"`
+	Line2      string `json:"line2,omitempty" bson:"line2,omitempty"`
+	City       string `json:"city" bson:"city"`
+	Region     string `json:"region,omitempty" bson:"region,omitempty"`
+	PostalCode string `json:"postal_code,omitempty" bson:"postal_code,omitempty"`
+	Country    string `json:"country" bson:"country"`
+}
+
+type Employment struct {
+	Status   string `json:"status" bson:"status"`
+	Employer string `json:"employer,omitempty" bson:"employer,omitempty"`
+	JobTitle string `json:"job_title,omitempty" bson:"job_title,omitempty"`
+}
+
+// KYCProfile is the identity information a borrower submits and an admin
+// verifies. Resubmitting it takes the user back to pending.
+type KYCProfile struct {
+	NationalID      string     `json:"national_id" bson:"national_id"`
+	Address         Address    `json:"address" bson:"address"`
+	Employment      Employment `json:"employment" bson:"employment"`
+	MonthlyIncome   Money      `json:"monthly_income" bson:"monthly_income"`
+	Status          string     `json:"status" bson:"status"`
+	SubmittedAt     *time.Time `json:"submitted_at,omitempty" bson:"submitted_at,omitempty"`
+	ReviewedBy      string     `json:"reviewed_by,omitempty" bson:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty" bson:"reviewed_at,omitempty"`
+	RejectionReason string     `json:"rejection_reason,omitempty" bson:"rejection_reason,omitempty"`
+}
+
+// KYCStatus returns the user's KYC status, unverified when they never
+// submitted any details.
+func (u *User) KYCStatus() string {
+	if u.KYC.Status == "" {
+		return KYCStatusUnverified
+	}
+	return u.KYC.Status
+}
+
+func (u *User) KYCVerified() bool {
+	return u.KYCStatus() == KYCStatusVerified
+}
+
+// AgeAt returns the user's age in whole years on the given day, or 0 when
+// their date of birth is unknown.
+func (u *User) AgeAt(t time.Time) int {
+	if u.DateOfBirth == nil {
+		return 0
+	}
+	dob := u.DateOfBirth.UTC()
+	t = t.UTC()
+	age := t.Year() - dob.Year()
+	if t.Month() < dob.Month() || (t.Month() == dob.Month() && t.Day() < dob.Day()) {
+		age--
+	}
+	return age
+}
+
+func ValidEmploymentStatus(status string) bool {
+	switch strings.ToLower(status) {
+	case EmploymentEmployed, EmploymentSelfEmployed, EmploymentUnemployed, EmploymentRetired, EmploymentStudent:
+		return true
+	}
+	return false
+}
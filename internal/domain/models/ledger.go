@@ -0,0 +1,43 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Ledger accounts a loan posts to. Every transaction is a balanced set of
+// entries: the sum of debits always equals the sum of credits. Scheduled
+// interest is held as unearned interest when the loan is booked and moves to
+// interest income as it accrues day by day.
+const (
+	AccountCash                = "cash"
+	AccountPrincipalReceivable = "principal_receivable"
+	AccountInterestReceivable  = "interest_receivable"
+	AccountFeesReceivable      = "fees_receivable"
+	AccountInterestIncome      = "interest_income"
+	AccountUnearnedInterest    = "unearned_interest"
+	AccountFeeIncome           = "fee_income"
+	AccountLateFeeIncome       = "late_fee_income"
+	AccountPrepaymentFeeIncome = "prepayment_fee_income"
+)
+
+// ErrDuplicateLedgerEntry is returned when a transaction is posted under a
+// key that was posted before.
+var ErrDuplicateLedgerEntry = errors.New("ledger transaction was already posted")
+
+type LedgerEntry struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	LoanID        primitive.ObjectID `json:"loan_id" bson:"loan_id"`
+	TransactionID primitive.ObjectID `json:"transaction_id" bson:"transaction_id"`
+	Account       string             `json:"account" bson:"account"`
+	Debit         Money              `json:"debit" bson:"debit"`
+	Credit        Money              `json:"credit" bson:"credit"`
+	Description   string             `json:"description" bson:"description"`
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`
+
+	// set on transactions that must be posted at most once, e.g. a day's
+	// accrual, and unique per account
+	Key string `json:"-" bson:"key,omitempty"`
+}
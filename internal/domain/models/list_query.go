@@ -0,0 +1,65 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// ErrInvalidQuery marks list requests with an unknown sort field, a bad
+// cursor or a malformed filter.
+var ErrInvalidQuery = errors.New("invalid list query")
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ListQuery describes one page of an admin listing. Pages are addressed with
+// the opaque Cursor returned alongside the previous page, never by offset, so
+// paging stays cheap however deep it goes. Filters a collection does not
+// support are ignored.
+type ListQuery struct {
+	Cursor string
+	Limit  int
+	Sort   []SortField
+
+	Status      string
+	Currency    string
+	MinAmount   *Money
+	MaxAmount   *Money
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	Search      string
+}
+
+// ParseSort reads a comma separated sort such as "-created_at,amount", where
+// a leading minus sorts that field in descending order.
+func ParseSort(value string) []SortField {
+	var fields []SortField
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		desc := strings.HasPrefix(part, "-")
+		fields = append(fields, SortField{Field: strings.TrimLeft(part, "+-"), Desc: desc})
+	}
+	return fields
+}
+
+// PageLimit clamps the requested page size to [1, MaxPageSize].
+func (q ListQuery) PageLimit() int {
+	switch {
+	case q.Limit <= 0:
+		return DefaultPageSize
+	case q.Limit > MaxPageSize:
+		return MaxPageSize
+	}
+	return q.Limit
+}
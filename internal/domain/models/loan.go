@@ -6,13 +6,68 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	RepaymentMethodAnnuity = "annuity"
+	RepaymentMethodFlat    = "flat"
+)
+
 type Loan struct {
-	ID             primitive.ObjectID 		`json:"id" bson:"_id,omitempty"`
-	Amount         int    					`json:"amount" bson:"amount"`
-	Interest       float32    			    `json:"interest" bson:"interest"`
-	Total          float32    				`json:"total" bson:"total"`
-	Status         string 					`json:"status" bson:"status"`
-	LoanPurpose    string 					`json:"loan_purpose" bson:"loan_purpose"`
-	UserId         primitive.ObjectID 		`json:"userId" bson:"userId"`
-	CreatedAt 	   time.Time                `jaon:"created_at" bson:"created_at"`
-}
\ No newline at end of file
+	ID                    primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	ProductID             primitive.ObjectID `json:"product_id" bson:"product_id,omitempty"`
+	Amount                Money              `json:"amount" bson:"amount"`
+	Currency              string             `json:"currency" bson:"currency"`
+	Interest              float64            `json:"interest" bson:"interest"`
+	Total                 Money              `json:"total" bson:"total"`
+	OriginationFee        Money              `json:"origination_fee" bson:"origination_fee"`
+	Term                  int                `json:"term" bson:"term"`
+	RepaymentMethod       string             `json:"repayment_method" bson:"repayment_method"`
+	Status                string             `json:"status" bson:"status"`
+	LoanPurpose           string             `json:"loan_purpose" bson:"loan_purpose"`
+	DeclaredMonthlyIncome Money              `json:"declared_monthly_income" bson:"declared_monthly_income"`
+	UserId                primitive.ObjectID `json:"userId" bson:"userId"`
+	CreatedAt             time.Time          `jaon:"created_at" bson:"created_at"`
+
+	// outstanding amounts start tracking once the loan is approved
+	OutstandingPrincipal Money `json:"outstanding_principal" bson:"outstanding_principal"`
+	OutstandingInterest  Money `json:"outstanding_interest" bson:"outstanding_interest"`
+	OutstandingFees      Money `json:"outstanding_fees" bson:"outstanding_fees"`
+	OutstandingBalance   Money `json:"outstanding_balance" bson:"outstanding_balance"`
+
+	// set when the application is submitted for review
+	CreditScore  *CreditScore   `json:"credit_score,omitempty" bson:"credit_score,omitempty"`
+	AutoDecision *AutoDecision  `json:"auto_decision,omitempty" bson:"auto_decision,omitempty"`
+	Approvals    []LoanApproval `json:"approvals,omitempty" bson:"approvals,omitempty"`
+	Decision     *LoanDecision  `json:"decision,omitempty" bson:"decision,omitempty"`
+
+	// guarantors and co-borrowers the borrower nominated
+	Parties []LoanParty `json:"parties,omitempty" bson:"parties,omitempty"`
+
+	// how far behind schedule the loan was at the last daily check
+	Delinquency *Delinquency `json:"delinquency,omitempty" bson:"delinquency,omitempty"`
+
+	// keys of the late fees added to the balances, so none is added twice
+	LateFeesCharged []string `json:"-" bson:"late_fees_charged,omitempty"`
+	// ids of the repayments applied to the balances, likewise
+	AppliedRepayments []string `json:"-" bson:"applied_repayments,omitempty"`
+
+	// interest recognised by the daily accrual so far
+	Accrual *InterestAccrual `json:"accrual,omitempty" bson:"accrual,omitempty"`
+
+	// the officer working the loan while it is under review
+	Assignment *LoanAssignment `json:"assignment,omitempty" bson:"assignment,omitempty"`
+
+	StatusHistory []StatusChange `json:"status_history" bson:"status_history"`
+}
+
+// LoanFilter narrows a borrower's loan listing. Zero values do not filter.
+type LoanFilter struct {
+	UserID      primitive.ObjectID
+	Status      string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	Skip        int64
+	Limit       int64
+
+	// also match loans the user is an accepted guarantor or co-borrower on
+	IncludeParties bool
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Parties who share responsibility for a loan with its borrower.
+const (
+	PartyRoleGuarantor  = "guarantor"
+	PartyRoleCoBorrower = "co_borrower"
+)
+
+const (
+	PartyStatusInvited  = "invited"
+	PartyStatusAccepted = "accepted"
+	PartyStatusDeclined = "declined"
+)
+
+var (
+	ErrInvalidParty   = errors.New("invalid guarantor or co-borrower")
+	ErrPartyNotFound  = errors.New("invitation not found")
+	ErrPartiesPending = errors.New("every guarantor and co-borrower must accept before the loan can move past review")
+)
+
+// ConsentStatements are the statements a nominee agrees to, by role, when
+// accepting an invitation.
+var ConsentStatements = map[string]string{
+	PartyRoleGuarantor:  "I agree to guarantee this loan and to repay it if the borrower does not.",
+	PartyRoleCoBorrower: "I agree to be a co-borrower on this loan and to be jointly responsible for repaying it.",
+}
+
+// LoanParty is a guarantor or co-borrower nominated by the borrower. UserID
+// is known once the nominee has an account, at the latest when they respond.
+type LoanParty struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id"`
+	Email       string             `json:"email" bson:"email"`
+	UserID      primitive.ObjectID `json:"user_id,omitempty" bson:"user_id,omitempty"`
+	Role        string             `json:"role" bson:"role"`
+	Status      string             `json:"status" bson:"status"`
+	InvitedAt   time.Time          `json:"invited_at" bson:"invited_at"`
+	RespondedAt *time.Time         `json:"responded_at,omitempty" bson:"responded_at,omitempty"`
+	Consent     string             `json:"consent,omitempty" bson:"consent,omitempty"`
+}
+
+// PartyRole returns the role the user holds on the loan as an accepted
+// guarantor or co-borrower, or "" when they hold none.
+func (l *Loan) PartyRole(userID string) string {
+	for _, party := range l.Parties {
+		if party.Status == PartyStatusAccepted && !party.UserID.IsZero() && party.UserID.Hex() == userID {
+			return party.Role
+		}
+	}
+	return ""
+}
+
+// PartiesAccepted reports whether every nominated party has accepted.
+// Declined nominations block the loan until they are withdrawn.
+func (l *Loan) PartiesAccepted() bool {
+	for _, party := range l.Parties {
+		if party.Status != PartyStatusAccepted {
+			return false
+		}
+	}
+	return true
+}
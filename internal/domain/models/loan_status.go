@@ -0,0 +1,71 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	LoanStatusDraft                 = "draft"
+	LoanStatusPending               = "pending"
+	LoanStatusUnderReview           = "under_review"
+	LoanStatusPendingSecondApproval = "pending_second_approval"
+	LoanStatusApproved              = "approved"
+	LoanStatusRejected              = "rejected"
+	LoanStatusDisbursed             = "disbursed"
+	LoanStatusActive                = "active"
+	LoanStatusClosed                = "closed"
+	LoanStatusDefaulted             = "defaulted"
+	LoanStatusWrittenOff            = "written_off"
+	LoanStatusCancelled             = "cancelled"
+)
+
+var (
+	ErrInvalidTransition = errors.New("illegal loan status transition")
+	ErrStatusConflict    = errors.New("loan status was changed by another request")
+	ErrLoanNotFound      = errors.New("loan not found")
+	ErrSameApprover      = errors.New("the second approval must come from a different admin")
+)
+
+// loanTransitions lists, for every status, the statuses a loan may move to
+// next. Statuses without an entry (rejected, closed, written_off, cancelled)
+// are terminal.
+var loanTransitions = map[string][]string{
+	LoanStatusDraft:                 {LoanStatusPending, LoanStatusCancelled},
+	LoanStatusPending:               {LoanStatusUnderReview, LoanStatusCancelled},
+	LoanStatusUnderReview:           {LoanStatusApproved, LoanStatusPendingSecondApproval, LoanStatusRejected, LoanStatusCancelled},
+	LoanStatusPendingSecondApproval: {LoanStatusApproved, LoanStatusRejected, LoanStatusCancelled},
+	LoanStatusApproved:              {LoanStatusDisbursed, LoanStatusCancelled},
+	LoanStatusDisbursed:             {LoanStatusActive, LoanStatusDefaulted},
+	LoanStatusActive:                {LoanStatusClosed, LoanStatusDefaulted, LoanStatusWrittenOff},
+	LoanStatusDefaulted:             {LoanStatusActive, LoanStatusClosed, LoanStatusWrittenOff},
+}
+
+// DeletableStatuses are the statuses in which a loan never moved any money
+// and can be deleted outright.
+var DeletableStatuses = []string{LoanStatusDraft, LoanStatusRejected, LoanStatusCancelled}
+
+// StatusChange records a single move through the loan lifecycle.
+type StatusChange struct {
+	From   string    `json:"from" bson:"from"`
+	To     string    `json:"to" bson:"to"`
+	Actor  string    `json:"actor" bson:"actor"`
+	Reason string    `json:"reason" bson:"reason,omitempty"`
+	At     time.Time `json:"at" bson:"at"`
+}
+
+// LoanApproval is one admin's sign-off on a loan. Loans above their
+// product's second approval threshold collect two, from different admins.
+type LoanApproval struct {
+	AdminID string    `json:"admin_id" bson:"admin_id"`
+	At      time.Time `json:"at" bson:"at"`
+}
+
+func CanTransitionLoan(from, to string) bool {
+	for _, next := range loanTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
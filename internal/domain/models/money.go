@@ -0,0 +1,289 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// DefaultCurrency is assumed for amounts that arrive without a currency code,
+// including documents written before amounts carried one.
+var DefaultCurrency = "USD"
+
+// currencyExponents lists ISO 4217 currencies whose minor unit is not the
+// usual hundredth.
+var currencyExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// Money is an exact amount held as an integer number of minor units (cents
+// for USD) together with its ISO 4217 currency code. All rounding of money
+// goes through roundHalfEven so the whole system rounds the same way.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+type moneyDocument struct {
+	Minor    int64  `bson:"minor"`
+	Currency string `bson:"currency"`
+}
+
+func NewMoney(minor int64, currency string) Money {
+	return Money{Amount: minor, Currency: strings.ToUpper(currency)}
+}
+
+// ParseMoney reads a decimal amount in major units such as "1250.50". Amounts
+// with more decimal places than the currency supports are rejected rather
+// than silently rounded.
+func ParseMoney(value string, currency string) (Money, error) {
+	currency = strings.ToUpper(currency)
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return Money{}, fmt.Errorf("invalid amount %q", value)
+	}
+	r.Mul(r, new(big.Rat).SetInt(minorFactor(currency)))
+	if !r.IsInt() {
+		return Money{}, fmt.Errorf("amount %q has more decimal places than %s allows", value, currency)
+	}
+	if !r.Num().IsInt64() {
+		return Money{}, fmt.Errorf("amount %q is out of range", value)
+	}
+	return Money{Amount: r.Num().Int64(), Currency: currency}, nil
+}
+
+// MoneyFromFloat converts a major-unit float, as stored by older documents,
+// rounding half to even to the nearest minor unit.
+func MoneyFromFloat(value float64, currency string) Money {
+	currency = strings.ToUpper(currency)
+	r := RateFromFloat(value)
+	r.Mul(r, new(big.Rat).SetInt(minorFactor(currency)))
+	return Money{Amount: roundHalfEven(r), Currency: currency}
+}
+
+// RateFromFloat turns a rate such as 0.05 into the exact decimal it was
+// written as, avoiding the binary noise of the float representation.
+func RateFromFloat(value float64) *big.Rat {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(value, 'f', -1, 64))
+	return r
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Add and Sub require both amounts to share a currency. A zero Money without
+// a currency adopts the other operand's, anything else is a programming error.
+func (m Money) Add(o Money) Money {
+	return Money{Amount: m.Amount + o.Amount, Currency: m.mustMatch(o)}
+}
+
+func (m Money) Sub(o Money) Money {
+	return Money{Amount: m.Amount - o.Amount, Currency: m.mustMatch(o)}
+}
+
+// Cmp returns -1, 0 or 1 as m is less than, equal to or greater than o.
+func (m Money) Cmp(o Money) int {
+	m.mustMatch(o)
+	switch {
+	case m.Amount < o.Amount:
+		return -1
+	case m.Amount > o.Amount:
+		return 1
+	}
+	return 0
+}
+
+func (m Money) Min(o Money) Money {
+	if m.Cmp(o) <= 0 {
+		return m
+	}
+	return o
+}
+
+// Mul multiplies by an exact rational factor and rounds half to even.
+func (m Money) Mul(factor *big.Rat) Money {
+	r := new(big.Rat).SetInt64(m.Amount)
+	r.Mul(r, factor)
+	return Money{Amount: roundHalfEven(r), Currency: m.Currency}
+}
+
+// MulRate multiplies by a decimal rate such as an annual interest rate.
+func (m Money) MulRate(rate float64) Money {
+	return m.Mul(RateFromFloat(rate))
+}
+
+// Div splits the amount into n parts, rounding half to even.
+func (m Money) Div(n int64) Money {
+	return m.Mul(big.NewRat(1, n))
+}
+
+// ConvertTo expresses the amount in another currency. rate is the number of
+// target currency units one unit of m's currency buys; differing minor unit
+// sizes are accounted for and the result is rounded half to even.
+func (m Money) ConvertTo(currency string, rate *big.Rat) Money {
+	currency = strings.ToUpper(currency)
+	r := new(big.Rat).SetFrac(big.NewInt(m.Amount), minorFactor(m.Currency))
+	r.Mul(r, rate)
+	r.Mul(r, new(big.Rat).SetInt(minorFactor(currency)))
+	return Money{Amount: roundHalfEven(r), Currency: currency}
+}
+
+// IsCurrencyCode reports whether code looks like an ISO 4217 alphabetic code.
+func IsCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Decimal renders the amount in major units, e.g. "1250.50".
+func (m Money) Decimal() string {
+	exp := currencyExponent(m.Currency)
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	if exp == 0 {
+		return sign + strconv.FormatInt(amount, 10)
+	}
+	factor := minorFactor(m.Currency).Int64()
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/factor, exp, amount%factor)
+}
+
+// Float64 is for reporting and ratios only, never for further money math.
+func (m Money) Float64() float64 {
+	f, _ := new(big.Rat).SetFrac(big.NewInt(m.Amount), minorFactor(m.Currency)).Float64()
+	return f
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}{m.Decimal(), m.Currency})
+}
+
+// UnmarshalJSON accepts {"amount": "12.50", "currency": "USD"} as produced by
+// MarshalJSON, and also a bare string or number in DefaultCurrency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var doc struct {
+		Amount   json.Number `json:"amount"`
+		Currency string      `json:"currency"`
+	}
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "{") {
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+	} else if err := json.Unmarshal(data, &doc.Amount); err != nil {
+		return errors.New("amount must be a decimal number")
+	}
+
+	if doc.Amount == "" {
+		*m = Money{Currency: strings.ToUpper(doc.Currency)}
+		return nil
+	}
+	currency := doc.Currency
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	parsed, err := ParseMoney(doc.Amount.String(), currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func (m Money) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bson.MarshalValue(moneyDocument{Minor: m.Amount, Currency: m.Currency})
+}
+
+// UnmarshalBSONValue reads the {minor, currency} document, and also the bare
+// major-unit numbers older loan documents stored, so they keep loading until
+// they are migrated.
+func (m *Money) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	raw := bson.RawValue{Type: t, Value: data}
+	switch t {
+	case bsontype.EmbeddedDocument:
+		var doc moneyDocument
+		if err := raw.Unmarshal(&doc); err != nil {
+			return err
+		}
+		*m = Money{Amount: doc.Minor, Currency: doc.Currency}
+	case bsontype.Int32:
+		*m = MoneyFromFloat(float64(raw.Int32()), DefaultCurrency)
+	case bsontype.Int64:
+		*m = MoneyFromFloat(float64(raw.Int64()), DefaultCurrency)
+	case bsontype.Double:
+		*m = MoneyFromFloat(raw.Double(), DefaultCurrency)
+	case bsontype.Null, bsontype.Undefined:
+		*m = Money{}
+	default:
+		return fmt.Errorf("cannot decode %s into Money", t)
+	}
+	return nil
+}
+
+func (m Money) mustMatch(o Money) string {
+	switch {
+	case m.Currency == o.Currency || o.Currency == "":
+		return m.Currency
+	case m.Currency == "":
+		return o.Currency
+	}
+	panic("money: currency mismatch " + m.Currency + " vs " + o.Currency)
+}
+
+func currencyExponent(currency string) int {
+	if exp, ok := currencyExponents[currency]; ok {
+		return exp
+	}
+	return 2
+}
+
+func minorFactor(currency string) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(currencyExponent(currency))), nil)
+}
+
+// roundHalfEven rounds to the nearest integer, sending exact halves to the
+// even neighbour (banker's rounding).
+func roundHalfEven(r *big.Rat) int64 {
+	quotient, remainder := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	twice := new(big.Int).Abs(remainder)
+	twice.Lsh(twice, 1)
+
+	cmp := twice.Cmp(r.Denom())
+	if cmp > 0 || (cmp == 0 && quotient.Bit(0) == 1) {
+		if r.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return quotient.Int64()
+}
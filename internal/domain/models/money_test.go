@@ -0,0 +1,75 @@
+package models
+
+import (
+	"math/big"
+	"testing"
+	"time"
+)
+
+func usd(minor int64) Money {
+	return NewMoney(minor, "USD")
+}
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Money
+		want Money
+	}{
+		{"add", usd(1050).Add(usd(-75)), usd(975)},
+		{"sub", usd(1000).Sub(usd(1)), usd(999)},
+		{"min", usd(1000).Min(usd(999)), usd(999)},
+		{"mul rounds a half down to even", usd(5).Mul(big.NewRat(1, 2)), usd(2)},
+		{"mul rounds a half up to even", usd(7).Mul(big.NewRat(1, 2)), usd(4)},
+		{"mul rounds above a half up", usd(100).Mul(big.NewRat(2, 3)), usd(67)},
+		{"negative halves round to even", usd(-5).Mul(big.NewRat(1, 2)), usd(-2)},
+		{"rate is exact decimal", usd(1000000).MulRate(0.07), usd(70000)},
+		{"rate without float noise", usd(10).MulRate(0.15), usd(2)},
+		{"div", usd(100).Div(3), usd(33)},
+		{"div half to even", usd(10).Div(4), usd(2)},
+		{"zero exponent currency", NewMoney(1001, "JPY").Div(2), NewMoney(500, "JPY")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.Cmp(tt.want) != 0 || tt.got.Currency != tt.want.Currency {
+				t.Fatalf("got %s, want %s", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestYearFraction(t *testing.T) {
+	tests := []struct {
+		name       string
+		convention string
+		from, to   time.Time
+		want       *big.Rat
+	}{
+		{"actual/360 over a month end", DayCountActual360, day(2026, 1, 31), day(2026, 3, 1), big.NewRat(29, 360)},
+		{"actual/360 over a leap February", DayCountActual360, day(2028, 2, 1), day(2028, 3, 1), big.NewRat(29, 360)},
+		{"actual/360 over a leap year", DayCountActual360, day(2028, 1, 1), day(2029, 1, 1), big.NewRat(366, 360)},
+		{"actual/365 over a month end", DayCountActual365, day(2026, 1, 31), day(2026, 2, 28), big.NewRat(28, 365)},
+		{"actual/365 over a leap year", DayCountActual365, day(2028, 1, 1), day(2029, 1, 1), big.NewRat(366, 365)},
+		{"actual/365 is the default", "", day(2026, 3, 1), day(2026, 4, 1), big.NewRat(31, 365)},
+		{"30/360 treats a whole month as 30 days", DayCount30360, day(2026, 1, 15), day(2026, 2, 15), big.NewRat(30, 360)},
+		{"30/360 from the 31st", DayCount30360, day(2026, 1, 31), day(2026, 2, 28), big.NewRat(28, 360)},
+		{"30/360 from the 30th to the 31st", DayCount30360, day(2026, 4, 30), day(2026, 5, 31), big.NewRat(30, 360)},
+		{"30/360 to the 31st from earlier in the month", DayCount30360, day(2026, 5, 1), day(2026, 5, 31), big.NewRat(30, 360)},
+		{"30/360 over a leap February", DayCount30360, day(2028, 2, 1), day(2028, 3, 1), big.NewRat(30, 360)},
+		{"30/360 to a leap day", DayCount30360, day(2028, 1, 29), day(2028, 2, 29), big.NewRat(30, 360)},
+		{"30/360 over a year", DayCount30360, day(2027, 12, 31), day(2028, 12, 31), big.NewRat(1, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := YearFraction(tt.convention, tt.from, tt.to); got.Cmp(tt.want) != 0 {
+				t.Fatalf("got %s, want %s", got.RatString(), tt.want.RatString())
+			}
+		})
+	}
+}
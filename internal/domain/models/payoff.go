@@ -0,0 +1,80 @@
+package models
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// How a partial prepayment reshapes the rest of the schedule: fewer
+// installments of about the same size, or as many installments as before
+// but smaller.
+const (
+	PrepaymentShortenTerm       = "shorten_term"
+	PrepaymentReduceInstallment = "reduce_installment"
+)
+
+var (
+	ErrQuoteExpired      = errors.New("payoff quote is no longer valid")
+	ErrInvalidPrepayment = errors.New("invalid prepayment")
+)
+
+// PrepaymentPenalty is what a product charges on principal repaid ahead of
+// schedule: a flat fee plus Rate times the principal prepaid. When
+// WithinInstallments is set only prepayments made before that many
+// installments have fallen due are charged.
+type PrepaymentPenalty struct {
+	FlatFee            Money   `json:"flat_fee" bson:"flat_fee"`
+	Rate               float64 `json:"rate" bson:"rate"`
+	WithinInstallments int     `json:"within_installments,omitempty" bson:"within_installments,omitempty"`
+}
+
+// Fee returns the penalty on prepaying principal once installmentsDue
+// installments have fallen due.
+func (p *PrepaymentPenalty) Fee(principal Money, installmentsDue int) Money {
+	if p.WithinInstallments > 0 && installmentsDue >= p.WithinInstallments {
+		return NewMoney(0, principal.Currency)
+	}
+	return p.FlatFee.Add(principal.MulRate(p.Rate))
+}
+
+// Split divides a payment into the principal it prepays and the penalty on
+// that principal, so that the two add up to the payment exactly.
+func (p *PrepaymentPenalty) Split(payment Money, installmentsDue int) (Money, Money) {
+	if p.Fee(payment, installmentsDue).IsZero() {
+		return payment, NewMoney(0, payment.Currency)
+	}
+	onePlusRate := new(big.Rat).Add(big.NewRat(1, 1), RateFromFloat(p.Rate))
+	principal := payment.Sub(p.FlatFee).Mul(new(big.Rat).Inv(onePlusRate))
+	return principal, payment.Sub(principal)
+}
+
+// PayoffQuote is what it takes to close a loan with a payment received on
+// AsOf: the principal still owed, the interest accrued up to that day, the
+// outstanding fees and the product's prepayment penalty. Interest scheduled
+// after AsOf is not owed. The quote can be settled until ExpiresAt.
+type PayoffQuote struct {
+	ID                primitive.ObjectID `json:"id"`
+	LoanID            primitive.ObjectID `json:"loan_id"`
+	AsOf              time.Time          `json:"as_of"`
+	Principal         Money              `json:"principal"`
+	AccruedInterest   Money              `json:"accrued_interest"`
+	Fees              Money              `json:"fees"`
+	PrepaymentPenalty Money              `json:"prepayment_penalty"`
+	Total             Money              `json:"total"`
+	ExpiresAt         time.Time          `json:"expires_at"`
+}
+
+// Fingerprint identifies the quote by the loan, the day and every figure in
+// it, so quoting an unchanged loan for the same day gives the same ID.
+func (q *PayoffQuote) Fingerprint() primitive.ObjectID {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s", q.LoanID.Hex(), q.AsOf.Format("2006-01-02"),
+		q.Principal, q.AccruedInterest, q.Fees, q.PrepaymentPenalty, q.Total)))
+	var id primitive.ObjectID
+	copy(id[:], sum[:])
+	return id
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	RateTypeFixed  = "fixed"
+	RateTypeTiered = "tiered"
+)
+
+// RateTier prices loans whose amount falls within [MinAmount, MaxAmount].
+type RateTier struct {
+	MinAmount  Money   `json:"min_amount" bson:"min_amount"`
+	MaxAmount  Money   `json:"max_amount" bson:"max_amount"`
+	AnnualRate float64 `json:"annual_rate" bson:"annual_rate"`
+}
+
+type LoanProduct struct {
+	ID                 primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name               string             `json:"name" bson:"name"`
+	Description        string             `json:"description" bson:"description"`
+	Currency           string             `json:"currency" bson:"currency"`
+	MinAmount          Money              `json:"min_amount" bson:"min_amount"`
+	MaxAmount          Money              `json:"max_amount" bson:"max_amount"`
+	AllowedTerms       []int              `json:"allowed_terms" bson:"allowed_terms"`
+	RateType           string             `json:"rate_type" bson:"rate_type"`
+	AnnualRate         float64            `json:"annual_rate" bson:"annual_rate"`
+	RateTiers          []RateTier         `json:"rate_tiers" bson:"rate_tiers"`
+	OriginationFeeRate float64            `json:"origination_fee_rate" bson:"origination_fee_rate"`
+	RepaymentMethod    string             `json:"repayment_method" bson:"repayment_method"`
+	Active             bool               `json:"active" bson:"active"`
+	CreatedAt          time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt          time.Time          `json:"updated_at" bson:"updated_at"`
+
+	// loans above this amount need the approval of two different admins
+	SecondApprovalThreshold *Money `json:"second_approval_threshold,omitempty" bson:"second_approval_threshold,omitempty"`
+
+	// document types a borrower must have verified before approval
+	RequiredDocuments []string `json:"required_documents,omitempty" bson:"required_documents,omitempty"`
+
+	// secured products set the highest loan-to-value ratio, e.g. 0.8, they
+	// approve at; zero means the product takes no collateral
+	MaxLTV float64 `json:"max_ltv,omitempty" bson:"max_ltv,omitempty"`
+
+	// charged on installments left unpaid; loans default once they are
+	// DefaultAfterDays past due, models.DefaultAfterDays when unset
+	LateFee          *LateFeePolicy `json:"late_fee,omitempty" bson:"late_fee,omitempty"`
+	DefaultAfterDays int            `json:"default_after_days,omitempty" bson:"default_after_days,omitempty"`
+
+	// when borrowers are emailed about installments, DefaultReminderCadence
+	// when unset
+	Reminders *ReminderCadence `json:"reminders,omitempty" bson:"reminders,omitempty"`
+
+	// charged when borrowers repay principal ahead of schedule
+	PrepaymentPenalty *PrepaymentPenalty `json:"prepayment_penalty,omitempty" bson:"prepayment_penalty,omitempty"`
+
+	// the day count interest accrues under, the server default when unset
+	DayCount string `json:"day_count,omitempty" bson:"day_count,omitempty"`
+}
+
+// RateFor returns the nominal annual rate the product charges on amount.
+func (p *LoanProduct) RateFor(amount Money) (float64, error) {
+	if p.RateType != RateTypeTiered {
+		return p.AnnualRate, nil
+	}
+	for _, tier := range p.RateTiers {
+		if amount.Cmp(tier.MinAmount) >= 0 && amount.Cmp(tier.MaxAmount) <= 0 {
+			return tier.AnnualRate, nil
+		}
+	}
+	return 0, errors.New("no rate tier covers the requested amount")
+}
+
+func (p *LoanProduct) AllowsTerm(term int) bool {
+	for _, allowed := range p.AllowedTerms {
+		if allowed == term {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReasonCodeAutomated explains decisions taken by the decision policy rather
+// than an admin.
+const ReasonCodeAutomated = "AUTOMATED_DECISION"
+
+// ErrInvalidReasonCode is returned when a decision cites a code that is
+// unknown, inactive or meant for the other outcome.
+var ErrInvalidReasonCode = errors.New("invalid reason code")
+
+// ReasonCode is an entry of the admin-managed catalogue every loan decision
+// has to cite. Label is the wording shown to borrowers.
+type ReasonCode struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Code      string             `json:"code" bson:"code"`
+	Label     string             `json:"label" bson:"label"`
+	AppliesTo []string           `json:"applies_to" bson:"applies_to"`
+	Active    bool               `json:"active" bson:"active"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// AppliesToStatus reports whether the code may justify moving a loan to
+// status. Codes without AppliesTo fit any decision.
+func (rc *ReasonCode) AppliesToStatus(status string) bool {
+	if len(rc.AppliesTo) == 0 {
+		return true
+	}
+	for _, s := range rc.AppliesTo {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// LoanDecision is the approval or rejection of a loan with the reason given
+// for it.
+type LoanDecision struct {
+	Status      string    `json:"status" bson:"status"`
+	ReasonCode  string    `json:"reason_code" bson:"reason_code"`
+	ReasonLabel string    `json:"reason_label" bson:"reason_label"`
+	Note        string    `json:"note,omitempty" bson:"note,omitempty"`
+	DecidedBy   string    `json:"decided_by" bson:"decided_by"`
+	DecidedAt   time.Time `json:"decided_at" bson:"decided_at"`
+}
+
+// Summary renders the decision reason for status history and logs.
+func (d LoanDecision) Summary() string {
+	summary := d.ReasonCode
+	if d.Note != "" {
+		summary += ": " + d.Note
+	}
+	return summary
+}
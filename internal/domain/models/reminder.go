@@ -0,0 +1,79 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	ReminderUpcoming = "upcoming"
+	ReminderOverdue  = "overdue"
+)
+
+var ErrReminderAlreadySent = errors.New("reminder already sent")
+
+// ReminderCadence is when a product reminds borrowers about an installment:
+// the days before its due date and the days past due it is emailed on.
+type ReminderCadence struct {
+	DaysBefore  []int `json:"days_before" bson:"days_before"`
+	OverdueDays []int `json:"overdue_days" bson:"overdue_days"`
+}
+
+// DefaultReminderCadence applies to products without a cadence of their own.
+var DefaultReminderCadence = ReminderCadence{
+	DaysBefore:  []int{3, 1},
+	OverdueDays: []int{1, 7, 30},
+}
+
+// Milestone returns the reminder due for an installment daysUntilDue days
+// away, negative once it is late, with the offset it was scheduled at. When
+// a run was missed only the latest milestone reached is returned, so
+// borrowers are not sent a backlog of stale reminders.
+func (c ReminderCadence) Milestone(daysUntilDue int) (string, int, bool) {
+	kind, offset, found := "", 0, false
+	if daysUntilDue >= 0 {
+		for _, days := range c.DaysBefore {
+			if days >= daysUntilDue && (!found || days < offset) {
+				kind, offset, found = ReminderUpcoming, days, true
+			}
+		}
+		return kind, offset, found
+	}
+	for _, days := range c.OverdueDays {
+		if days <= -daysUntilDue && (!found || days > offset) {
+			kind, offset, found = ReminderOverdue, days, true
+		}
+	}
+	return kind, offset, found
+}
+
+// Reminder records an email sent about an installment. Key is unique per
+// schedule version, installment, kind and offset, so each reminder goes out
+// at most once, and again for the same installment number once a restructure
+// or prepayment has rescheduled it. Email is only shown to staff and the
+// borrower.
+type Reminder struct {
+	ID                primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Key               string             `json:"-" bson:"key"`
+	LoanID            primitive.ObjectID `json:"loan_id" bson:"loan_id"`
+	InstallmentNumber int                `json:"installment_number" bson:"installment_number"`
+	Kind              string             `json:"kind" bson:"kind"`
+	Offset            int                `json:"offset_days" bson:"offset_days"`
+	DueDate           time.Time          `json:"due_date" bson:"due_date"`
+	AmountDue         Money              `json:"amount_due" bson:"amount_due"`
+	Email             string             `json:"email,omitempty" bson:"email"`
+	SentAt            time.Time          `json:"sent_at" bson:"sent_at"`
+}
+
+// ReminderKey identifies a reminder. Keys for the first schedule version
+// have no version part, as they did before schedules were versioned.
+func ReminderKey(loanID primitive.ObjectID, scheduleVersion int, installment int, kind string, offset int) string {
+	key := fmt.Sprintf("%s:%d:%s:%d", loanID.Hex(), installment, kind, offset)
+	if scheduleVersion > 1 {
+		key += fmt.Sprintf(":v%d", scheduleVersion)
+	}
+	return key
+}
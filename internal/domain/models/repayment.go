@@ -0,0 +1,63 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	RepaymentChannelAdmin       = "admin"
+	RepaymentChannelIntegration = "integration"
+)
+
+// ErrDuplicateRepayment is returned when a repayment reuses the reference of
+// another one.
+var ErrDuplicateRepayment = errors.New("a repayment with this reference was already recorded")
+
+type Repayment struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	LoanID        primitive.ObjectID `json:"loan_id" bson:"loan_id"`
+	Amount        Money              `json:"amount" bson:"amount"`
+	FeesPaid      Money              `json:"fees_paid" bson:"fees_paid"`
+	InterestPaid  Money              `json:"interest_paid" bson:"interest_paid"`
+	PrincipalPaid Money              `json:"principal_paid" bson:"principal_paid"`
+	Reference     string             `json:"reference" bson:"reference,omitempty"`
+	Channel       string             `json:"channel" bson:"channel"`
+	RecordedBy    string             `json:"recorded_by" bson:"recorded_by,omitempty"`
+	ReceivedAt    time.Time          `json:"received_at" bson:"received_at"`
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`
+
+	// set when the payment closes the loan on a payoff quote, or repays
+	// principal early and says how to reschedule the rest
+	QuoteID    *primitive.ObjectID `json:"quote_id,omitempty" bson:"quote_id,omitempty"`
+	Prepayment string              `json:"prepayment,omitempty" bson:"prepayment,omitempty"`
+
+	// how the payment changes the loan, kept until it has been applied in
+	// full; repayments recorded before it was kept have neither
+	Application *RepaymentApplication `json:"-" bson:"application,omitempty"`
+	AppliedAt   *time.Time            `json:"-" bson:"applied_at,omitempty"`
+}
+
+// Pending reports whether the repayment was recorded but not yet applied in
+// full to the loan.
+func (r Repayment) Pending() bool {
+	return r.Application != nil && r.AppliedAt == nil
+}
+
+// RepaymentApplication is everything a repayment writes to the loan, worked
+// out before any of it is written. A scheduled repayment updates the
+// installments of ScheduleID, an early one replaces the schedule with
+// Schedule and releases interest it no longer charges.
+type RepaymentApplication struct {
+	BalanceBefore        Money              `bson:"balance_before"`
+	OutstandingPrincipal Money              `bson:"outstanding_principal"`
+	OutstandingInterest  Money              `bson:"outstanding_interest"`
+	OutstandingFees      Money              `bson:"outstanding_fees"`
+	ScheduleID           primitive.ObjectID `bson:"schedule_id"`
+	Installments         []Installment      `bson:"installments,omitempty"`
+	Schedule             *RepaymentSchedule `bson:"schedule,omitempty"`
+	ReleasedInterest     Money              `bson:"released_interest"`
+	Postings             []LedgerEntry      `bson:"postings"`
+}
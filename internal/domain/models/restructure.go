@@ -0,0 +1,76 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// An offer the borrower accepts stays accepted while it is applied; one that
+// no longer fits the loan when they answer, e.g. because a payment changed the
+// balance meanwhile, lapses.
+const (
+	RestructureProposed = "proposed"
+	RestructureAccepted = "accepted"
+	RestructureDeclined = "declined"
+	RestructureLapsed   = "lapsed"
+)
+
+// RestructureOfferValidity is how long the borrower has to accept a
+// restructure offer.
+const RestructureOfferValidity = 7 * 24 * time.Hour
+
+var (
+	ErrRestructureNotFound = errors.New("restructure not found")
+	ErrInvalidRestructure  = errors.New("invalid restructure")
+)
+
+// RestructureTerms are the changes a hardship arrangement makes to a loan:
+// more installments, a new rate, unpaid installments folded into principal
+// and months without installments, whose interest is added to principal.
+// A nil AnnualRate keeps the current rate.
+type RestructureTerms struct {
+	ExtendMonths      int      `json:"extend_months" bson:"extend_months"`
+	AnnualRate        *float64 `json:"annual_rate,omitempty" bson:"annual_rate,omitempty"`
+	CapitalizeArrears bool     `json:"capitalize_arrears" bson:"capitalize_arrears"`
+	HolidayMonths     int      `json:"holiday_months" bson:"holiday_months"`
+}
+
+// LoanTerms summarises a loan's schedule on a given day, to compare it
+// before and after a restructure. Installment is the next one not yet due.
+type LoanTerms struct {
+	ScheduleVersion       int       `json:"schedule_version" bson:"schedule_version"`
+	AnnualRate            float64   `json:"annual_rate" bson:"annual_rate"`
+	RemainingInstallments int       `json:"remaining_installments" bson:"remaining_installments"`
+	Installment           Money     `json:"installment" bson:"installment"`
+	OutstandingPrincipal  Money     `json:"outstanding_principal" bson:"outstanding_principal"`
+	OutstandingInterest   Money     `json:"outstanding_interest" bson:"outstanding_interest"`
+	Arrears               Money     `json:"arrears" bson:"arrears"`
+	MaturityDate          time.Time `json:"maturity_date" bson:"maturity_date"`
+}
+
+// Restructure is an admin's offer to change a loan's terms, applied once the
+// borrower accepts it through the emailed link. Every offer carries
+// Schedule, the schedule projected when the offer is made, the one After
+// summarises and the borrower was shown. It is the schedule applied on
+// acceptance, as long as the loan still stands where it did on
+// BaseScheduleID, the schedule it was projected from.
+type Restructure struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	LoanID      primitive.ObjectID `json:"loan_id" bson:"loan_id"`
+	Terms       RestructureTerms   `json:"terms" bson:"terms"`
+	Note        string             `json:"note,omitempty" bson:"note,omitempty"`
+	Status      string             `json:"status" bson:"status"`
+	Before      LoanTerms          `json:"before" bson:"before"`
+	After       LoanTerms          `json:"after" bson:"after"`
+	Token       string             `json:"-" bson:"token"`
+	ExpiresAt   time.Time          `json:"expires_at" bson:"expires_at"`
+	ProposedBy  string             `json:"proposed_by" bson:"proposed_by"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+	RespondedAt *time.Time         `json:"responded_at,omitempty" bson:"responded_at,omitempty"`
+	AppliedAt   *time.Time         `json:"applied_at,omitempty" bson:"applied_at,omitempty"`
+
+	BaseScheduleID primitive.ObjectID `json:"-" bson:"base_schedule_id"`
+	Schedule       *RepaymentSchedule `json:"-" bson:"schedule"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrScheduleNotFound = errors.New("repayment schedule not found")
+
+type Installment struct {
+	Number    int       `json:"number" bson:"number"`
+	DueDate   time.Time `json:"due_date" bson:"due_date"`
+	Principal Money     `json:"principal" bson:"principal"`
+	Interest  Money     `json:"interest" bson:"interest"`
+	Payment   Money     `json:"payment" bson:"payment"`
+	Balance   Money     `json:"balance" bson:"balance"`
+
+	PaidPrincipal Money `json:"paid_principal" bson:"paid_principal"`
+	PaidInterest  Money `json:"paid_interest" bson:"paid_interest"`
+	Paid          bool  `json:"paid" bson:"paid"`
+
+	// charged once when the installment is left unpaid past the grace period
+	LateFee *Money `json:"late_fee,omitempty" bson:"late_fee,omitempty"`
+}
+
+type RepaymentSchedule struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	LoanID        primitive.ObjectID `json:"loan_id" bson:"loan_id"`
+	Method        string             `json:"method" bson:"method"`
+	Principal     Money              `json:"principal" bson:"principal"`
+	AnnualRate    float64            `json:"annual_rate" bson:"annual_rate"`
+	Term          int                `json:"term" bson:"term"`
+	TotalInterest Money              `json:"total_interest" bson:"total_interest"`
+	TotalPayment  Money              `json:"total_payment" bson:"total_payment"`
+	Installments  []Installment      `json:"installments" bson:"installments"`
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`
+
+	// each regeneration of a loan's schedule is stored as a new version,
+	// keeping the earlier ones for audit
+	Version int `json:"version" bson:"version"`
+}
+
+// NextVersion is the version of the schedule that replaces s.
+func (s *RepaymentSchedule) NextVersion() int {
+	return s.Version + 1
+}
+
+// AddMonths moves t by months calendar months, keeping its day of month but
+// clamping it to the last day of shorter months, so a schedule started on
+// Jan 31 falls due on Feb 28 (29 in leap years), Mar 31, Apr 30 and so on.
+func AddMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -10,11 +12,12 @@ type User struct {
 	Email  				string             `json:"email" bson:"email"`
 	Password 			string			   `json:"password" bson:"password"`
 	Role   				string             `json:"role" bson:"role"`
-	Age    				int                `json:"age" bson:"age"`
+	DateOfBirth			*time.Time         `json:"date_of_birth,omitempty" bson:"date_of_birth,omitempty"`
 	PhoneNum			string             `json:"phone_num" bson:"phone_num"`
 	Bio   				string             `json:"bio" bson:"bio"`
 	ProfilePicture		string             `json:"profile_picture" bson:"profile_picture"`
 	IsVerified			bool 			   `json:"is_verified" bson:"is_verified"`
 	VerificationToken	string			   `json:"-" bson:"verification_token"`	
 	RefToken			string 			   `json:"-" bson:"refresh_token"`	
+	KYC					KYCProfile         `json:"kyc" bson:"kyc"`
 }
@@ -0,0 +1,53 @@
+// Package jobs runs background work on a timer inside the API process.
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Every runs job every interval until ctx is done. A failing run is logged
+// and the next one goes ahead as planned.
+func Every(ctx context.Context, name string, interval time.Duration, job func() error) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run(name, job)
+			}
+		}
+	}()
+}
+
+func run(name string, job func() error) {
+	if err := job(); err != nil {
+		log.Printf("job %s failed: %v", name, err)
+	}
+}
+
+// Daily runs job once a day at the given time of day, in the server's
+// local time, until ctx is done.
+func Daily(ctx context.Context, name string, hour, minute int, job func() error) {
+	go func() {
+		for {
+			now := time.Now()
+			next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+			if !next.After(now) {
+				next = next.AddDate(0, 0, 1)
+			}
+			timer := time.NewTimer(next.Sub(now))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				run(name, job)
+			}
+		}
+	}()
+}
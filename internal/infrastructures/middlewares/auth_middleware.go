@@ -2,6 +2,7 @@ package middlewares
 
 import (
 	"LoanGuard/internal/infrastructures/services"
+	"crypto/subtle"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -12,17 +13,20 @@ import (
 type IAuthMiddleware interface{
 	Authentication() gin.HandlerFunc
 	RoleAuth(roles ...string) gin.HandlerFunc
+	IntegrationAuth() gin.HandlerFunc
 }
 
 type AuthMiddleware struct {
 	jwtSvc services.IJWTService
 	cacheSvc services.ICacheService
+	integrationKey string
 }
 
-func NewAuthMiddleware(jwtSvc services.IJWTService, cacheSvc services.ICacheService) IAuthMiddleware{
+func NewAuthMiddleware(jwtSvc services.IJWTService, cacheSvc services.ICacheService, integrationKey string) IAuthMiddleware{
 	return &AuthMiddleware{
 		jwtSvc: jwtSvc,
 		cacheSvc: cacheSvc,
+		integrationKey: integrationKey,
 	}
 }
 
@@ -119,4 +123,26 @@ func (mid *AuthMiddleware) RoleAuth(roles ...string) gin.HandlerFunc {
 	}
 }
 
+// IntegrationAuth lets payment integrations call a route with the shared
+// X-Api-Key header instead of a user token. The request then carries the
+// INTEGRATION role so it can be combined with RoleAuth. Requests without the
+// header fall back to regular bearer token authentication.
+func (mid *AuthMiddleware) IntegrationAuth() gin.HandlerFunc {
+	authenticate := mid.Authentication()
+	return func(c *gin.Context) {
+		apiKey := c.GetHeader("X-Api-Key")
+		if apiKey == "" {
+			authenticate(c)
+			return
+		}
+
+		if mid.integrationKey == "" || subtle.ConstantTimeCompare([]byte(apiKey), []byte(mid.integrationKey)) != 1 {
+			c.JSON(401, gin.H{"error": "Invalid API key"})
+			c.Abort()
+			return
+		}
 
+		c.Set("claims", jwt.MapClaims{"user_id": "", "role": "INTEGRATION"})
+		c.Next()
+	}
+}
@@ -0,0 +1,87 @@
+package middlewares
+
+import (
+	"LoanGuard/internal/domain/models"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+type ILoanAccessMiddleware interface {
+	OwnerOrAdmin() gin.HandlerFunc
+	OwnerOr(roles ...string) gin.HandlerFunc
+	PartyOr(roles ...string) gin.HandlerFunc
+}
+
+// LoanGetter is the part of the loan repository the middleware reads from.
+type LoanGetter interface {
+	GetLoanByID(loanID string) (*models.Loan, error)
+}
+
+type LoanAccessMiddleware struct {
+	loanRepo LoanGetter
+}
+
+func NewLoanAccessMiddleware(loanRepo LoanGetter) ILoanAccessMiddleware {
+	return &LoanAccessMiddleware{
+		loanRepo: loanRepo,
+	}
+}
+
+// OwnerOrAdmin lets a request for the loan in the :id path parameter through
+// only when the caller is the borrower or an admin.
+func (mid *LoanAccessMiddleware) OwnerOrAdmin() gin.HandlerFunc {
+	return mid.OwnerOr("ADMIN")
+}
+
+// OwnerOr lets the borrower and callers holding any of roles reach the loan in
+// the :id path parameter. It must run after authentication. Everyone else is
+// told the loan does not exist, so loan ids cannot be probed. The loan is
+// left in the context under "loan" for the handler.
+func (mid *LoanAccessMiddleware) OwnerOr(roles ...string) gin.HandlerFunc {
+	return mid.guard(func(loan *models.Loan, userID, role string) bool {
+		return canAccessLoan(loan, userID, role, roles)
+	})
+}
+
+// PartyOr is OwnerOr that also lets in guarantors and co-borrowers who have
+// accepted their invitation. Use it only for routes that read the loan.
+func (mid *LoanAccessMiddleware) PartyOr(roles ...string) gin.HandlerFunc {
+	return mid.guard(func(loan *models.Loan, userID, role string) bool {
+		return canAccessLoan(loan, userID, role, roles) || (userID != "" && loan.PartyRole(userID) != "")
+	})
+}
+
+func (mid *LoanAccessMiddleware) guard(allowed func(loan *models.Loan, userID, role string) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, _ := c.Get("claims")
+		claimsMap, ok := claims.(jwt.MapClaims)
+		if !ok {
+			c.JSON(401, gin.H{"error": "Claims not found in context"})
+			c.Abort()
+			return
+		}
+		userID, _ := claimsMap["user_id"].(string)
+		role, _ := claimsMap["role"].(string)
+
+		loan, err := mid.loanRepo.GetLoanByID(c.Param("id"))
+		if err != nil || !allowed(loan, userID, role) {
+			c.JSON(404, gin.H{"error": models.ErrLoanNotFound.Error()})
+			c.Abort()
+			return
+		}
+
+		c.Set("loan", loan)
+		c.Next()
+	}
+}
+
+func canAccessLoan(loan *models.Loan, userID string, role string, roles []string) bool {
+	for _, elem := range roles {
+		if role != "" && strings.EqualFold(elem, role) {
+			return true
+		}
+	}
+	return userID != "" && loan.UserId.Hex() == userID
+}
@@ -0,0 +1,102 @@
+package middlewares
+
+import (
+	"LoanGuard/internal/domain/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// memoryLoanRepository is an in-memory LoanGetter holding loans by id.
+type memoryLoanRepository struct {
+	loans map[string]*models.Loan
+}
+
+func newMemoryLoanRepository(loans ...*models.Loan) *memoryLoanRepository {
+	repo := &memoryLoanRepository{loans: map[string]*models.Loan{}}
+	for _, loan := range loans {
+		repo.loans[loan.ID.Hex()] = loan
+	}
+	return repo
+}
+
+func (r *memoryLoanRepository) GetLoanByID(loanID string) (*models.Loan, error) {
+	if _, err := primitive.ObjectIDFromHex(loanID); err != nil {
+		return nil, err
+	}
+	loan, ok := r.loans[loanID]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return loan, nil
+}
+
+func serveLoanRoute(t *testing.T, handler gin.HandlerFunc, claims jwt.MapClaims, loanID string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/loan/:id", func(c *gin.Context) {
+		if claims != nil {
+			c.Set("claims", claims)
+		}
+		c.Next()
+	}, handler, func(c *gin.Context) {
+		loan, _ := c.Get("loan")
+		c.JSON(http.StatusOK, gin.H{"loan": loan})
+	})
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/loan/"+loanID, nil)
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestLoanAccessMiddleware(t *testing.T) {
+	owner := primitive.NewObjectID()
+	stranger := primitive.NewObjectID()
+	guarantor := primitive.NewObjectID()
+	invitee := primitive.NewObjectID()
+	loan := &models.Loan{ID: primitive.NewObjectID(), UserId: owner, Status: models.LoanStatusPending, Parties: []models.LoanParty{
+		{ID: primitive.NewObjectID(), UserID: guarantor, Role: models.PartyRoleGuarantor, Status: models.PartyStatusAccepted},
+		{ID: primitive.NewObjectID(), UserID: invitee, Role: models.PartyRoleCoBorrower, Status: models.PartyStatusInvited},
+	}}
+	access := NewLoanAccessMiddleware(newMemoryLoanRepository(loan))
+
+	tests := []struct {
+		name    string
+		handler gin.HandlerFunc
+		claims  jwt.MapClaims
+		loanID  string
+		want    int
+	}{
+		{"owner", access.OwnerOrAdmin(), jwt.MapClaims{"user_id": owner.Hex(), "role": "USER"}, loan.ID.Hex(), http.StatusOK},
+		{"admin", access.OwnerOrAdmin(), jwt.MapClaims{"user_id": stranger.Hex(), "role": "ADMIN"}, loan.ID.Hex(), http.StatusOK},
+		{"admin role is case insensitive", access.OwnerOrAdmin(), jwt.MapClaims{"user_id": stranger.Hex(), "role": "admin"}, loan.ID.Hex(), http.StatusOK},
+		{"other borrower", access.OwnerOrAdmin(), jwt.MapClaims{"user_id": stranger.Hex(), "role": "USER"}, loan.ID.Hex(), http.StatusNotFound},
+		{"empty user id", access.OwnerOrAdmin(), jwt.MapClaims{"user_id": "", "role": "USER"}, loan.ID.Hex(), http.StatusNotFound},
+		{"unknown loan", access.OwnerOrAdmin(), jwt.MapClaims{"user_id": owner.Hex(), "role": "USER"}, primitive.NewObjectID().Hex(), http.StatusNotFound},
+		{"malformed loan id", access.OwnerOrAdmin(), jwt.MapClaims{"user_id": owner.Hex(), "role": "ADMIN"}, "not-an-id", http.StatusNotFound},
+		{"integration not allowed by default", access.OwnerOrAdmin(), jwt.MapClaims{"user_id": "", "role": "INTEGRATION"}, loan.ID.Hex(), http.StatusNotFound},
+		{"integration allowed explicitly", access.OwnerOr("ADMIN", "INTEGRATION"), jwt.MapClaims{"user_id": "", "role": "INTEGRATION"}, loan.ID.Hex(), http.StatusOK},
+		{"missing claims", access.OwnerOrAdmin(), nil, loan.ID.Hex(), http.StatusUnauthorized},
+		{"accepted party reads loan", access.PartyOr("ADMIN"), jwt.MapClaims{"user_id": guarantor.Hex(), "role": "USER"}, loan.ID.Hex(), http.StatusOK},
+		{"accepted party not owner", access.OwnerOrAdmin(), jwt.MapClaims{"user_id": guarantor.Hex(), "role": "USER"}, loan.ID.Hex(), http.StatusNotFound},
+		{"invited party", access.PartyOr("ADMIN"), jwt.MapClaims{"user_id": invitee.Hex(), "role": "USER"}, loan.ID.Hex(), http.StatusNotFound},
+		{"owner through party route", access.PartyOr("ADMIN"), jwt.MapClaims{"user_id": owner.Hex(), "role": "USER"}, loan.ID.Hex(), http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serveLoanRoute(t, tt.handler, tt.claims, tt.loanID)
+			if recorder.Code != tt.want {
+				t.Fatalf("status = %d, want %d (body %s)", recorder.Code, tt.want, recorder.Body.String())
+			}
+		})
+	}
+}
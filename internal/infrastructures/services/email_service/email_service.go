@@ -11,6 +11,72 @@ import (
 type IEmailService interface {
 	SendResetEmail(to, link string) error
 	SendVerificationEmail(to, link string) error
+	SendLoanDecisionEmail(to string, data LoanDecisionEmail) error
+	SendMentionEmail(to string, data MentionEmail) error
+	SendPartyInvitationEmail(to string, data PartyInvitationEmail) error
+	SendPaymentReminderEmail(to string, data PaymentReminderEmail) error
+	SendRestructureOfferEmail(to string, data RestructureOfferEmail) error
+}
+
+// LoanDecisionEmail fills the loan decision template.
+type LoanDecisionEmail struct {
+	Name        string
+	LoanID      string
+	Amount      string
+	Approved    bool
+	ReasonLabel string
+	Note        string
+}
+
+// MentionEmail fills the comment mention template.
+type MentionEmail struct {
+	Name       string
+	AuthorName string
+	LoanID     string
+	Comment    string
+}
+
+// PartyInvitationEmail fills the guarantor and co-borrower invitation
+// template.
+type PartyInvitationEmail struct {
+	BorrowerName string
+	Role         string
+	LoanID       string
+	Amount       string
+	Consent      string
+}
+
+// PaymentReminderEmail fills the upcoming installment and overdue notice
+// template.
+type PaymentReminderEmail struct {
+	Name              string
+	LoanID            string
+	InstallmentNumber int
+	DueDate           string
+	AmountDue         string
+	Overdue           bool
+	DaysPastDue       int
+	DaysUntilDue      int
+}
+
+// RestructureOfferEmail fills the restructure offer template with the
+// loan's current and proposed terms.
+type RestructureOfferEmail struct {
+	Name               string
+	LoanID             string
+	Note               string
+	CurrentRate        string
+	NewRate            string
+	CurrentInstallment string
+	NewInstallment     string
+	CurrentRemaining   int
+	NewRemaining       int
+	CurrentMaturity    string
+	NewMaturity        string
+	HolidayMonths      int
+	CapitalizeArrears  bool
+	ReviewLink         string
+	ExpiresAt          string
 }
 
 type EmailService struct {
@@ -62,17 +128,72 @@ func (e *EmailService) SendVerificationEmail(to, link string) error {
 	return e.SendEmail(to, "Verify Your Email", body)
 }
 
+func (e *EmailService) SendLoanDecisionEmail(to string, data LoanDecisionEmail) error {
+	templatePath := filepath.Join("../internal/infrastructures/services/email_service/templates", "loan_decision.html")
+	body, err := executeTemplate(templatePath, data)
+	if err != nil {
+		return err
+	}
+	subject := "Your Loan Application Was Not Approved"
+	if data.Approved {
+		subject = "Your Loan Application Was Approved"
+	}
+	return e.SendEmail(to, subject, body)
+}
+
+func (e *EmailService) SendMentionEmail(to string, data MentionEmail) error {
+	templatePath := filepath.Join("../internal/infrastructures/services/email_service/templates", "comment_mention.html")
+	body, err := executeTemplate(templatePath, data)
+	if err != nil {
+		return err
+	}
+	return e.SendEmail(to, data.AuthorName+" mentioned you on loan "+data.LoanID, body)
+}
+
+func (e *EmailService) SendPartyInvitationEmail(to string, data PartyInvitationEmail) error {
+	templatePath := filepath.Join("../internal/infrastructures/services/email_service/templates", "party_invitation.html")
+	body, err := executeTemplate(templatePath, data)
+	if err != nil {
+		return err
+	}
+	return e.SendEmail(to, "You have been invited to a loan on LoanGuard", body)
+}
+
+func (e *EmailService) SendPaymentReminderEmail(to string, data PaymentReminderEmail) error {
+	templatePath := filepath.Join("../internal/infrastructures/services/email_service/templates", "payment_reminder.html")
+	body, err := executeTemplate(templatePath, data)
+	if err != nil {
+		return err
+	}
+	subject := "Your loan installment is due soon"
+	if data.Overdue {
+		subject = "Your loan installment is overdue"
+	}
+	return e.SendEmail(to, subject, body)
+}
+
+func (e *EmailService) SendRestructureOfferEmail(to string, data RestructureOfferEmail) error {
+	templatePath := filepath.Join("../internal/infrastructures/services/email_service/templates", "restructure_offer.html")
+	body, err := executeTemplate(templatePath, data)
+	if err != nil {
+		return err
+	}
+	return e.SendEmail(to, "New terms offered for your loan "+data.LoanID, body)
+}
+
 func parseTemplate(templatePath, link string) (string, error) {
+	return executeTemplate(templatePath, map[string]string{
+		"Link": link,
+	})
+}
+
+func executeTemplate(templatePath string, data interface{}) (string, error) {
 	tmpl, err := template.ParseFiles(templatePath)
 	if err != nil {
 		return "", err
 	}
 
 	var body bytes.Buffer
-	data := map[string]string{
-		"Link": link,
-	}
-
 	if err := tmpl.Execute(&body, data); err != nil {
 		return "", err
 	}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+)
+
+// IFileStorage keeps uploaded files. Save stores content under key, a slash
+// separated path such as "loans/<id>/<file>", and returns the key to open it
+// with again. Keys say nothing about where or how the file is stored, and
+// files are never reachable without going through Open.
+type IFileStorage interface {
+	Save(key string, content io.Reader) (string, error)
+	Open(key string) (io.ReadCloser, error)
+}
+
+// LocalFileStorage keeps files on the local filesystem under a root
+// directory. It is meant for development and tests.
+type LocalFileStorage struct {
+	root string
+}
+
+func NewLocalFileStorage(root string) IFileStorage {
+	return &LocalFileStorage{root: root}
+}
+
+func (fs *LocalFileStorage) Save(key string, content io.Reader) (string, error) {
+	key = cleanKey(key)
+	target := fs.path(key)
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return "", err
+	}
+	file, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()
+		os.Remove(target)
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// Open also accepts the paths under the root that earlier versions stored.
+func (fs *LocalFileStorage) Open(key string) (io.ReadCloser, error) {
+	if legacy, ok := strings.CutPrefix(filepath.Clean(key), filepath.Clean(fs.root)+string(filepath.Separator)); ok {
+		key = filepath.ToSlash(legacy)
+	} else if filepath.IsAbs(key) {
+		return nil, errors.New("file is outside the document storage")
+	}
+	return os.Open(fs.path(cleanKey(key)))
+}
+
+func (fs *LocalFileStorage) path(key string) string {
+	return filepath.Join(fs.root, filepath.FromSlash(key))
+}
+
+// cleanKey keeps a key from climbing out of the storage root.
+func cleanKey(key string) string {
+	return strings.TrimPrefix(path.Clean("/"+key), "/")
+}
+
+// CloudinaryFileStorage uploads files to Cloudinary under a folder as private
+// assets, which can only be fetched with a signed download URL.
+type CloudinaryFileStorage struct {
+	cld    *cloudinary.Cloudinary
+	folder string
+}
+
+// cloudinaryDownloadExpiry is how long the signed URL Open fetches a file
+// with stays valid.
+const cloudinaryDownloadExpiry = 5 * time.Minute
+
+func NewCloudinaryFileStorage(cloudName, apiKey, apiSecret, folder string) (IFileStorage, error) {
+	cld, err := cloudinary.NewFromParams(cloudName, apiKey, apiSecret)
+	if err != nil {
+		return nil, err
+	}
+	return &CloudinaryFileStorage{cld: cld, folder: folder}, nil
+}
+
+func (fs *CloudinaryFileStorage) Save(key string, content io.Reader) (string, error) {
+	key = cleanKey(key)
+	uploadParams := uploader.UploadParams{
+		Folder:       path.Join(fs.folder, path.Dir(key)),
+		PublicID:     strings.TrimSuffix(path.Base(key), path.Ext(key)),
+		ResourceType: "image",
+		Type:         api.Private,
+	}
+	if _, err := fs.cld.Upload.Upload(context.Background(), content, uploadParams); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// Open also accepts the public URLs earlier versions stored.
+func (fs *CloudinaryFileStorage) Open(key string) (io.ReadCloser, error) {
+	url := key
+	if !strings.HasPrefix(key, "https://") {
+		key = cleanKey(key)
+		expiresAt := time.Now().Add(cloudinaryDownloadExpiry)
+		var err error
+		url, err = fs.cld.Upload.PrivateDownloadURL(uploader.PrivateDownloadURLParams{
+			PublicID:     path.Join(fs.folder, strings.TrimSuffix(key, path.Ext(key))),
+			Format:       strings.TrimPrefix(path.Ext(key), "."),
+			DeliveryType: api.Private,
+			ExpiresAt:    &expiresAt,
+			ResourceType: api.Image,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	response, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, fmt.Errorf("document storage answered %s", response.Status)
+	}
+	return response.Body, nil
+}
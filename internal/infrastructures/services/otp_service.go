@@ -9,4 +9,11 @@ func GenerateOTP() string {
 	bytes := make([]byte, 3)
 	rand.Read(bytes)
 	return hex.EncodeToString(bytes)
-}
\ No newline at end of file
+}
+// GenerateLinkToken returns a random token for links emailed to users,
+// long enough that it cannot be guessed.
+func GenerateLinkToken() string {
+	bytes := make([]byte, 32)
+	rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}
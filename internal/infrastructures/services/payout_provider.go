@@ -0,0 +1,123 @@
+package services
+
+import (
+	"LoanGuard/internal/domain/models"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// PayoutRequest asks a provider to send money. Reference is unique per
+// disbursement and is how the provider recognises a repeated request.
+type PayoutRequest struct {
+	Reference   string                   `json:"reference"`
+	LoanID      string                   `json:"loan_id"`
+	Amount      models.Money             `json:"amount"`
+	Destination models.PayoutDestination `json:"destination"`
+}
+
+// IPayoutProvider moves loan money to the borrower. Payout returns the
+// provider's own reference for the transfer. A request repeated with the
+// same Reference must not pay out twice.
+type IPayoutProvider interface {
+	Name() string
+	Payout(req PayoutRequest) (string, error)
+}
+
+// FakePayoutProvider pays out in memory. It fails the first failures
+// requests it receives so retries can be exercised, and is meant for
+// development and tests.
+type FakePayoutProvider struct {
+	mu       sync.Mutex
+	failures int
+	payouts  map[string]string
+}
+
+func NewFakePayoutProvider(failures int) IPayoutProvider {
+	return &FakePayoutProvider{
+		failures: failures,
+		payouts:  map[string]string{},
+	}
+}
+
+func (p *FakePayoutProvider) Name() string {
+	return "fake"
+}
+
+func (p *FakePayoutProvider) Payout(req PayoutRequest) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if reference, ok := p.payouts[req.Reference]; ok {
+		return reference, nil
+	}
+	if p.failures > 0 {
+		p.failures--
+		return "", errors.New("fake payout provider unavailable")
+	}
+	reference := fmt.Sprintf("fake-%d", len(p.payouts)+1)
+	p.payouts[req.Reference] = reference
+	return reference, nil
+}
+
+// HTTPPayoutProvider posts payout requests as JSON to a webhook. The body is
+// signed with HMAC-SHA256 under secret in the X-Signature header and the
+// reference is sent as the Idempotency-Key header. Any 2xx answer carrying
+// {"reference": "..."} is a successful payout.
+type HTTPPayoutProvider struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+func NewHTTPPayoutProvider(url, secret string) IPayoutProvider {
+	return &HTTPPayoutProvider{
+		url:    url,
+		secret: secret,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (p *HTTPPayoutProvider) Name() string {
+	return "http"
+}
+
+func (p *HTTPPayoutProvider) Payout(req PayoutRequest) (string, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, []byte(p.secret))
+	mac.Write(body)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Idempotency-Key", req.Reference)
+	httpReq.Header.Set("X-Signature", hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("payout webhook answered %d: %s", resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+	var result struct {
+		Reference string `json:"reference"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil || result.Reference == "" {
+		return "", errors.New("payout webhook answered without a reference")
+	}
+	return result.Reference, nil
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"LoanGuard/internal/domain/models"
+	"errors"
+	"math"
+	"math/big"
+	"time"
+)
+
+type IScheduleService interface {
+	GenerateSchedule(principal models.Money, annualRate float64, term int, method string, startDate time.Time) (*models.RepaymentSchedule, error)
+}
+
+type ScheduleService struct{}
+
+func NewScheduleService() IScheduleService {
+	return &ScheduleService{}
+}
+
+// GenerateSchedule builds a monthly installment plan starting one month after
+// startDate, each installment falling due on startDate's day of month or the
+// last day of shorter months. Every amount is an exact number of minor units and the final
+// installment absorbs any rounding remainder so the plan always pays the
+// principal off exactly.
+func (s *ScheduleService) GenerateSchedule(principal models.Money, annualRate float64, term int, method string, startDate time.Time) (*models.RepaymentSchedule, error) {
+	if principal.Amount <= 0 {
+		return nil, errors.New("principal must be greater than zero")
+	}
+	if term <= 0 {
+		return nil, errors.New("term must be at least one month")
+	}
+	if annualRate < 0 {
+		return nil, errors.New("interest rate cannot be negative")
+	}
+
+	var installments []models.Installment
+	switch method {
+	case models.RepaymentMethodAnnuity:
+		installments = annuityInstallments(principal, annualRate, term, startDate)
+	case models.RepaymentMethodFlat:
+		installments = flatInstallments(principal, annualRate, term, startDate)
+	default:
+		return nil, errors.New("unsupported repayment method")
+	}
+
+	schedule := &models.RepaymentSchedule{
+		Method:        method,
+		Principal:     principal,
+		AnnualRate:    annualRate,
+		Term:          term,
+		TotalInterest: models.NewMoney(0, principal.Currency),
+		TotalPayment:  models.NewMoney(0, principal.Currency),
+		Installments:  installments,
+		CreatedAt:     time.Now(),
+	}
+	for _, inst := range installments {
+		schedule.TotalInterest = schedule.TotalInterest.Add(inst.Interest)
+		schedule.TotalPayment = schedule.TotalPayment.Add(inst.Payment)
+	}
+	return schedule, nil
+}
+
+func annuityInstallments(principal models.Money, annualRate float64, term int, startDate time.Time) []models.Installment {
+	monthlyRate := new(big.Rat).Quo(models.RateFromFloat(annualRate), big.NewRat(12, 1))
+
+	// the level payment itself comes from the closed-form annuity formula;
+	// only its rounding to a whole minor unit matters, the split below is exact
+	payment := principal.Div(int64(term))
+	if monthlyRate.Sign() > 0 {
+		r, _ := monthlyRate.Float64()
+		factor := r / (1 - math.Pow(1+r, -float64(term)))
+		payment = models.MoneyFromFloat(principal.Float64()*factor, principal.Currency)
+	}
+
+	installments := make([]models.Installment, 0, term)
+	balance := principal
+	for i := 1; i <= term; i++ {
+		interest := balance.Mul(monthlyRate)
+		principalPart := payment.Sub(interest)
+		if i == term || principalPart.Cmp(balance) > 0 {
+			principalPart = balance
+		}
+		balance = balance.Sub(principalPart)
+		installments = append(installments, newInstallment(i, startDate, principalPart, interest, balance))
+	}
+	return installments
+}
+
+func flatInstallments(principal models.Money, annualRate float64, term int, startDate time.Time) []models.Installment {
+	totalInterest := principal.Mul(new(big.Rat).Mul(models.RateFromFloat(annualRate), big.NewRat(int64(term), 12)))
+	principalPart := principal.Div(int64(term))
+	interestPart := totalInterest.Div(int64(term))
+
+	installments := make([]models.Installment, 0, term)
+	balance := principal
+	interestLeft := totalInterest
+	for i := 1; i <= term; i++ {
+		p, in := principalPart, interestPart
+		if i == term {
+			p, in = balance, interestLeft
+		}
+		balance = balance.Sub(p)
+		interestLeft = interestLeft.Sub(in)
+		installments = append(installments, newInstallment(i, startDate, p, in, balance))
+	}
+	return installments
+}
+
+func newInstallment(number int, startDate time.Time, principal, interest, balance models.Money) models.Installment {
+	zero := models.NewMoney(0, principal.Currency)
+	return models.Installment{
+		Number:        number,
+		DueDate:       models.AddMonths(startDate, number),
+		Principal:     principal,
+		Interest:      interest,
+		Payment:       principal.Add(interest),
+		Balance:       balance,
+		PaidPrincipal: zero,
+		PaidInterest:  zero,
+	}
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"LoanGuard/internal/domain/models"
+	"testing"
+	"time"
+)
+
+func TestGenerateScheduleDueDates(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name  string
+		start time.Time
+		want  []time.Time
+	}{
+		{"29th through a leap February", date(2028, 1, 29), []time.Time{date(2028, 2, 29), date(2028, 3, 29), date(2028, 4, 29)}},
+		{"29th through a common February", date(2026, 1, 29), []time.Time{date(2026, 2, 28), date(2026, 3, 29), date(2026, 4, 29)}},
+		{"30th", date(2026, 1, 30), []time.Time{date(2026, 2, 28), date(2026, 3, 30), date(2026, 4, 30)}},
+		{"31st", date(2026, 1, 31), []time.Time{date(2026, 2, 28), date(2026, 3, 31), date(2026, 4, 30)}},
+		{"31st in a leap year", date(2028, 1, 31), []time.Time{date(2028, 2, 29), date(2028, 3, 31), date(2028, 4, 30)}},
+		{"31st across the year end", date(2026, 10, 31), []time.Time{date(2026, 11, 30), date(2026, 12, 31), date(2027, 1, 31)}},
+	}
+
+	svc := NewScheduleService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, method := range []string{models.RepaymentMethodAnnuity, models.RepaymentMethodFlat} {
+				schedule, err := svc.GenerateSchedule(models.NewMoney(300000, "USD"), 0.12, len(tt.want), method, tt.start)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for i, inst := range schedule.Installments {
+					if !inst.DueDate.Equal(tt.want[i]) {
+						t.Fatalf("%s installment %d due %s, want %s", method, inst.Number, inst.DueDate.Format("2006-01-02"), tt.want[i].Format("2006-01-02"))
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package implementations
+
+import (
+	"context"
+	"time"
+
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoCollateralRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoCollateralRepository(db *mongo.Database) repository_interface.ICollateralRepository {
+	collection := db.Collection("collateral")
+	collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "loan_id", Value: 1}, {Key: "created_at", Value: 1}},
+	})
+	return &mongoCollateralRepository{
+		collection: collection,
+	}
+}
+
+func (r *mongoCollateralRepository) CreateCollateral(collateral *models.Collateral) (*models.Collateral, error) {
+	if collateral.ID == primitive.NilObjectID {
+		collateral.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(context.Background(), collateral)
+	if err != nil {
+		return nil, err
+	}
+	return collateral, nil
+}
+
+func (r *mongoCollateralRepository) GetCollateral(collateralID string) (*models.Collateral, error) {
+	Id, err := primitive.ObjectIDFromHex(collateralID)
+	if err != nil {
+		return nil, err
+	}
+	var collateral models.Collateral
+	err = r.collection.FindOne(context.Background(), bson.M{"_id": Id}).Decode(&collateral)
+	if err != nil {
+		return nil, err
+	}
+	return &collateral, nil
+}
+
+// GetCollateralByLoan returns the collateral pledged for the loan, oldest
+// first.
+func (r *mongoCollateralRepository) GetCollateralByLoan(loanID string) ([]models.Collateral, error) {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return nil, err
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(context.Background(), bson.M{"loan_id": Id}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	collateral := []models.Collateral{}
+	if err := cursor.All(context.Background(), &collateral); err != nil {
+		return nil, err
+	}
+	return collateral, nil
+}
+
+func (r *mongoCollateralRepository) UpdateCollateral(collateral *models.Collateral) error {
+	_, err := r.collection.ReplaceOne(context.Background(), bson.M{"_id": collateral.ID}, collateral)
+	return err
+}
+
+func (r *mongoCollateralRepository) DeleteCollateral(collateralID string) error {
+	Id, err := primitive.ObjectIDFromHex(collateralID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.DeleteOne(context.Background(), bson.M{"_id": Id})
+	return err
+}
+
+// UpdateLienStatus moves the lien on every item of the loan's collateral in
+// status from to status to, stamping when the lien became active or was
+// released.
+func (r *mongoCollateralRepository) UpdateLienStatus(loanID string, from string, to string, at time.Time) error {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return err
+	}
+	set := bson.M{"lien_status": to, "updated_at": at}
+	switch to {
+	case models.LienStatusActive:
+		set["lien_active_at"] = at
+	case models.LienStatusReleased:
+		set["lien_released_at"] = at
+	}
+	_, err = r.collection.UpdateMany(context.Background(), bson.M{"loan_id": Id, "lien_status": from}, bson.M{"$set": set})
+	return err
+}
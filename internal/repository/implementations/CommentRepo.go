@@ -0,0 +1,81 @@
+package implementations
+
+import (
+	"context"
+
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoCommentRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoCommentRepository(db *mongo.Database) repository_interface.ICommentRepository {
+	collection := db.Collection("comments")
+	collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "loan_id", Value: 1}, {Key: "created_at", Value: 1}},
+	})
+	return &mongoCommentRepository{
+		collection: collection,
+	}
+}
+
+func (r *mongoCommentRepository) CreateComment(comment *models.Comment) (*models.Comment, error) {
+	if comment.ID == primitive.NilObjectID {
+		comment.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(context.Background(), comment)
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (r *mongoCommentRepository) GetComment(commentID string) (*models.Comment, error) {
+	Id, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return nil, err
+	}
+	var comment models.Comment
+	err = r.collection.FindOne(context.Background(), bson.M{"_id": Id}).Decode(&comment)
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// GetComments returns the loan's comments oldest first. Internal comments
+// are left out unless includeInternal is set.
+func (r *mongoCommentRepository) GetComments(loanID string, includeInternal bool) ([]models.Comment, error) {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.M{"loan_id": Id}
+	if !includeInternal {
+		filter["visibility"] = models.CommentVisibilityBorrower
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(context.Background(), filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := []models.Comment{}
+	if err := cursor.All(context.Background(), &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (r *mongoCommentRepository) UpdateComment(comment *models.Comment) error {
+	_, err := r.collection.ReplaceOne(context.Background(), bson.M{"_id": comment.ID}, comment)
+	return err
+}
@@ -0,0 +1,50 @@
+package implementations
+
+import (
+	"context"
+
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoDecisionPolicyRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoDecisionPolicyRepository(db *mongo.Database) repository_interface.IDecisionPolicyRepository {
+	return &mongoDecisionPolicyRepository{
+		collection: db.Collection("decision_policies"),
+	}
+}
+
+func (r *mongoDecisionPolicyRepository) CreatePolicy(policy *models.DecisionPolicy) (*models.DecisionPolicy, error) {
+	if policy.ID == primitive.NilObjectID {
+		policy.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(context.Background(), policy)
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// GetLatestPolicy returns nil without an error when no policy has been
+// saved yet.
+func (r *mongoDecisionPolicyRepository) GetLatestPolicy() (*models.DecisionPolicy, error) {
+	findOptions := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})
+
+	var policy models.DecisionPolicy
+	err := r.collection.FindOne(context.Background(), bson.M{}, findOptions).Decode(&policy)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
@@ -0,0 +1,140 @@
+package implementations
+
+import (
+	"context"
+	"time"
+
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoDisbursementRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoDisbursementRepository keeps idempotency keys unique and allows a
+// loan only one active disbursement, so concurrent requests cannot pay a
+// loan out twice.
+func NewMongoDisbursementRepository(db *mongo.Database) repository_interface.IDisbursementRepository {
+	collection := db.Collection("disbursements")
+	collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "idempotency_key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "loan_id", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"active": true}),
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}},
+		},
+	})
+	return &mongoDisbursementRepository{
+		collection: collection,
+	}
+}
+
+// CreateDisbursement returns ErrDisbursementExists when the idempotency key
+// was used before or the loan already has an active disbursement.
+func (r *mongoDisbursementRepository) CreateDisbursement(disbursement *models.Disbursement) (*models.Disbursement, error) {
+	if disbursement.ID == primitive.NilObjectID {
+		disbursement.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(context.Background(), disbursement)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, models.ErrDisbursementExists
+	}
+	if err != nil {
+		return nil, err
+	}
+	return disbursement, nil
+}
+
+func (r *mongoDisbursementRepository) GetDisbursement(disbursementID string) (*models.Disbursement, error) {
+	Id, err := primitive.ObjectIDFromHex(disbursementID)
+	if err != nil {
+		return nil, err
+	}
+	return r.findOne(bson.M{"_id": Id})
+}
+
+func (r *mongoDisbursementRepository) GetDisbursementByKey(idempotencyKey string) (*models.Disbursement, error) {
+	return r.findOne(bson.M{"idempotency_key": idempotencyKey})
+}
+
+// GetActiveDisbursement returns the loan's pending or succeeded
+// disbursement.
+func (r *mongoDisbursementRepository) GetActiveDisbursement(loanID string) (*models.Disbursement, error) {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return nil, err
+	}
+	return r.findOne(bson.M{"loan_id": Id, "active": true})
+}
+
+// GetDisbursementsByLoan returns every disbursement requested for the loan,
+// oldest first.
+func (r *mongoDisbursementRepository) GetDisbursementsByLoan(loanID string) ([]models.Disbursement, error) {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return nil, err
+	}
+	return r.find(bson.M{"loan_id": Id})
+}
+
+// GetDueDisbursements returns the pending disbursements whose next payout
+// attempt is due.
+func (r *mongoDisbursementRepository) GetDueDisbursements(now time.Time) ([]models.Disbursement, error) {
+	return r.find(bson.M{
+		"status":          models.DisbursementStatusPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	})
+}
+
+// GetUnbookedDisbursements returns the succeeded disbursements whose loan
+// has not been booked yet.
+func (r *mongoDisbursementRepository) GetUnbookedDisbursements() ([]models.Disbursement, error) {
+	return r.find(bson.M{
+		"status":    models.DisbursementStatusSucceeded,
+		"booked_at": bson.M{"$exists": false},
+	})
+}
+
+// UpdateDisbursement returns ErrDisbursementExists when it would make a
+// second disbursement of the loan active.
+func (r *mongoDisbursementRepository) UpdateDisbursement(disbursement *models.Disbursement) error {
+	_, err := r.collection.ReplaceOne(context.Background(), bson.M{"_id": disbursement.ID}, disbursement)
+	if mongo.IsDuplicateKeyError(err) {
+		return models.ErrDisbursementExists
+	}
+	return err
+}
+
+func (r *mongoDisbursementRepository) findOne(filter bson.M) (*models.Disbursement, error) {
+	var disbursement models.Disbursement
+	err := r.collection.FindOne(context.Background(), filter).Decode(&disbursement)
+	if err != nil {
+		return nil, err
+	}
+	return &disbursement, nil
+}
+
+func (r *mongoDisbursementRepository) find(filter bson.M) ([]models.Disbursement, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(context.Background(), filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	disbursements := []models.Disbursement{}
+	if err := cursor.All(context.Background(), &disbursements); err != nil {
+		return nil, err
+	}
+	return disbursements, nil
+}
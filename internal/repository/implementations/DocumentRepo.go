@@ -0,0 +1,75 @@
+package implementations
+
+import (
+	"context"
+
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoDocumentRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoDocumentRepository(db *mongo.Database) repository_interface.IDocumentRepository {
+	collection := db.Collection("loan_documents")
+	collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "loan_id", Value: 1}, {Key: "uploaded_at", Value: 1}},
+	})
+	return &mongoDocumentRepository{
+		collection: collection,
+	}
+}
+
+func (r *mongoDocumentRepository) CreateDocument(document *models.LoanDocument) (*models.LoanDocument, error) {
+	if document.ID == primitive.NilObjectID {
+		document.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(context.Background(), document)
+	if err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+func (r *mongoDocumentRepository) GetDocument(documentID string) (*models.LoanDocument, error) {
+	Id, err := primitive.ObjectIDFromHex(documentID)
+	if err != nil {
+		return nil, err
+	}
+	var document models.LoanDocument
+	err = r.collection.FindOne(context.Background(), bson.M{"_id": Id}).Decode(&document)
+	if err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+// GetDocuments returns every document uploaded for the loan, oldest first.
+func (r *mongoDocumentRepository) GetDocuments(loanID string) ([]models.LoanDocument, error) {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return nil, err
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "uploaded_at", Value: 1}})
+	cursor, err := r.collection.Find(context.Background(), bson.M{"loan_id": Id}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := []models.LoanDocument{}
+	if err := cursor.All(context.Background(), &documents); err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+func (r *mongoDocumentRepository) UpdateDocument(document *models.LoanDocument) error {
+	_, err := r.collection.ReplaceOne(context.Background(), bson.M{"_id": document.ID}, document)
+	return err
+}
@@ -0,0 +1,81 @@
+package implementations
+
+import (
+	"context"
+	"time"
+
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoFxRateRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoFxRateRepository(db *mongo.Database) repository_interface.IFxRateRepository {
+	return &mongoFxRateRepository{
+		collection: db.Collection("fx_rates"),
+	}
+}
+
+func (r *mongoFxRateRepository) CreateRates(rates []models.FXRate) error {
+	docs := make([]interface{}, len(rates))
+	for i := range rates {
+		if rates[i].ID == primitive.NilObjectID {
+			rates[i].ID = primitive.NewObjectID()
+		}
+		docs[i] = rates[i]
+	}
+	_, err := r.collection.InsertMany(context.Background(), docs)
+	return err
+}
+
+func (r *mongoFxRateRepository) RateExists(baseCurrency string, currency string, effectiveDate time.Time) (bool, error) {
+	filter := bson.M{
+		"base_currency":  baseCurrency,
+		"currency":       currency,
+		"effective_date": effectiveDate,
+	}
+	count, err := r.collection.CountDocuments(context.Background(), filter)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetRatesAsOf returns, per currency, the rate with the latest effective date
+// on or before asOf.
+func (r *mongoFxRateRepository) GetRatesAsOf(baseCurrency string, asOf time.Time) (map[string]models.FXRate, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"base_currency":  baseCurrency,
+			"effective_date": bson.M{"$lte": asOf},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "effective_date", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":  "$currency",
+			"rate": bson.M{"$first": "$$ROOT"},
+		}}},
+	}
+	cursor, err := r.collection.Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []struct {
+		Rate models.FXRate `bson:"rate"`
+	}
+	if err := cursor.All(context.Background(), &results); err != nil {
+		return nil, err
+	}
+
+	rates := make(map[string]models.FXRate, len(results))
+	for _, result := range results {
+		rates[result.Rate.Currency] = result.Rate
+	}
+	return rates, nil
+}
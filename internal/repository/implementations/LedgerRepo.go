@@ -0,0 +1,64 @@
+package implementations
+
+import (
+	"context"
+
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoLedgerRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoLedgerRepository keeps keyed transactions unique, so one posted
+// twice concurrently is only written once.
+func NewMongoLedgerRepository(db *mongo.Database) repository_interface.ILedgerRepository {
+	collection := db.Collection("ledger")
+	collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "key", Value: 1}, {Key: "account", Value: 1}},
+		Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"key": bson.M{"$exists": true}}),
+	})
+	return &mongoLedgerRepository{
+		collection: collection,
+	}
+}
+
+// CreateEntries returns ErrDuplicateLedgerEntry when the entries carry a key
+// that was posted before. Entries are written in order, so a transaction
+// stops at its first entry in that case.
+func (r *mongoLedgerRepository) CreateEntries(entries []models.LedgerEntry) error {
+	docs := make([]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		docs = append(docs, entry)
+	}
+	_, err := r.collection.InsertMany(context.Background(), docs)
+	if mongo.IsDuplicateKeyError(err) {
+		return models.ErrDuplicateLedgerEntry
+	}
+	return err
+}
+
+func (r *mongoLedgerRepository) GetEntriesByLoanID(loanID string) ([]models.LedgerEntry, error) {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(context.Background(), bson.M{"loan_id": Id}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []models.LedgerEntry
+	if err := cursor.All(context.Background(), &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -2,7 +2,9 @@ package implementations
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"LoanGuard/internal/domain/models"
 	"LoanGuard/internal/repository/interfaces"
@@ -23,51 +25,84 @@ func NewMongoLoanRepository(db *mongo.Database) repository_interface.ILoanReposi
     }
 }
 
-func (r *mongoLoanRepository) GetAllLoans(status string, order string) ([]models.Loan, error) {
-	var loans []models.Loan
-	filter := bson.M{}
+var loanListSpec = listSpec{
+	sortable: map[string]string{
+		"created_at": "created_at",
+		"amount":     "amount.minor",
+		"status":     "status",
+		"term":       "term",
+	},
+	defaultSort: []models.SortField{{Field: "created_at", Desc: true}},
+}
 
-	if status != "" && status != "all" {
-		filter["status"] = status
+// ListLoans returns one page of loans filtered by status, currency, amount
+// range and creation date.
+func (r *mongoLoanRepository) ListLoans(query models.ListQuery) ([]models.Loan, string, error) {
+	filter := bson.M{}
+	if query.Status != "" && query.Status != "all" {
+		filter["status"] = query.Status
 	}
-
-	findOptions := options.Find()
-	if order == "asc" {
-		findOptions.SetSort(bson.D{{Key: "created_at", Value: 1}})
-	} else {
-		findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}})
+	if query.Currency != "" {
+		filter["amount.currency"] = query.Currency
 	}
-
-	cursor, err := r.collection.Find(context.Background(), filter, findOptions)
-	if err != nil {
-		return nil, err
+	amount := bson.M{}
+	if query.MinAmount != nil {
+		amount["$gte"] = query.MinAmount.Amount
 	}
-
-	if err := cursor.All(context.Background(), &loans); err != nil {
-		return nil, err
+	if query.MaxAmount != nil {
+		amount["$lte"] = query.MaxAmount.Amount
 	}
-
-	return loans, nil
+	if len(amount) > 0 {
+		if query.Currency == "" {
+			return nil, "", fmt.Errorf("%w: an amount filter needs a currency", models.ErrInvalidQuery)
+		}
+		filter["amount.currency"] = query.Currency
+		filter["amount.minor"] = amount
+	}
+	if created := createdRange(query, func(t time.Time) interface{} { return t }); len(created) > 0 {
+		filter["created_at"] = created
+	}
+	return findPage[models.Loan](r.collection, filter, query, loanListSpec)
 }
 
-func (r *mongoLoanRepository) UpdateLoanStatus(loanID string, status string) error {
+// UpdateLoanStatus applies the change only while the loan is still in
+// change.From, so of two concurrent transitions from the same status exactly
+// one succeeds and the other gets ErrStatusConflict.
+func (r *mongoLoanRepository) UpdateLoanStatus(loanID string, change models.StatusChange) error {
 	Id, err := primitive.ObjectIDFromHex(loanID)
 	if err != nil {
 		return err
 	}
-    filter := bson.M{"_id": Id}
-    update := bson.M{"$set": bson.M{"status": status}}
-    _, err = r.collection.UpdateOne(context.Background(), filter, update)
-    return err
+    filter := bson.M{"_id": Id, "status": change.From}
+    update := bson.M{
+        "$set":  bson.M{"status": change.To},
+        "$push": bson.M{"status_history": change},
+    }
+    result, err := r.collection.UpdateOne(context.Background(), filter, update)
+    if err != nil {
+        return err
+    }
+    if result.MatchedCount == 0 {
+        return models.ErrStatusConflict
+    }
+    return nil
 }
 
-func (r *mongoLoanRepository) DeleteLoan(loanID string) error {
+// DeleteLoan deletes the loan provided it is still in one of statuses. It
+// returns ErrStatusConflict otherwise.
+func (r *mongoLoanRepository) DeleteLoan(loanID string, statuses []string) error {
 	Id, err := primitive.ObjectIDFromHex(loanID)
 	if err != nil {
 		return err
 	}
-	_, err = r.collection.DeleteOne(context.Background(), bson.M{"_id": Id})
-    return err
+	result, err := r.collection.DeleteOne(context.Background(), bson.M{"_id": Id, "status": bson.M{"$in": statuses}})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return models.ErrStatusConflict
+	}
+	return nil
 }
 
 func (r *mongoLoanRepository) RequestLoan(loan *models.Loan) (*models.Loan, error) {
@@ -102,4 +137,407 @@ func (r *mongoLoanRepository) ViewLoanStatus(loanID string) (string, error) {
 	}
 	fmt.Println(loan)
 	return loan.Status, nil
-}
\ No newline at end of file
+}
+
+func (r *mongoLoanRepository) GetLoanByID(loanID string) (*models.Loan, error) {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	var loan models.Loan
+	err = r.collection.FindOne(context.Background(), bson.M{"_id": Id}).Decode(&loan)
+	if err != nil {
+		return nil, err
+	}
+	return &loan, nil
+}
+
+// UpdateLoanBalances only writes when the stored balance still equals
+// previousBalance, so two repayments racing on the same loan cannot both
+// apply against the same starting balance. It relies on balances being stored
+// as minor-unit money, which the server checks at startup.
+func (r *mongoLoanRepository) UpdateLoanBalances(loan *models.Loan, previousBalance models.Money) error {
+	var balanceFilter interface{} = previousBalance.Amount
+	if previousBalance.IsZero() {
+		// loans stored before balances were tracked have no field at all
+		balanceFilter = bson.M{"$in": bson.A{0, nil}}
+	}
+	filter := bson.M{"_id": loan.ID, "outstanding_balance.minor": balanceFilter}
+	update := bson.M{"$set": bson.M{
+		"outstanding_principal": loan.OutstandingPrincipal,
+		"outstanding_interest":  loan.OutstandingInterest,
+		"outstanding_fees":      loan.OutstandingFees,
+		"outstanding_balance":   loan.OutstandingBalance,
+	}}
+	result, err := r.collection.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("loan balance changed concurrently, please retry")
+	}
+	return nil
+}
+
+// ChargeLateFee writes the loan's balances with a late fee added, like
+// UpdateLoanBalances, and records key with them in the same update. It
+// returns false without writing when the fee under key was already charged.
+func (r *mongoLoanRepository) ChargeLateFee(loan *models.Loan, previousBalance models.Money, key string) (bool, error) {
+	return r.updateBalancesOnce(loan, previousBalance, "late_fees_charged", key)
+}
+
+// ApplyRepaymentBalances writes the loan's balances after a repayment, like
+// UpdateLoanBalances, and records the repayment with them in the same
+// update. It returns false without writing when the repayment was already
+// applied.
+func (r *mongoLoanRepository) ApplyRepaymentBalances(loan *models.Loan, previousBalance models.Money, repaymentID string) (bool, error) {
+	return r.updateBalancesOnce(loan, previousBalance, "applied_repayments", repaymentID)
+}
+
+// updateBalancesOnce writes the balances unless key is already in the loan's
+// list field, and adds it there in the same update.
+func (r *mongoLoanRepository) updateBalancesOnce(loan *models.Loan, previousBalance models.Money, field string, key string) (bool, error) {
+	filter := bson.M{
+		"_id":                       loan.ID,
+		"outstanding_balance.minor": previousBalance.Amount,
+		field:                       bson.M{"$ne": key},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"outstanding_principal": loan.OutstandingPrincipal,
+			"outstanding_interest":  loan.OutstandingInterest,
+			"outstanding_fees":      loan.OutstandingFees,
+			"outstanding_balance":   loan.OutstandingBalance,
+		},
+		"$push": bson.M{field: key},
+	}
+	result, err := r.collection.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		return false, err
+	}
+	if result.MatchedCount == 1 {
+		return true, nil
+	}
+	count, err := r.collection.CountDocuments(context.Background(), bson.M{"_id": loan.ID, field: key})
+	if err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return false, nil
+	}
+	return false, errors.New("loan balance changed concurrently, please retry")
+}
+
+// GetLoanTotals sums loan amounts and outstanding balances per currency and
+// status. Amounts stay in minor units of their own currency.
+func (r *mongoLoanRepository) GetLoanTotals(status string) ([]models.LoanTotals, error) {
+	match := bson.M{}
+	if status != "" && status != "all" {
+		match["status"] = status
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"currency": "$amount.currency",
+				"status":   "$status",
+			},
+			"count":       bson.M{"$sum": 1},
+			"amount":      bson.M{"$sum": "$amount.minor"},
+			"outstanding": bson.M{"$sum": "$outstanding_balance.minor"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":         0,
+			"currency":    "$_id.currency",
+			"status":      "$_id.status",
+			"count":       1,
+			"amount":      1,
+			"outstanding": 1,
+		}}},
+	}
+	cursor, err := r.collection.Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var totals []models.LoanTotals
+	if err := cursor.All(context.Background(), &totals); err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// GetLoansByUser returns one page of a borrower's loans, newest first, with
+// the number of loans matching the filter across all pages.
+func (r *mongoLoanRepository) GetLoansByUser(filter models.LoanFilter) ([]models.Loan, int64, error) {
+	query := bson.M{"userId": filter.UserID}
+	if filter.IncludeParties {
+		query = bson.M{"$or": bson.A{
+			bson.M{"userId": filter.UserID},
+			bson.M{"parties": bson.M{"$elemMatch": bson.M{
+				"user_id": filter.UserID,
+				"status":  models.PartyStatusAccepted,
+			}}},
+		}}
+	}
+	if filter.Status != "" && filter.Status != "all" {
+		query["status"] = filter.Status
+	}
+	createdAt := bson.M{}
+	if !filter.CreatedFrom.IsZero() {
+		createdAt["$gte"] = filter.CreatedFrom
+	}
+	if !filter.CreatedTo.IsZero() {
+		createdAt["$lt"] = filter.CreatedTo
+	}
+	if len(createdAt) > 0 {
+		query["created_at"] = createdAt
+	}
+
+	total, err := r.collection.CountDocuments(context.Background(), query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(filter.Skip).
+		SetLimit(filter.Limit)
+	cursor, err := r.collection.Find(context.Background(), query, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	loans := []models.Loan{}
+	if err := cursor.All(context.Background(), &loans); err != nil {
+		return nil, 0, err
+	}
+	return loans, total, nil
+}
+
+func (r *mongoLoanRepository) UpdateCreditScore(loanID string, score *models.CreditScore) error {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(context.Background(), bson.M{"_id": Id}, bson.M{"$set": bson.M{"credit_score": score}})
+	return err
+}
+
+func (r *mongoLoanRepository) UpdateAutoDecision(loanID string, decision *models.AutoDecision) error {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(context.Background(), bson.M{"_id": Id}, bson.M{"$set": bson.M{"auto_decision": decision}})
+	return err
+}
+
+// DecideLoan changes the loan's status like UpdateLoanStatus and, in the
+// same write, stores the decision and appends the approval when given, so a
+// decision is never recorded without its status or the other way round.
+func (r *mongoLoanRepository) DecideLoan(loanID string, change models.StatusChange, decision *models.LoanDecision, approval *models.LoanApproval) error {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return err
+	}
+	set := bson.M{"status": change.To}
+	push := bson.M{"status_history": change}
+	if decision != nil {
+		set["decision"] = decision
+	}
+	if approval != nil {
+		push["approvals"] = approval
+	}
+	filter := bson.M{"_id": Id, "status": change.From}
+	result, err := r.collection.UpdateOne(context.Background(), filter, bson.M{"$set": set, "$push": push})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return models.ErrStatusConflict
+	}
+	return nil
+}
+
+// AssignLoan gives the loan to an officer, or takes it off them when
+// assignment is nil, provided it is still in one of statuses. It returns
+// ErrStatusConflict otherwise.
+func (r *mongoLoanRepository) AssignLoan(loanID string, assignment *models.LoanAssignment, statuses []string) error {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return err
+	}
+	update := bson.M{"$set": bson.M{"assignment": assignment}}
+	if assignment == nil {
+		update = bson.M{"$unset": bson.M{"assignment": ""}}
+	}
+	filter := bson.M{"_id": Id, "status": bson.M{"$in": statuses}}
+	result, err := r.collection.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return models.ErrStatusConflict
+	}
+	return nil
+}
+
+// AddLoanParty appends a guarantor or co-borrower nomination to the loan.
+func (r *mongoLoanRepository) AddLoanParty(loanID string, party models.LoanParty) error {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(context.Background(), bson.M{"_id": Id}, bson.M{"$push": bson.M{"parties": party}})
+	return err
+}
+
+// UpdateLoanParty replaces the nomination with the same ID as party.
+func (r *mongoLoanRepository) UpdateLoanParty(loanID string, party models.LoanParty) error {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return err
+	}
+	filter := bson.M{"_id": Id, "parties._id": party.ID}
+	result, err := r.collection.UpdateOne(context.Background(), filter, bson.M{"$set": bson.M{"parties.$": party}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return models.ErrPartyNotFound
+	}
+	return nil
+}
+
+// RemoveLoanParty withdraws a nomination from the loan.
+func (r *mongoLoanRepository) RemoveLoanParty(loanID, partyID string) error {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return err
+	}
+	partyObjectID, err := primitive.ObjectIDFromHex(partyID)
+	if err != nil {
+		return models.ErrPartyNotFound
+	}
+	_, err = r.collection.UpdateOne(context.Background(), bson.M{"_id": Id}, bson.M{"$pull": bson.M{"parties": bson.M{"_id": partyObjectID}}})
+	return err
+}
+
+// GetLoansByStatus returns every loan in one of statuses, oldest first.
+func (r *mongoLoanRepository) GetLoansByStatus(statuses []string) ([]models.Loan, error) {
+	return r.findLoans(bson.M{"status": bson.M{"$in": statuses}})
+}
+
+func (r *mongoLoanRepository) UpdateDelinquency(loanID string, delinquency *models.Delinquency) error {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(context.Background(), bson.M{"_id": Id}, bson.M{"$set": bson.M{"delinquency": delinquency}})
+	return err
+}
+
+// UpdateLoanTerms records the rate and term a loan was restructured to.
+func (r *mongoLoanRepository) UpdateLoanTerms(loanID string, annualRate float64, term int) error {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(context.Background(), bson.M{"_id": Id}, bson.M{"$set": bson.M{"interest": annualRate, "term": term}})
+	return err
+}
+
+func (r *mongoLoanRepository) UpdateAccrual(loanID string, accrual *models.InterestAccrual) error {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(context.Background(), bson.M{"_id": Id}, bson.M{"$set": bson.M{"accrual": accrual}})
+	return err
+}
+
+// GetDelinquentLoans returns the loans in one of statuses that were past due
+// at the last check, most overdue first, optionally only those in bucket.
+// Loans that have left those statuses keep their last delinquency, so the
+// status filter is what takes them off the list.
+func (r *mongoLoanRepository) GetDelinquentLoans(bucket string, statuses []string) ([]models.Loan, error) {
+	filter := bson.M{
+		"delinquency.days_past_due": bson.M{"$gt": 0},
+		"status":                    bson.M{"$in": statuses},
+	}
+	if bucket != "" {
+		filter["delinquency.bucket"] = bucket
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "delinquency.days_past_due", Value: -1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(context.Background(), filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	loans := []models.Loan{}
+	if err := cursor.All(context.Background(), &loans); err != nil {
+		return nil, err
+	}
+	return loans, nil
+}
+
+// GetAssignedLoans returns the officer's loans in one of statuses, oldest
+// first.
+func (r *mongoLoanRepository) GetAssignedLoans(officerID string, statuses []string) ([]models.Loan, error) {
+	filter := bson.M{
+		"assignment.officer_id": officerID,
+		"status":                bson.M{"$in": statuses},
+	}
+	return r.findLoans(filter)
+}
+
+// GetUnassignedLoans returns the loans in one of statuses nobody works on,
+// oldest first.
+func (r *mongoLoanRepository) GetUnassignedLoans(statuses []string) ([]models.Loan, error) {
+	filter := bson.M{
+		"assignment": nil,
+		"status":     bson.M{"$in": statuses},
+	}
+	return r.findLoans(filter)
+}
+
+func (r *mongoLoanRepository) findLoans(filter bson.M) ([]models.Loan, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(context.Background(), filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	loans := []models.Loan{}
+	if err := cursor.All(context.Background(), &loans); err != nil {
+		return nil, err
+	}
+	return loans, nil
+}
+
+// GetOfficerWorkloads counts, per officer who was ever assigned a loan, the
+// loans they hold in one of statuses and when they were last assigned one.
+func (r *mongoLoanRepository) GetOfficerWorkloads(statuses []string) ([]models.OfficerWorkload, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"assignment": bson.M{"$ne": nil}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": "$assignment.officer_id",
+			"open_loans": bson.M{"$sum": bson.M{
+				"$cond": bson.A{bson.M{"$in": bson.A{"$status", statuses}}, 1, 0},
+			}},
+			"last_assigned_at": bson.M{"$max": "$assignment.assigned_at"},
+		}}},
+	}
+	cursor, err := r.collection.Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var workloads []models.OfficerWorkload
+	if err := cursor.All(context.Background(), &workloads); err != nil {
+		return nil, err
+	}
+	return workloads, nil
+}
@@ -2,6 +2,8 @@ package implementations
 
 import (
     "context"
+    "regexp"
+    "time"
 
     "LoanGuard/internal/domain/models"
 	"LoanGuard/internal/repository/interfaces"
@@ -24,14 +26,24 @@ func (r *mongoLogRepository) CreateLog(log *models.SystemLog) error {
     return err
 }
 
-func (r *mongoLogRepository) GetAllLogs() ([]models.SystemLog, error) {
-    var logs []models.SystemLog
-    cursor, err := r.collection.Find(context.Background(), bson.M{})
-    if err != nil {
-        return nil, err
+var logListSpec = listSpec{
+    sortable: map[string]string{
+        "created_at": "timestamp",
+        "timestamp":  "timestamp",
+        "action":     "action",
+    },
+    defaultSort: []models.SortField{{Field: "timestamp", Desc: true}},
+}
+
+// ListLogs returns one page of logs, filtered by timestamp and by a case
+// insensitive search on the action text.
+func (r *mongoLogRepository) ListLogs(query models.ListQuery) ([]models.SystemLog, string, error) {
+    filter := bson.M{}
+    if created := createdRange(query, func(t time.Time) interface{} { return t }); len(created) > 0 {
+        filter["timestamp"] = created
     }
-    if err := cursor.All(context.Background(), &logs); err != nil {
-        return nil, err
+    if query.Search != "" {
+        filter["action"] = bson.M{"$regex": regexp.QuoteMeta(query.Search), "$options": "i"}
     }
-    return logs, nil
+    return findPage[models.SystemLog](r.collection, filter, query, logListSpec)
 }
\ No newline at end of file
@@ -0,0 +1,109 @@
+package implementations
+
+import (
+	"context"
+
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoMigrationRepository struct {
+	db *mongo.Database
+}
+
+func NewMongoMigrationRepository(db *mongo.Database) repository_interface.IMigrationRepository {
+	return &mongoMigrationRepository{db: db}
+}
+
+// legacyMoneyFields are the money fields of each collection that older
+// versions stored as bare numbers. Only loans predate minor-unit money.
+var legacyMoneyFields = map[string][]string{
+	"loans": {"amount", "total"},
+}
+
+// MigrateLegacyMoney rewrites loans that still hold amounts as bare numbers
+// into the {minor, currency} form. Money's BSON decoder already reads the old
+// numbers, so each loan is decoded through its model and written back
+// unchanged otherwise. Loans missing a currency code get the one of their
+// amount. Running it again is a no-op.
+func (r *mongoMigrationRepository) MigrateLegacyMoney() (map[string]int64, error) {
+	counts := map[string]int64{}
+	var err error
+
+	if counts["loans"], err = migrateCollection[models.Loan](r.db.Collection("loans"), legacyMoneyFields["loans"]...); err != nil {
+		return counts, err
+	}
+	// loans carry their own currency code since amounts became currency
+	// aware; older ones take it from their amount
+	if counts["loans (currency)"], err = backfillCurrency(r.db.Collection("loans"), "amount"); err != nil {
+		return counts, err
+	}
+	return counts, nil
+}
+
+// CountLegacyMoney counts, per collection, the documents that still hold
+// amounts as bare numbers. Collections with none are left out.
+func (r *mongoMigrationRepository) CountLegacyMoney() (map[string]int64, error) {
+	counts := map[string]int64{}
+	for collection, fields := range legacyMoneyFields {
+		count, err := r.db.Collection(collection).CountDocuments(context.Background(), legacyMoneyFilter(fields))
+		if err != nil {
+			return counts, err
+		}
+		if count > 0 {
+			counts[collection] = count
+		}
+	}
+	return counts, nil
+}
+
+func legacyMoneyFilter(fields []string) bson.M {
+	legacy := bson.A{}
+	for _, field := range fields {
+		legacy = append(legacy, bson.M{field: bson.M{"$type": "number"}})
+	}
+	return bson.M{"$or": legacy}
+}
+
+func backfillCurrency(collection *mongo.Collection, amountField string) (int64, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"currency": bson.M{"$exists": false}},
+		bson.M{"currency": ""},
+	}}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"currency": "$" + amountField + ".currency"}}},
+	}
+	result, err := collection.UpdateMany(context.Background(), filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func migrateCollection[T any](collection *mongo.Collection, fields ...string) (int64, error) {
+	ctx := context.Background()
+
+	cursor, err := collection.Find(ctx, legacyMoneyFilter(fields))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var migrated int64
+	for cursor.Next(ctx) {
+		id := cursor.Current.Lookup("_id")
+
+		var doc T
+		if err := cursor.Decode(&doc); err != nil {
+			return migrated, err
+		}
+		if _, err := collection.ReplaceOne(ctx, bson.M{"_id": id}, doc); err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+	return migrated, cursor.Err()
+}
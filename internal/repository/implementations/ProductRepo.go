@@ -0,0 +1,83 @@
+package implementations
+
+import (
+	"context"
+
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoProductRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoProductRepository(db *mongo.Database) repository_interface.IProductRepository {
+	return &mongoProductRepository{
+		collection: db.Collection("products"),
+	}
+}
+
+func (r *mongoProductRepository) CreateProduct(product *models.LoanProduct) (*models.LoanProduct, error) {
+	if product.ID == primitive.NilObjectID {
+		product.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(context.Background(), product)
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+func (r *mongoProductRepository) GetProductByID(productID string) (*models.LoanProduct, error) {
+	Id, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	var product models.LoanProduct
+	err = r.collection.FindOne(context.Background(), bson.M{"_id": Id}).Decode(&product)
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *mongoProductRepository) GetProducts(activeOnly bool) ([]models.LoanProduct, error) {
+	filter := bson.M{}
+	if activeOnly {
+		filter["active"] = true
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.collection.Find(context.Background(), filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var products []models.LoanProduct
+	if err := cursor.All(context.Background(), &products); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (r *mongoProductRepository) UpdateProduct(productID string, product *models.LoanProduct) error {
+	Id, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return err
+	}
+	product.ID = Id
+	result, err := r.collection.ReplaceOne(context.Background(), bson.M{"_id": Id}, product)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package implementations
+
+import (
+	"context"
+
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoReasonCodeRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoReasonCodeRepository(db *mongo.Database) repository_interface.IReasonCodeRepository {
+	collection := db.Collection("reason_codes")
+	collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "code", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return &mongoReasonCodeRepository{
+		collection: collection,
+	}
+}
+
+func (r *mongoReasonCodeRepository) CreateReasonCode(reasonCode *models.ReasonCode) (*models.ReasonCode, error) {
+	if reasonCode.ID == primitive.NilObjectID {
+		reasonCode.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(context.Background(), reasonCode)
+	if err != nil {
+		return nil, err
+	}
+	return reasonCode, nil
+}
+
+func (r *mongoReasonCodeRepository) GetReasonCode(code string) (*models.ReasonCode, error) {
+	var reasonCode models.ReasonCode
+	err := r.collection.FindOne(context.Background(), bson.M{"code": code}).Decode(&reasonCode)
+	if err != nil {
+		return nil, err
+	}
+	return &reasonCode, nil
+}
+
+func (r *mongoReasonCodeRepository) GetReasonCodes(activeOnly bool) ([]models.ReasonCode, error) {
+	filter := bson.M{}
+	if activeOnly {
+		filter["active"] = true
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "code", Value: 1}})
+	cursor, err := r.collection.Find(context.Background(), filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	reasonCodes := []models.ReasonCode{}
+	if err := cursor.All(context.Background(), &reasonCodes); err != nil {
+		return nil, err
+	}
+	return reasonCodes, nil
+}
+
+func (r *mongoReasonCodeRepository) UpdateReasonCode(code string, reasonCode *models.ReasonCode) error {
+	result, err := r.collection.ReplaceOne(context.Background(), bson.M{"code": code}, reasonCode)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
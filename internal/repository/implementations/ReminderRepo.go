@@ -0,0 +1,77 @@
+package implementations
+
+import (
+	"context"
+
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoReminderRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoReminderRepository(db *mongo.Database) repository_interface.IReminderRepository {
+	collection := db.Collection("reminders")
+	collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "loan_id", Value: 1}, {Key: "sent_at", Value: 1}},
+		},
+	})
+	return &mongoReminderRepository{
+		collection: collection,
+	}
+}
+
+// CreateReminder returns ErrReminderAlreadySent when a reminder with the
+// same key exists.
+func (r *mongoReminderRepository) CreateReminder(reminder *models.Reminder) (*models.Reminder, error) {
+	if reminder.ID == primitive.NilObjectID {
+		reminder.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(context.Background(), reminder)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, models.ErrReminderAlreadySent
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reminder, nil
+}
+
+func (r *mongoReminderRepository) DeleteReminder(reminderID string) error {
+	Id, err := primitive.ObjectIDFromHex(reminderID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.DeleteOne(context.Background(), bson.M{"_id": Id})
+	return err
+}
+
+// GetReminders returns every reminder sent about the loan, oldest first.
+func (r *mongoReminderRepository) GetReminders(loanID string) ([]models.Reminder, error) {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return nil, err
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "sent_at", Value: 1}})
+	cursor, err := r.collection.Find(context.Background(), bson.M{"loan_id": Id}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	reminders := []models.Reminder{}
+	if err := cursor.All(context.Background(), &reminders); err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
@@ -0,0 +1,119 @@
+package implementations
+
+import (
+	"context"
+	"time"
+
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoRepaymentRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoRepaymentRepository keeps references unique, so a payment reported
+// twice at once is only recorded once.
+func NewMongoRepaymentRepository(db *mongo.Database) repository_interface.IRepaymentRepository {
+	collection := db.Collection("repayments")
+	collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "reference", Value: 1}},
+		Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"reference": bson.M{"$exists": true}}),
+	})
+	return &mongoRepaymentRepository{
+		collection: collection,
+	}
+}
+
+// CreateRepayment returns ErrDuplicateRepayment when a repayment with the
+// same reference was already recorded.
+func (r *mongoRepaymentRepository) CreateRepayment(repayment *models.Repayment) (*models.Repayment, error) {
+	if repayment.ID == primitive.NilObjectID {
+		repayment.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(context.Background(), repayment)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, models.ErrDuplicateRepayment
+	}
+	if err != nil {
+		return nil, err
+	}
+	return repayment, nil
+}
+
+func (r *mongoRepaymentRepository) GetRepaymentsByLoanID(loanID string) ([]models.Repayment, error) {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "received_at", Value: 1}})
+	cursor, err := r.collection.Find(context.Background(), bson.M{"loan_id": Id}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var repayments []models.Repayment
+	if err := cursor.All(context.Background(), &repayments); err != nil {
+		return nil, err
+	}
+	return repayments, nil
+}
+
+func (r *mongoRepaymentRepository) GetRepaymentByReference(reference string) (*models.Repayment, error) {
+	var repayment models.Repayment
+	err := r.collection.FindOne(context.Background(), bson.M{"reference": reference}).Decode(&repayment)
+	if err != nil {
+		return nil, err
+	}
+	return &repayment, nil
+}
+
+// GetPendingRepayments returns the loan's repayments that were recorded but
+// not applied in full, oldest first.
+func (r *mongoRepaymentRepository) GetPendingRepayments(loanID string) ([]models.Repayment, error) {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{
+		"loan_id":     Id,
+		"application": bson.M{"$exists": true},
+		"applied_at":  bson.M{"$exists": false},
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(context.Background(), filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	repayments := []models.Repayment{}
+	if err := cursor.All(context.Background(), &repayments); err != nil {
+		return nil, err
+	}
+	return repayments, nil
+}
+
+func (r *mongoRepaymentRepository) MarkRepaymentApplied(repaymentID string, at time.Time) error {
+	Id, err := primitive.ObjectIDFromHex(repaymentID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(context.Background(), bson.M{"_id": Id}, bson.M{"$set": bson.M{"applied_at": at}})
+	return err
+}
+
+func (r *mongoRepaymentRepository) DeleteRepayment(repaymentID string) error {
+	Id, err := primitive.ObjectIDFromHex(repaymentID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.DeleteOne(context.Background(), bson.M{"_id": Id})
+	return err
+}
@@ -0,0 +1,101 @@
+package implementations
+
+import (
+	"context"
+	"time"
+
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoRestructureRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoRestructureRepository(db *mongo.Database) repository_interface.IRestructureRepository {
+	collection := db.Collection("restructures")
+	collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "loan_id", Value: 1}, {Key: "created_at", Value: 1}},
+	})
+	return &mongoRestructureRepository{
+		collection: collection,
+	}
+}
+
+func (r *mongoRestructureRepository) CreateRestructure(restructure *models.Restructure) (*models.Restructure, error) {
+	if restructure.ID == primitive.NilObjectID {
+		restructure.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(context.Background(), restructure)
+	if err != nil {
+		return nil, err
+	}
+	return restructure, nil
+}
+
+func (r *mongoRestructureRepository) GetRestructure(restructureID string) (*models.Restructure, error) {
+	Id, err := primitive.ObjectIDFromHex(restructureID)
+	if err != nil {
+		return nil, models.ErrRestructureNotFound
+	}
+
+	var restructure models.Restructure
+	err = r.collection.FindOne(context.Background(), bson.M{"_id": Id}).Decode(&restructure)
+	if err == mongo.ErrNoDocuments {
+		return nil, models.ErrRestructureNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &restructure, nil
+}
+
+// GetRestructuresByLoan returns every restructure offered on the loan, oldest
+// first.
+func (r *mongoRestructureRepository) GetRestructuresByLoan(loanID string) ([]models.Restructure, error) {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return nil, err
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(context.Background(), bson.M{"loan_id": Id}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	restructures := []models.Restructure{}
+	if err := cursor.All(context.Background(), &restructures); err != nil {
+		return nil, err
+	}
+	return restructures, nil
+}
+
+// UpdateRestructureStatus moves the restructure from one status to another.
+// It returns ErrStatusConflict when the restructure is no longer in from, so
+// an offer answered twice at once is only applied once.
+func (r *mongoRestructureRepository) UpdateRestructureStatus(restructureID string, from string, to string, at time.Time) error {
+	Id, err := primitive.ObjectIDFromHex(restructureID)
+	if err != nil {
+		return models.ErrRestructureNotFound
+	}
+	filter := bson.M{"_id": Id, "status": from}
+	update := bson.M{"$set": bson.M{"status": to, "responded_at": at}}
+	result, err := r.collection.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return models.ErrStatusConflict
+	}
+	return nil
+}
+
+func (r *mongoRestructureRepository) UpdateRestructure(restructure *models.Restructure) error {
+	_, err := r.collection.ReplaceOne(context.Background(), bson.M{"_id": restructure.ID}, restructure)
+	return err
+}
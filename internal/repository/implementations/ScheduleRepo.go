@@ -0,0 +1,96 @@
+package implementations
+
+import (
+	"context"
+	"fmt"
+
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoScheduleRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoScheduleRepository keeps schedule versions unique per loan, so two
+// writers replacing the same version cannot both succeed.
+func NewMongoScheduleRepository(db *mongo.Database) repository_interface.IScheduleRepository {
+	collection := db.Collection("schedules")
+	collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "loan_id", Value: 1}, {Key: "version", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return &mongoScheduleRepository{
+		collection: collection,
+	}
+}
+
+// CreateSchedule returns ErrStatusConflict when the loan already has a
+// schedule of the same version.
+func (r *mongoScheduleRepository) CreateSchedule(schedule *models.RepaymentSchedule) (*models.RepaymentSchedule, error) {
+	if schedule.ID == primitive.NilObjectID {
+		schedule.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(context.Background(), schedule)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, fmt.Errorf("%w: schedule version %d already exists", models.ErrStatusConflict, schedule.Version)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (r *mongoScheduleRepository) GetScheduleByLoanID(loanID string) (*models.RepaymentSchedule, error) {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	findOptions := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})
+
+	var schedule models.RepaymentSchedule
+	err = r.collection.FindOne(context.Background(), bson.M{"loan_id": Id}, findOptions).Decode(&schedule)
+	if err == mongo.ErrNoDocuments {
+		return nil, models.ErrScheduleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// GetSchedulesByLoanID returns every version of the loan's schedule, oldest
+// first.
+func (r *mongoScheduleRepository) GetSchedulesByLoanID(loanID string) ([]models.RepaymentSchedule, error) {
+	Id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "version", Value: 1}})
+	cursor, err := r.collection.Find(context.Background(), bson.M{"loan_id": Id}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := []models.RepaymentSchedule{}
+	if err := cursor.All(context.Background(), &schedules); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (r *mongoScheduleRepository) UpdateInstallments(scheduleID string, installments []models.Installment) error {
+	Id, err := primitive.ObjectIDFromHex(scheduleID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(context.Background(), bson.M{"_id": Id}, bson.M{"$set": bson.M{"installments": installments}})
+	return err
+}
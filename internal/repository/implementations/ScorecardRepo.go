@@ -0,0 +1,50 @@
+package implementations
+
+import (
+	"context"
+
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoScorecardRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoScorecardRepository(db *mongo.Database) repository_interface.IScorecardRepository {
+	return &mongoScorecardRepository{
+		collection: db.Collection("scorecards"),
+	}
+}
+
+func (r *mongoScorecardRepository) CreateScorecard(scorecard *models.Scorecard) (*models.Scorecard, error) {
+	if scorecard.ID == primitive.NilObjectID {
+		scorecard.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(context.Background(), scorecard)
+	if err != nil {
+		return nil, err
+	}
+	return scorecard, nil
+}
+
+// GetLatestScorecard returns nil without an error when no scorecard has been
+// saved yet.
+func (r *mongoScorecardRepository) GetLatestScorecard() (*models.Scorecard, error) {
+	findOptions := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})
+
+	var scorecard models.Scorecard
+	err := r.collection.FindOne(context.Background(), bson.M{}, findOptions).Decode(&scorecard)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &scorecard, nil
+}
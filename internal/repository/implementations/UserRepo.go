@@ -6,12 +6,14 @@ import (
 	"LoanGuard/internal/infrastructures/services"
 	"LoanGuard/internal/repository/interfaces"
 	"errors"
+	"regexp"
 	"time"
 	"context"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type MongoUserRepository struct {
@@ -58,19 +60,29 @@ func (r *MongoUserRepository) GetUserByID(id string) (*models.User, error) {
 	return &user, nil
 }
 
-func (repo *MongoUserRepository) GetAllUsers() ([]*models.User, error) {
-	var users []*models.User
-	cursor, err := repo.collection.Find(context.Background(), bson.M{})
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(context.Background())
+var userListSpec = listSpec{
+	sortable: map[string]string{
+		"name":       "name",
+		"email":      "email",
+		"role":       "role",
+		"created_at": "_id",
+	},
+	defaultSort: []models.SortField{{Field: "created_at", Desc: true}},
+}
 
-	err = cursor.All(context.Background(), &users)
-	if err != nil {
-		return nil, err
+// ListUsers returns one page of users. Users carry no creation timestamp, so
+// created_at sorting and filtering use the time embedded in their ObjectID.
+// Search matches name or email case insensitively.
+func (repo *MongoUserRepository) ListUsers(query models.ListQuery) ([]*models.User, string, error) {
+	filter := bson.M{}
+	if created := createdRange(query, func(t time.Time) interface{} { return primitive.NewObjectIDFromTimestamp(t) }); len(created) > 0 {
+		filter["_id"] = created
 	}
-	return users, nil
+	if query.Search != "" {
+		pattern := bson.M{"$regex": regexp.QuoteMeta(query.Search), "$options": "i"}
+		filter["$or"] = bson.A{bson.M{"name": pattern}, bson.M{"email": pattern}}
+	}
+	return findPage[*models.User](repo.collection, filter, query, userListSpec)
 }
 
 func (r *MongoUserRepository) GetUserByEmail(email string) (*models.User, error) {
@@ -118,6 +130,76 @@ func (r *MongoUserRepository) DemoteUser(userID string) error {
 	return err
 }
 
+func (r *MongoUserRepository) UpdateRole(userID string, role string) error {
+	user_id, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+	result, err := r.collection.UpdateOne(context.Background(), bson.M{"_id": user_id}, bson.M{"$set": bson.M{"role": role}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// GetUsersByRole matches the role case insensitively because promoted users
+// were stored with lower case roles.
+func (r *MongoUserRepository) GetUsersByRole(role string) ([]*models.User, error) {
+	filter := bson.M{"role": bson.M{"$regex": "^" + regexp.QuoteMeta(role) + "$", "$options": "i"}}
+	cursor, err := r.collection.Find(context.Background(), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	users := []*models.User{}
+	if err := cursor.All(context.Background(), &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *MongoUserRepository) UpdateKYC(userID string, dateOfBirth *time.Time, kyc *models.KYCProfile) error {
+	user_id, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+	update := bson.M{"$set": bson.M{"date_of_birth": dateOfBirth, "kyc": kyc}, "$unset": bson.M{"age": ""}}
+	result, err := r.collection.UpdateOne(context.Background(), bson.M{"_id": user_id}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// GetUsersByKYCStatus returns the users in a KYC status, those who submitted
+// their details first at the top.
+func (r *MongoUserRepository) GetUsersByKYCStatus(status string) ([]*models.User, error) {
+	filter := bson.M{"kyc.status": status}
+	if status == models.KYCStatusUnverified {
+		filter = bson.M{"$or": bson.A{
+			bson.M{"kyc.status": status},
+			bson.M{"kyc.status": bson.M{"$exists": false}},
+		}}
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "kyc.submitted_at", Value: 1}})
+	cursor, err := r.collection.Find(context.Background(), filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	users := []*models.User{}
+	if err := cursor.All(context.Background(), &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 func (r *MongoUserRepository) UpdatePassword(userID string, hashedPassword string) error {
 	objID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
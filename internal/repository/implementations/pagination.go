@@ -0,0 +1,229 @@
+package implementations
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"LoanGuard/internal/domain/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// listSpec describes how a collection may be listed: the sort fields a client
+// may name, mapped to the document fields they sort on, and the order used
+// when the client names none.
+type listSpec struct {
+	sortable    map[string]string
+	defaultSort []models.SortField
+}
+
+// pageCursor is what the opaque cursor token carries: the sort it was issued
+// for and the sort key values of the last document on the page.
+type pageCursor struct {
+	Sort   string `bson:"s"`
+	Values bson.A `bson:"v"`
+}
+
+// findPage runs a keyset-paginated find. The sort always ends on _id so every
+// document has a unique position, and the next page starts strictly after the
+// last document returned, so inserts and deletes between requests never
+// cause documents to be skipped or repeated. It returns an empty next cursor
+// on the last page.
+func findPage[T any](collection *mongo.Collection, filter bson.M, query models.ListQuery, spec listSpec) ([]T, string, error) {
+	ctx := context.Background()
+
+	keys, err := spec.sortKeys(query.Sort)
+	if err != nil {
+		return nil, "", err
+	}
+	signature := sortSignature(keys)
+
+	conditions := bson.A{}
+	if len(filter) > 0 {
+		conditions = append(conditions, filter)
+	}
+	if query.Cursor != "" {
+		after, err := decodeCursor(query.Cursor, signature, len(keys))
+		if err != nil {
+			return nil, "", err
+		}
+		conditions = append(conditions, keysetFilter(keys, after))
+	}
+	find := bson.M{}
+	if len(conditions) > 0 {
+		find = bson.M{"$and": conditions}
+	}
+
+	sort := bson.D{}
+	for _, key := range keys {
+		direction := 1
+		if key.Desc {
+			direction = -1
+		}
+		sort = append(sort, bson.E{Key: key.Field, Value: direction})
+	}
+
+	limit := query.PageLimit()
+	findOptions := options.Find().SetSort(sort).SetLimit(int64(limit) + 1)
+	cursor, err := collection.Find(ctx, find, findOptions)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var raws []bson.Raw
+	if err := cursor.All(ctx, &raws); err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(raws) > limit {
+		raws = raws[:limit]
+		nextCursor, err = encodeCursor(raws[len(raws)-1], keys, signature)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	items := make([]T, 0, len(raws))
+	for _, raw := range raws {
+		var item T
+		if err := bson.Unmarshal(raw, &item); err != nil {
+			return nil, "", err
+		}
+		items = append(items, item)
+	}
+	return items, nextCursor, nil
+}
+
+// sortKeys resolves the requested sort to document fields and appends the
+// _id tie breaker, so the last key of every cursor is never null.
+func (spec listSpec) sortKeys(requested []models.SortField) ([]models.SortField, error) {
+	if len(requested) == 0 {
+		requested = spec.defaultSort
+	}
+	keys := make([]models.SortField, 0, len(requested)+1)
+	seen := map[string]bool{}
+	for _, field := range requested {
+		column, ok := spec.sortable[field.Field]
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot sort by %q", models.ErrInvalidQuery, field.Field)
+		}
+		if seen[column] {
+			continue
+		}
+		seen[column] = true
+		keys = append(keys, models.SortField{Field: column, Desc: field.Desc})
+	}
+	if !seen["_id"] {
+		desc := len(keys) > 0 && keys[len(keys)-1].Desc
+		keys = append(keys, models.SortField{Field: "_id", Desc: desc})
+	}
+	return keys, nil
+}
+
+// keysetFilter matches the documents sorting after values, i.e.
+// (k1 > v1) or (k1 = v1 and k2 > v2) or ..., with > flipped to < for
+// descending keys. A missing or null sort key is stored in the cursor as nil
+// and, as in MongoDB's sort order, ranks below every other value.
+func keysetFilter(keys []models.SortField, values bson.A) bson.M {
+	branches := bson.A{}
+	for i, key := range keys {
+		after, ok := sortsAfter(key, values[i])
+		if !ok {
+			continue
+		}
+		conditions := bson.A{}
+		for j := 0; j < i; j++ {
+			// {field: nil} also matches documents without the field
+			conditions = append(conditions, bson.M{keys[j].Field: values[j]})
+		}
+		conditions = append(conditions, after)
+		branches = append(branches, bson.M{"$and": conditions})
+	}
+	if len(branches) == 0 {
+		// nothing sorts after the last document
+		return bson.M{"_id": bson.M{"$exists": false}}
+	}
+	return bson.M{"$or": branches}
+}
+
+// sortsAfter matches the documents whose key sorts after value. Comparison
+// operators never match null or a missing field, so nulls are handled
+// explicitly. It is false when nothing can sort after value, which is a null
+// in a descending sort.
+func sortsAfter(key models.SortField, value interface{}) (bson.M, bool) {
+	switch {
+	case value == nil && key.Desc:
+		return nil, false
+	case value == nil:
+		return bson.M{key.Field: bson.M{"$ne": nil}}, true
+	case key.Desc:
+		return bson.M{"$or": bson.A{
+			bson.M{key.Field: bson.M{"$lt": value}},
+			bson.M{key.Field: nil},
+		}}, true
+	}
+	return bson.M{key.Field: bson.M{"$gt": value}}, true
+}
+
+func sortSignature(keys []models.SortField) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key.Field
+		if key.Desc {
+			parts[i] = "-" + key.Field
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func encodeCursor(last bson.Raw, keys []models.SortField, signature string) (string, error) {
+	values := make(bson.A, len(keys))
+	for i, key := range keys {
+		value, err := last.LookupErr(strings.Split(key.Field, ".")...)
+		if err != nil || value.Type == bsontype.Null {
+			values[i] = nil
+			continue
+		}
+		values[i] = value
+	}
+	data, err := bson.Marshal(pageCursor{Sort: signature, Values: values})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(token string, signature string, size int) (bson.A, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", models.ErrInvalidQuery)
+	}
+	var cursor pageCursor
+	if err := bson.Unmarshal(data, &cursor); err != nil || len(cursor.Values) != size {
+		return nil, fmt.Errorf("%w: malformed cursor", models.ErrInvalidQuery)
+	}
+	if cursor.Sort != signature {
+		return nil, fmt.Errorf("%w: cursor was issued for a different sort", models.ErrInvalidQuery)
+	}
+	return cursor.Values, nil
+}
+
+// createdRange builds the {$gte, $lt} condition for the query's creation
+// date bounds, converting each bound with value for the field it applies to.
+func createdRange(query models.ListQuery, value func(time.Time) interface{}) bson.M {
+	condition := bson.M{}
+	if !query.CreatedFrom.IsZero() {
+		condition["$gte"] = value(query.CreatedFrom)
+	}
+	if !query.CreatedTo.IsZero() {
+		condition["$lt"] = value(query.CreatedTo)
+	}
+	return condition
+}
@@ -0,0 +1,15 @@
+package repository_interface
+
+import (
+	"LoanGuard/internal/domain/models"
+	"time"
+)
+
+type ICollateralRepository interface {
+	CreateCollateral(collateral *models.Collateral) (*models.Collateral, error)
+	GetCollateral(collateralID string) (*models.Collateral, error)
+	GetCollateralByLoan(loanID string) ([]models.Collateral, error)
+	UpdateCollateral(collateral *models.Collateral) error
+	DeleteCollateral(collateralID string) error
+	UpdateLienStatus(loanID string, from string, to string, at time.Time) error
+}
@@ -0,0 +1,12 @@
+package repository_interface
+
+import (
+	"LoanGuard/internal/domain/models"
+)
+
+type ICommentRepository interface {
+	CreateComment(comment *models.Comment) (*models.Comment, error)
+	GetComment(commentID string) (*models.Comment, error)
+	GetComments(loanID string, includeInternal bool) ([]models.Comment, error)
+	UpdateComment(comment *models.Comment) error
+}
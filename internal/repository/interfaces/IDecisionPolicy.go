@@ -0,0 +1,10 @@
+package repository_interface
+
+import (
+	"LoanGuard/internal/domain/models"
+)
+
+type IDecisionPolicyRepository interface {
+	CreatePolicy(policy *models.DecisionPolicy) (*models.DecisionPolicy, error)
+	GetLatestPolicy() (*models.DecisionPolicy, error)
+}
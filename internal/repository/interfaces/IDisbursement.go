@@ -0,0 +1,17 @@
+package repository_interface
+
+import (
+	"LoanGuard/internal/domain/models"
+	"time"
+)
+
+type IDisbursementRepository interface {
+	CreateDisbursement(disbursement *models.Disbursement) (*models.Disbursement, error)
+	GetDisbursement(disbursementID string) (*models.Disbursement, error)
+	GetDisbursementByKey(idempotencyKey string) (*models.Disbursement, error)
+	GetDisbursementsByLoan(loanID string) ([]models.Disbursement, error)
+	GetActiveDisbursement(loanID string) (*models.Disbursement, error)
+	GetDueDisbursements(now time.Time) ([]models.Disbursement, error)
+	GetUnbookedDisbursements() ([]models.Disbursement, error)
+	UpdateDisbursement(disbursement *models.Disbursement) error
+}
@@ -0,0 +1,12 @@
+package repository_interface
+
+import (
+	"LoanGuard/internal/domain/models"
+)
+
+type IDocumentRepository interface {
+	CreateDocument(document *models.LoanDocument) (*models.LoanDocument, error)
+	GetDocument(documentID string) (*models.LoanDocument, error)
+	GetDocuments(loanID string) ([]models.LoanDocument, error)
+	UpdateDocument(document *models.LoanDocument) error
+}
@@ -0,0 +1,12 @@
+package repository_interface
+
+import (
+	"LoanGuard/internal/domain/models"
+	"time"
+)
+
+type IFxRateRepository interface {
+	CreateRates(rates []models.FXRate) error
+	RateExists(baseCurrency string, currency string, effectiveDate time.Time) (bool, error)
+	GetRatesAsOf(baseCurrency string, asOf time.Time) (map[string]models.FXRate, error)
+}
@@ -0,0 +1,10 @@
+package repository_interface
+
+import (
+	"LoanGuard/internal/domain/models"
+)
+
+type ILedgerRepository interface {
+	CreateEntries(entries []models.LedgerEntry) error
+	GetEntriesByLoanID(loanID string) ([]models.LedgerEntry, error)
+}
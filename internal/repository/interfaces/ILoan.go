@@ -5,9 +5,30 @@ import (
 )
 
 type ILoanRepository interface {
-	GetAllLoans(status string, order string) ([]models.Loan, error)
-	UpdateLoanStatus(loanID string, status string) error
-	DeleteLoan(loanID string) error
+	ListLoans(query models.ListQuery) ([]models.Loan, string, error)
+	UpdateLoanStatus(loanID string, change models.StatusChange) error
+	DeleteLoan(loanID string, statuses []string) error
 	RequestLoan(loan *models.Loan) (*models.Loan, error)
 	ViewLoanStatus(loanID string) (string, error)
+	GetLoanByID(loanID string) (*models.Loan, error)
+	UpdateLoanBalances(loan *models.Loan, previousBalance models.Money) error
+	ChargeLateFee(loan *models.Loan, previousBalance models.Money, key string) (bool, error)
+	ApplyRepaymentBalances(loan *models.Loan, previousBalance models.Money, repaymentID string) (bool, error)
+	GetLoanTotals(status string) ([]models.LoanTotals, error)
+	GetLoansByUser(filter models.LoanFilter) ([]models.Loan, int64, error)
+	UpdateCreditScore(loanID string, score *models.CreditScore) error
+	UpdateAutoDecision(loanID string, decision *models.AutoDecision) error
+	DecideLoan(loanID string, change models.StatusChange, decision *models.LoanDecision, approval *models.LoanApproval) error
+	AssignLoan(loanID string, assignment *models.LoanAssignment, statuses []string) error
+	AddLoanParty(loanID string, party models.LoanParty) error
+	UpdateLoanParty(loanID string, party models.LoanParty) error
+	RemoveLoanParty(loanID, partyID string) error
+	GetLoansByStatus(statuses []string) ([]models.Loan, error)
+	UpdateDelinquency(loanID string, delinquency *models.Delinquency) error
+	GetDelinquentLoans(bucket string, statuses []string) ([]models.Loan, error)
+	UpdateLoanTerms(loanID string, annualRate float64, term int) error
+	UpdateAccrual(loanID string, accrual *models.InterestAccrual) error
+	GetAssignedLoans(officerID string, statuses []string) ([]models.Loan, error)
+	GetUnassignedLoans(statuses []string) ([]models.Loan, error)
+	GetOfficerWorkloads(statuses []string) ([]models.OfficerWorkload, error)
 }
@@ -6,5 +6,5 @@ import (
 
 type ILogRepository interface {
 	CreateLog(log *models.SystemLog) error
-	GetAllLogs() ([]models.SystemLog, error)
+	ListLogs(query models.ListQuery) ([]models.SystemLog, string, error)
 }
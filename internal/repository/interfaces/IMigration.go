@@ -0,0 +1,6 @@
+package repository_interface
+
+type IMigrationRepository interface {
+	MigrateLegacyMoney() (map[string]int64, error)
+	CountLegacyMoney() (map[string]int64, error)
+}
@@ -0,0 +1,12 @@
+package repository_interface
+
+import (
+	"LoanGuard/internal/domain/models"
+)
+
+type IProductRepository interface {
+	CreateProduct(product *models.LoanProduct) (*models.LoanProduct, error)
+	GetProductByID(productID string) (*models.LoanProduct, error)
+	GetProducts(activeOnly bool) ([]models.LoanProduct, error)
+	UpdateProduct(productID string, product *models.LoanProduct) error
+}
@@ -0,0 +1,12 @@
+package repository_interface
+
+import (
+	"LoanGuard/internal/domain/models"
+)
+
+type IReasonCodeRepository interface {
+	CreateReasonCode(reasonCode *models.ReasonCode) (*models.ReasonCode, error)
+	GetReasonCode(code string) (*models.ReasonCode, error)
+	GetReasonCodes(activeOnly bool) ([]models.ReasonCode, error)
+	UpdateReasonCode(code string, reasonCode *models.ReasonCode) error
+}
@@ -0,0 +1,11 @@
+package repository_interface
+
+import (
+	"LoanGuard/internal/domain/models"
+)
+
+type IReminderRepository interface {
+	CreateReminder(reminder *models.Reminder) (*models.Reminder, error)
+	DeleteReminder(reminderID string) error
+	GetReminders(loanID string) ([]models.Reminder, error)
+}
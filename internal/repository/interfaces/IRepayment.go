@@ -0,0 +1,15 @@
+package repository_interface
+
+import (
+	"LoanGuard/internal/domain/models"
+	"time"
+)
+
+type IRepaymentRepository interface {
+	CreateRepayment(repayment *models.Repayment) (*models.Repayment, error)
+	GetRepaymentsByLoanID(loanID string) ([]models.Repayment, error)
+	GetRepaymentByReference(reference string) (*models.Repayment, error)
+	GetPendingRepayments(loanID string) ([]models.Repayment, error)
+	MarkRepaymentApplied(repaymentID string, at time.Time) error
+	DeleteRepayment(repaymentID string) error
+}
@@ -0,0 +1,14 @@
+package repository_interface
+
+import (
+	"LoanGuard/internal/domain/models"
+	"time"
+)
+
+type IRestructureRepository interface {
+	CreateRestructure(restructure *models.Restructure) (*models.Restructure, error)
+	GetRestructure(restructureID string) (*models.Restructure, error)
+	GetRestructuresByLoan(loanID string) ([]models.Restructure, error)
+	UpdateRestructureStatus(restructureID string, from string, to string, at time.Time) error
+	UpdateRestructure(restructure *models.Restructure) error
+}
@@ -0,0 +1,12 @@
+package repository_interface
+
+import (
+	"LoanGuard/internal/domain/models"
+)
+
+type IScheduleRepository interface {
+	CreateSchedule(schedule *models.RepaymentSchedule) (*models.RepaymentSchedule, error)
+	GetScheduleByLoanID(loanID string) (*models.RepaymentSchedule, error)
+	GetSchedulesByLoanID(loanID string) ([]models.RepaymentSchedule, error)
+	UpdateInstallments(scheduleID string, installments []models.Installment) error
+}
@@ -0,0 +1,10 @@
+package repository_interface
+
+import (
+	"LoanGuard/internal/domain/models"
+)
+
+type IScorecardRepository interface {
+	CreateScorecard(scorecard *models.Scorecard) (*models.Scorecard, error)
+	GetLatestScorecard() (*models.Scorecard, error)
+}
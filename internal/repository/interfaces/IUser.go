@@ -9,13 +9,17 @@ import (
 type IUserRepository interface {
 	Register(user *models.User) (*models.User, error)
 	GetUserByID(id string) (*models.User, error)
-	GetAllUsers() ([]*models.User, error)
+	ListUsers(query models.ListQuery) ([]*models.User, string, error)
 	GetUserByEmail(email string) (*models.User, error)
 	DeleteUser(id string) error
 	UpdateUser(id string, user *models.User) error
 	UpdateUserProfile(userID string, updateData *models.User) (*dtos.UpdateProfileDTO, error)
 	PromoteUser(userID string) error
 	DemoteUser(userID string) error
+	UpdateRole(userID string, role string) error
+	GetUsersByRole(role string) ([]*models.User, error)
+	UpdateKYC(userID string, dateOfBirth *time.Time, kyc *models.KYCProfile) error
+	GetUsersByKYCStatus(status string) ([]*models.User, error)
 	UpdatePassword(userID string, hashedPassword string) error
 	BlacklistToken(token string, remainingTime time.Duration) error
 }
@@ -0,0 +1,249 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// accrualDescription starts the description of each day's accrual in the
+// ledger.
+const accrualDescription = "Interest accrued for "
+
+type IAccrualUsecase interface {
+	RunAccrual(through time.Time) error
+}
+
+type AccrualUsecase struct {
+	loanRepo     repository_interface.ILoanRepository
+	scheduleRepo repository_interface.IScheduleRepository
+	productRepo  repository_interface.IProductRepository
+	ledgerRepo   repository_interface.ILedgerRepository
+	logRepo      repository_interface.ILogRepository
+	dayCount     string
+}
+
+// NewAccrualUsecase accrues interest under dayCount for loans whose product
+// does not set a convention of its own.
+func NewAccrualUsecase(loanRepo repository_interface.ILoanRepository, scheduleRepo repository_interface.IScheduleRepository, productRepo repository_interface.IProductRepository, ledgerRepo repository_interface.ILedgerRepository, logRepo repository_interface.ILogRepository, dayCount string) IAccrualUsecase {
+	return &AccrualUsecase{
+		loanRepo:     loanRepo,
+		scheduleRepo: scheduleRepo,
+		productRepo:  productRepo,
+		ledgerRepo:   ledgerRepo,
+		logRepo:      logRepo,
+		dayCount:     dayCount,
+	}
+}
+
+// RunAccrual recognises each serviced loan's interest as income for every
+// day up to and including through that has not been accrued yet, so a run
+// after downtime backfills the days that were missed. Each day accrues the
+// principal outstanding at its end at the rate of the schedule version in
+// force, for the day's share of a year under the loan's day count, and is
+// posted to the ledger as its own transaction. Days the loan spent
+// defaulted accrue nothing. Accrual never recognises more than the interest
+// still unearned on the loan. Where accrual resumes is read from the ledger,
+// not from the loan, and each day is posted under its own key, so a run that
+// is repeated, interrupted or overlaps another never accrues a day twice.
+func (au *AccrualUsecase) RunAccrual(through time.Time) error {
+	through = time.Date(through.Year(), through.Month(), through.Day(), 0, 0, 0, 0, time.UTC)
+	if models.DaysBetween(time.Now().UTC(), through) >= 0 {
+		return fmt.Errorf("%w: interest can only be accrued for days that have ended", models.ErrInvalidQuery)
+	}
+	loans, err := au.loanRepo.GetLoansByStatus(servicedStatuses)
+	if err != nil {
+		return err
+	}
+	dayCounts := map[string]string{}
+	var failed []string
+	for i := range loans {
+		if err := au.accrueLoan(&loans[i], through, dayCounts); err != nil {
+			failed = append(failed, loans[i].ID.Hex()+": "+err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("interest accrual failed for %d loans: %s", len(failed), strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+func (au *AccrualUsecase) accrueLoan(loan *models.Loan, through time.Time, dayCounts map[string]string) error {
+	schedules, err := au.scheduleRepo.GetSchedulesByLoanID(loan.ID.Hex())
+	if err != nil || len(schedules) == 0 {
+		return err
+	}
+	entries, err := au.ledgerRepo.GetEntriesByLoanID(loan.ID.Hex())
+	if err != nil {
+		return err
+	}
+	dayCount, err := au.loanDayCount(loan, dayCounts)
+	if err != nil {
+		return err
+	}
+
+	currency := loan.OutstandingBalance.Currency
+	accrual := &models.InterestAccrual{AccruedInterest: models.NewMoney(0, currency), DayCount: dayCount}
+
+	// the ledger is the record of what has accrued, so a run that stopped
+	// before saving the loan's accrual neither loses nor repeats a day.
+	// Accrual starts on the day the loan was booked.
+	unearned := models.NewMoney(0, currency)
+	posted := map[string]bool{}
+	var day time.Time
+	for _, entry := range entries {
+		switch entry.Account {
+		case models.AccountUnearnedInterest:
+			unearned = unearned.Add(entry.Credit).Sub(entry.Debit)
+		case models.AccountInterestIncome:
+			if strings.HasPrefix(entry.Description, accrualDescription) {
+				posted[entry.Description] = true
+				accrual.AccruedInterest = accrual.AccruedInterest.Add(entry.Credit)
+				if accrued, err := time.Parse("2006-01-02", strings.TrimPrefix(entry.Description, accrualDescription)); err == nil && !accrued.Before(day) {
+					day = accrued.AddDate(0, 0, 1)
+				}
+			}
+		case models.AccountPrincipalReceivable:
+			if day.IsZero() && !entry.Debit.IsZero() {
+				day = utcDay(entry.CreatedAt)
+			}
+		}
+	}
+	if day.IsZero() {
+		return nil
+	}
+	if day.After(through) {
+		return nil
+	}
+
+	principal := models.NewMoney(0, currency)
+	next := 0
+	for ; !day.After(through); day = day.AddDate(0, 0, 1) {
+		end := day.AddDate(0, 0, 1)
+		for ; next < len(entries) && entries[next].CreatedAt.Before(end); next++ {
+			if entries[next].Account == models.AccountPrincipalReceivable {
+				principal = principal.Add(entries[next].Debit).Sub(entries[next].Credit)
+			}
+		}
+
+		description := accrualDescription + day.Format("2006-01-02")
+		if posted[description] || !accruingAt(loan, end) || principal.Amount <= 0 {
+			continue
+		}
+		rate := models.RateFromFloat(scheduleInForce(schedules, end).AnnualRate)
+		interest := principal.Mul(rate.Mul(rate, models.YearFraction(dayCount, day, end))).Min(unearned)
+		if interest.Amount <= 0 {
+			continue
+		}
+		transaction, err := newKeyedLedgerTransaction(loan.ID, "accrual:"+loan.ID.Hex()+":"+day.Format("2006-01-02"), description,
+			debit(models.AccountUnearnedInterest, interest),
+			credit(models.AccountInterestIncome, interest),
+		)
+		if err != nil {
+			return err
+		}
+		// a run overlapping this one may have posted the day first
+		if err := au.ledgerRepo.CreateEntries(transaction); err != nil && !errors.Is(err, models.ErrDuplicateLedgerEntry) {
+			return err
+		}
+		unearned = unearned.Sub(interest)
+		accrual.AccruedInterest = accrual.AccruedInterest.Add(interest)
+	}
+
+	accrual.AccruedThrough = through
+	return au.loanRepo.UpdateAccrual(loan.ID.Hex(), accrual)
+}
+
+func utcDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// loanDayCount returns the loan's product's day count, or the default,
+// loading each product once per run.
+func (au *AccrualUsecase) loanDayCount(loan *models.Loan, dayCounts map[string]string) (string, error) {
+	if loan.ProductID.IsZero() {
+		return au.dayCount, nil
+	}
+	id := loan.ProductID.Hex()
+	if dayCount, ok := dayCounts[id]; ok {
+		return dayCount, nil
+	}
+	product, err := au.productRepo.GetProductByID(id)
+	if err != nil {
+		return "", err
+	}
+	dayCount := au.dayCount
+	if product.DayCount != "" {
+		dayCount = product.DayCount
+	}
+	dayCounts[id] = dayCount
+	return dayCount, nil
+}
+
+// accruingAt reports whether the loan was in an accruing status just before
+// the given time, going by its status history.
+func accruingAt(loan *models.Loan, at time.Time) bool {
+	status := ""
+	for _, change := range loan.StatusHistory {
+		if change.At.Before(at) {
+			status = change.To
+		}
+	}
+	if status == "" {
+		status = loan.Status
+	}
+	return containsString(models.AccruingStatuses, status)
+}
+
+// scheduleInForce returns the latest schedule version created before the
+// given time, so a rate change takes effect from the day it was made.
+// schedules are ordered oldest first.
+func scheduleInForce(schedules []models.RepaymentSchedule, at time.Time) *models.RepaymentSchedule {
+	inForce := &schedules[0]
+	for i := range schedules {
+		if schedules[i].CreatedAt.Before(at) {
+			inForce = &schedules[i]
+		}
+	}
+	return inForce
+}
+
+// recognizeUnearnedInterest moves whatever interest is still unearned on a
+// loan that has been repaid in full to income, settling the difference
+// between the interest the schedule charged and what accrued day by day.
+func recognizeUnearnedInterest(ledgerRepo repository_interface.ILedgerRepository, loan *models.Loan) error {
+	unearned, err := unearnedInterest(ledgerRepo, loan)
+	if err != nil {
+		return err
+	}
+	if unearned.Amount <= 0 {
+		return nil
+	}
+	transaction, err := newLedgerTransaction(loan.ID, "Remaining interest recognised on closing",
+		debit(models.AccountUnearnedInterest, unearned),
+		credit(models.AccountInterestIncome, unearned),
+	)
+	if err != nil {
+		return err
+	}
+	return ledgerRepo.CreateEntries(transaction)
+}
+
+// unearnedInterest is the balance of the loan's unearned interest account.
+func unearnedInterest(ledgerRepo repository_interface.ILedgerRepository, loan *models.Loan) (models.Money, error) {
+	entries, err := ledgerRepo.GetEntriesByLoanID(loan.ID.Hex())
+	if err != nil {
+		return models.Money{}, err
+	}
+	unearned := models.NewMoney(0, loan.OutstandingBalance.Currency)
+	for _, entry := range entries {
+		if entry.Account == models.AccountUnearnedInterest {
+			unearned = unearned.Add(entry.Credit).Sub(entry.Debit)
+		}
+	}
+	return unearned, nil
+}
@@ -1,56 +1,192 @@
 package usecases
 
 import (
+	"LoanGuard/internal/domain/dtos"
 	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/infrastructures/services/email_service"
 	"LoanGuard/internal/repository/interfaces"
 	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
 )
 	
 type IAdminUsecase interface {
-    GetLoans(status, order string) ([]models.Loan, error)
-    AcceptOrRejectLoan(loanID string, status string) error
+    GetLoans(query models.ListQuery) ([]models.Loan, string, error)
+    GetLoanSummary(status string, asOf time.Time) (*models.LoanSummary, error)
+    AcceptOrRejectLoan(loanID string, actorID string, req dtos.LoanDecisionDTO) error
+    TransitionLoan(loanID string, status string, actorID string, reason string) error
     DeleteLoan(loanID string) error
-    GetSystemLogs() ([]models.SystemLog, error)
+    GetSystemLogs(query models.ListQuery) ([]models.SystemLog, string, error)
 }
 
 type adminUseCase struct {
-    loanRepo repository_interface.ILoanRepository
-    logRepo  repository_interface.ILogRepository
+    loanRepo     repository_interface.ILoanRepository
+    logRepo      repository_interface.ILogRepository
+    fxRateRepo   repository_interface.IFxRateRepository
+    reasonCodeRepo repository_interface.IReasonCodeRepository
+    reportingCurrency string
+    decider      *loanDecider
 }
 
-func NewAdminUsecase(loanRepo repository_interface.ILoanRepository, logRepo repository_interface.ILogRepository) IAdminUsecase {
-    return &adminUseCase{loanRepo: loanRepo, logRepo: logRepo}
+func NewAdminUsecase(loanRepo repository_interface.ILoanRepository, logRepo repository_interface.ILogRepository, fxRateRepo repository_interface.IFxRateRepository, productRepo repository_interface.IProductRepository, userRepo repository_interface.IUserRepository, reasonCodeRepo repository_interface.IReasonCodeRepository, documentRepo repository_interface.IDocumentRepository, collateralRepo repository_interface.ICollateralRepository, emailSvc email_service.IEmailService, reportingCurrency string) IAdminUsecase {
+    return &adminUseCase{
+        loanRepo:          loanRepo,
+        logRepo:           logRepo,
+        fxRateRepo:        fxRateRepo,
+        reasonCodeRepo:    reasonCodeRepo,
+        reportingCurrency: strings.ToUpper(reportingCurrency),
+        decider:           newLoanDecider(loanRepo, logRepo, productRepo, userRepo, documentRepo, collateralRepo, emailSvc),
+    }
+}
+
+func (uc *adminUseCase) GetLoans(query models.ListQuery) ([]models.Loan, string, error) {
+    return uc.loanRepo.ListLoans(query)
 }
 
-func (uc *adminUseCase) GetLoans(status string, order string) ([]models.Loan, error) {
-    loans, err := uc.loanRepo.GetAllLoans(status, order)
+// GetLoanSummary totals the portfolio in the reporting currency using the FX
+// rates that were effective on asOf. Currencies without a rate on that date
+// are left out of the converted totals and listed in MissingRates.
+func (uc *adminUseCase) GetLoanSummary(status string, asOf time.Time) (*models.LoanSummary, error) {
+    totals, err := uc.loanRepo.GetLoanTotals(status)
+    if err != nil {
+        return nil, err
+    }
+    rates, err := uc.fxRateRepo.GetRatesAsOf(uc.reportingCurrency, asOf)
     if err != nil {
         return nil, err
     }
-    return loans, nil
+
+    zero := models.NewMoney(0, uc.reportingCurrency)
+    summary := &models.LoanSummary{
+        ReportingCurrency: uc.reportingCurrency,
+        AsOf:              asOf,
+        Total:             models.LoanSummaryLine{Amount: zero, Outstanding: zero},
+        ByStatus:          map[string]models.LoanSummaryLine{},
+        ByCurrency:        map[string]models.LoanSummaryLine{},
+        RatesUsed:         map[string]string{},
+        MissingRates:      []string{},
+    }
+
+    for _, t := range totals {
+        currency := t.Currency
+        if currency == "" {
+            currency = models.DefaultCurrency
+        }
+        amount := models.NewMoney(t.Amount, currency)
+        outstanding := models.NewMoney(t.Outstanding, currency)
+
+        native := summary.ByCurrency[currency]
+        if native.Amount.Currency == "" {
+            native.Amount, native.Outstanding = models.NewMoney(0, currency), models.NewMoney(0, currency)
+        }
+        native.Count += t.Count
+        native.Amount = native.Amount.Add(amount)
+        native.Outstanding = native.Outstanding.Add(outstanding)
+        summary.ByCurrency[currency] = native
+
+        rate := big.NewRat(1, 1)
+        if currency != uc.reportingCurrency {
+            fx, ok := rates[currency]
+            if !ok {
+                summary.MissingRates = appendUnique(summary.MissingRates, currency)
+                continue
+            }
+            rate, err = parseRate(fx.Rate)
+            if err != nil {
+                return nil, err
+            }
+            summary.RatesUsed[currency] = fx.Rate
+        }
+        amount = amount.ConvertTo(uc.reportingCurrency, rate)
+        outstanding = outstanding.ConvertTo(uc.reportingCurrency, rate)
+
+        line := summary.ByStatus[t.Status]
+        if line.Amount.Currency == "" {
+            line.Amount, line.Outstanding = zero, zero
+        }
+        line.Count += t.Count
+        line.Amount = line.Amount.Add(amount)
+        line.Outstanding = line.Outstanding.Add(outstanding)
+        summary.ByStatus[t.Status] = line
+
+        summary.Total.Count += t.Count
+        summary.Total.Amount = summary.Total.Amount.Add(amount)
+        summary.Total.Outstanding = summary.Total.Outstanding.Add(outstanding)
+    }
+    return summary, nil
 }
 
-func (uc *adminUseCase) AcceptOrRejectLoan(loanID string, status string) error {
-    if status != "approved" && status != "rejected" {
+func appendUnique(values []string, value string) []string {
+    for _, v := range values {
+        if v == value {
+            return values
+        }
+    }
+    return append(values, value)
+}
+
+// AcceptOrRejectLoan decides a loan with a reason code from the catalogue.
+// The code has to be active and meant for the requested status.
+func (uc *adminUseCase) AcceptOrRejectLoan(loanID string, actorID string, req dtos.LoanDecisionDTO) error {
+    if req.Status != models.LoanStatusApproved && req.Status != models.LoanStatusRejected {
         return errors.New("invalid status")
     }
+    code := strings.ToUpper(strings.TrimSpace(req.ReasonCode))
+    if code == "" {
+        return fmt.Errorf("%w: reason_code is required", models.ErrInvalidReasonCode)
+    }
+    reasonCode, err := uc.reasonCodeRepo.GetReasonCode(code)
+    if err != nil || !reasonCode.Active {
+        return fmt.Errorf("%w: %s is not an active reason code", models.ErrInvalidReasonCode, code)
+    }
+    if !reasonCode.AppliesToStatus(req.Status) {
+        return fmt.Errorf("%w: %s cannot be used for %s loans", models.ErrInvalidReasonCode, code, req.Status)
+    }
 
-    err := uc.loanRepo.UpdateLoanStatus(loanID, status)
+    loan, err := uc.loanRepo.GetLoanByID(loanID)
     if err != nil {
-        return err
+        return models.ErrLoanNotFound
     }
+    return uc.decider.decide(loan, actorID, models.LoanDecision{
+        Status:      req.Status,
+        ReasonCode:  reasonCode.Code,
+        ReasonLabel: reasonCode.Label,
+        Note:        strings.TrimSpace(req.Note),
+    })
+}
 
-    log := models.SystemLog{
-        Action: "Loan " + status + ": " + loanID,
+// TransitionLoan moves a loan through the operational stages of its
+// lifecycle. Approval and rejection go through AcceptOrRejectLoan and
+// disbursement through the payout provider instead because they carry side
+// effects of their own.
+func (uc *adminUseCase) TransitionLoan(loanID string, status string, actorID string, reason string) error {
+    switch status {
+    case models.LoanStatusUnderReview, models.LoanStatusActive,
+        models.LoanStatusDefaulted, models.LoanStatusWrittenOff:
+    default:
+        return fmt.Errorf("%w: %s cannot be set directly", models.ErrInvalidTransition, status)
     }
-    uc.logRepo.CreateLog(&log)
 
-    return nil
+    loan, err := uc.loanRepo.GetLoanByID(loanID)
+    if err != nil {
+        return models.ErrLoanNotFound
+    }
+    return transitionLoan(uc.loanRepo, uc.logRepo, loan, status, actorID, reason)
 }
 
+// DeleteLoan deletes a draft, rejected or cancelled loan. Loans that went
+// further are kept for their ledger and history.
 func (uc *adminUseCase) DeleteLoan(loanID string) error {
-    err := uc.loanRepo.DeleteLoan(loanID)
+    loan, err := uc.loanRepo.GetLoanByID(loanID)
     if err != nil {
+        return models.ErrLoanNotFound
+    }
+    if err := uc.loanRepo.DeleteLoan(loanID, models.DeletableStatuses); err != nil {
+        if errors.Is(err, models.ErrStatusConflict) {
+            return fmt.Errorf("%w: the loan is %s, only draft, rejected or cancelled loans can be deleted", models.ErrStatusConflict, loan.Status)
+        }
         return err
     }
 
@@ -62,10 +198,6 @@ func (uc *adminUseCase) DeleteLoan(loanID string) error {
     return nil
 }
 
-func (uc *adminUseCase) GetSystemLogs() ([]models.SystemLog, error) {
-    logs, err := uc.logRepo.GetAllLogs()
-    if err != nil {
-        return nil, err
-    }
-    return logs, nil
+func (uc *adminUseCase) GetSystemLogs(query models.ListQuery) ([]models.SystemLog, string, error) {
+    return uc.logRepo.ListLogs(query)
 }
@@ -0,0 +1,208 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type IAssignmentUsecase interface {
+	GetQueue(officerID string) ([]dtos.QueueItemDTO, error)
+	GetOfficers() ([]models.OfficerWorkload, error)
+	AssignLoan(loanID string, actorID string, req dtos.LoanAssignmentDTO) (*models.LoanAssignment, error)
+	AssignPendingLoans(actorID string, strategy string) (int, error)
+	ReassignOfficerLoans(officerID string, actorID string, strategy string) (int, error)
+	UpdateUserRole(userID string, actorID string, role string) error
+}
+
+type AssignmentUsecase struct {
+	loanRepo  repository_interface.ILoanRepository
+	userRepo  repository_interface.IUserRepository
+	logRepo   repository_interface.ILogRepository
+	assigner  *loanAssigner
+	reviewSLA time.Duration
+}
+
+func NewAssignmentUsecase(loanRepo repository_interface.ILoanRepository, userRepo repository_interface.IUserRepository, logRepo repository_interface.ILogRepository, reviewSLA time.Duration) IAssignmentUsecase {
+	return &AssignmentUsecase{
+		loanRepo:  loanRepo,
+		userRepo:  userRepo,
+		logRepo:   logRepo,
+		assigner:  newLoanAssigner(loanRepo, userRepo, logRepo),
+		reviewSLA: reviewSLA,
+	}
+}
+
+// GetQueue returns the loans the officer holds under review, the one whose
+// review deadline comes first at the top. The deadline runs from the last
+// time the loan was submitted.
+func (au *AssignmentUsecase) GetQueue(officerID string) ([]dtos.QueueItemDTO, error) {
+	loans, err := au.loanRepo.GetAssignedLoans(officerID, models.ReviewStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	queue := make([]dtos.QueueItemDTO, 0, len(loans))
+	for i := range loans {
+		submittedAt := submissionTime(&loans[i])
+		dueAt := submittedAt.Add(au.reviewSLA)
+		queue = append(queue, dtos.QueueItemDTO{
+			Loan:        &loans[i],
+			SubmittedAt: submittedAt,
+			SLADueAt:    dueAt,
+			Overdue:     now.After(dueAt),
+		})
+	}
+	sort.SliceStable(queue, func(i, j int) bool {
+		return queue[i].SLADueAt.Before(queue[j].SLADueAt)
+	})
+	return queue, nil
+}
+
+func submissionTime(loan *models.Loan) time.Time {
+	for i := len(loan.StatusHistory) - 1; i >= 0; i-- {
+		if loan.StatusHistory[i].To == models.LoanStatusPending {
+			return loan.StatusHistory[i].At
+		}
+	}
+	return loan.CreatedAt
+}
+
+func (au *AssignmentUsecase) GetOfficers() ([]models.OfficerWorkload, error) {
+	return au.assigner.workloads()
+}
+
+// AssignLoan gives a loan under review to the named officer, or to one
+// picked by the requested strategy. A loan that already has an officer is
+// reassigned.
+func (au *AssignmentUsecase) AssignLoan(loanID string, actorID string, req dtos.LoanAssignmentDTO) (*models.LoanAssignment, error) {
+	loan, err := au.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, models.ErrLoanNotFound
+	}
+	if !inReview(loan) {
+		return nil, fmt.Errorf("%w: %s loans cannot be assigned", models.ErrInvalidTransition, loan.Status)
+	}
+
+	officerID, strategy := req.OfficerID, models.AssignmentManual
+	if officerID != "" {
+		officer, err := au.userRepo.GetUserByID(officerID)
+		if err != nil || !isOfficer(officer) {
+			return nil, fmt.Errorf("%w: user is not a loan officer", models.ErrInvalidAssignment)
+		}
+	} else {
+		strategy = req.Strategy
+		if !validAssignmentStrategy(strategy) {
+			return nil, fmt.Errorf("%w: name an officer_id or a strategy of round_robin or least_loaded", models.ErrInvalidAssignment)
+		}
+		workloads, err := au.assigner.workloads()
+		if err != nil {
+			return nil, err
+		}
+		exclude := ""
+		if loan.Assignment != nil {
+			exclude = loan.Assignment.OfficerID
+		}
+		chosen, err := chooseOfficer(workloads, strategy, exclude)
+		if err != nil {
+			return nil, err
+		}
+		officerID = workloads[chosen].OfficerID
+	}
+	if loan.Assignment != nil && loan.Assignment.OfficerID == officerID {
+		return nil, fmt.Errorf("%w: loan is already assigned to this officer", models.ErrInvalidAssignment)
+	}
+
+	if err := au.assigner.assign(loan, officerID, strategy, actorID, strings.TrimSpace(req.Reason)); err != nil {
+		return nil, err
+	}
+	return loan.Assignment, nil
+}
+
+// AssignPendingLoans spreads every unassigned loan under review over the
+// officers, oldest loan first, and returns how many were assigned.
+func (au *AssignmentUsecase) AssignPendingLoans(actorID string, strategy string) (int, error) {
+	loans, err := au.loanRepo.GetUnassignedLoans(models.ReviewStatuses)
+	if err != nil {
+		return 0, err
+	}
+	return au.distribute(loans, actorID, strategy, "", "")
+}
+
+// ReassignOfficerLoans moves every loan an officer holds under review to the
+// other officers, for instance when they leave or go on holiday.
+func (au *AssignmentUsecase) ReassignOfficerLoans(officerID string, actorID string, strategy string) (int, error) {
+	loans, err := au.loanRepo.GetAssignedLoans(officerID, models.ReviewStatuses)
+	if err != nil {
+		return 0, err
+	}
+	return au.distribute(loans, actorID, strategy, officerID, "officer's queue reassigned")
+}
+
+func (au *AssignmentUsecase) distribute(loans []models.Loan, actorID string, strategy string, exclude string, reason string) (int, error) {
+	if !validAssignmentStrategy(strategy) {
+		return 0, fmt.Errorf("%w: strategy must be round_robin or least_loaded", models.ErrInvalidAssignment)
+	}
+	if len(loans) == 0 {
+		return 0, nil
+	}
+	workloads, err := au.assigner.workloads()
+	if err != nil {
+		return 0, err
+	}
+
+	assigned := 0
+	for i := range loans {
+		chosen, err := chooseOfficer(workloads, strategy, exclude)
+		if err != nil {
+			return assigned, err
+		}
+		if err := au.assigner.assign(&loans[i], workloads[chosen].OfficerID, strategy, actorID, reason); err != nil {
+			return assigned, err
+		}
+		workloads[chosen].OpenLoans++
+		workloads[chosen].LastAssignedAt = loans[i].Assignment.AssignedAt
+		assigned++
+	}
+	return assigned, nil
+}
+
+// UpdateUserRole changes a user's role. The queue of an officer losing the
+// role is spread over the remaining officers; with no officer left it stays
+// as it is until an admin reassigns it.
+func (au *AssignmentUsecase) UpdateUserRole(userID string, actorID string, role string) error {
+	role = strings.ToUpper(strings.TrimSpace(role))
+	if !models.IsRole(role) {
+		return errors.New("role must be ADMIN, OFFICER or USER")
+	}
+	user, err := au.userRepo.GetUserByID(userID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	if err := au.userRepo.UpdateRole(userID, role); err != nil {
+		return err
+	}
+
+	actor, _ := primitive.ObjectIDFromHex(actorID)
+	au.logRepo.CreateLog(&models.SystemLog{
+		Action:    fmt.Sprintf("User role changed from %s to %s", strings.ToUpper(user.Role), role),
+		Timestamp: time.Now(),
+		UserID:    actor,
+	})
+
+	if isOfficer(user) && role != models.RoleOfficer {
+		_, err := au.ReassignOfficerLoans(userID, actorID, models.AssignmentLeastLoaded)
+		if err != nil && !errors.Is(err, models.ErrNoOfficers) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ICollateralUsecase interface {
+	AddCollateral(loanID string, actorID string, role string, req dtos.CollateralDTO) (*models.Collateral, error)
+	GetCollateral(loanID string) (*dtos.LoanCollateralDTO, error)
+	UpdateCollateral(loanID string, collateralID string, adminID string, req dtos.CollateralDTO) (*models.Collateral, error)
+	RemoveCollateral(loanID string, collateralID string, actorID string) error
+}
+
+type CollateralUsecase struct {
+	collateralRepo repository_interface.ICollateralRepository
+	loanRepo       repository_interface.ILoanRepository
+	productRepo    repository_interface.IProductRepository
+	documentRepo   repository_interface.IDocumentRepository
+	logRepo        repository_interface.ILogRepository
+}
+
+func NewCollateralUsecase(collateralRepo repository_interface.ICollateralRepository, loanRepo repository_interface.ILoanRepository, productRepo repository_interface.IProductRepository, documentRepo repository_interface.IDocumentRepository, logRepo repository_interface.ILogRepository) ICollateralUsecase {
+	return &CollateralUsecase{
+		collateralRepo: collateralRepo,
+		loanRepo:       loanRepo,
+		productRepo:    productRepo,
+		documentRepo:   documentRepo,
+		logRepo:        logRepo,
+	}
+}
+
+// AddCollateral pledges an asset for the loan while it is being applied for
+// or reviewed. Its lien stays pending until the loan is approved. A value
+// given by the borrower only counts once an admin or officer appraises the
+// asset; one given by staff is their appraisal.
+func (cu *CollateralUsecase) AddCollateral(loanID string, actorID string, role string, req dtos.CollateralDTO) (*models.Collateral, error) {
+	loan, err := cu.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, models.ErrLoanNotFound
+	}
+	if !acceptsDocuments(loan) {
+		return nil, fmt.Errorf("%w: collateral cannot be added to %s loans", models.ErrInvalidTransition, loan.Status)
+	}
+
+	now := time.Now()
+	collateral := &models.Collateral{
+		LoanID:     loan.ID,
+		LienStatus: models.LienStatusPending,
+		CreatedBy:  actorID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := cu.fillCollateral(collateral, loan, req); err != nil {
+		return nil, err
+	}
+	if models.IsStaffRole(role) {
+		collateral.AppraisedBy = actorID
+		collateral.AppraisedAt = &now
+	}
+	result, err := cu.collateralRepo.CreateCollateral(collateral)
+	if err != nil {
+		return nil, err
+	}
+	cu.logCollateral(actorID, loanID, "Collateral added: "+collateral.Type+" appraised at "+collateral.AppraisedValue.String())
+	return result, nil
+}
+
+// GetCollateral lists the loan's collateral and its loan-to-value ratio.
+func (cu *CollateralUsecase) GetCollateral(loanID string) (*dtos.LoanCollateralDTO, error) {
+	loan, err := cu.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, models.ErrLoanNotFound
+	}
+	collateral, err := cu.collateralRepo.GetCollateralByLoan(loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dtos.LoanCollateralDTO{
+		Collateral:  collateral,
+		TotalValue:  models.CollateralValue(collateral, loan.Amount.Currency),
+		WithinLimit: true,
+	}
+	if ltv, ok := models.LoanToValue(loan.Amount, result.TotalValue); ok {
+		result.LTV = &ltv
+	}
+	if !loan.ProductID.IsZero() {
+		product, err := cu.productRepo.GetProductByID(loan.ProductID.Hex())
+		if err != nil {
+			return nil, err
+		}
+		if product.MaxLTV > 0 {
+			result.MaxLTV = &product.MaxLTV
+			result.WithinLimit = result.LTV != nil && *result.LTV <= product.MaxLTV
+		}
+	}
+	return result, nil
+}
+
+// UpdateCollateral records an admin's or officer's appraisal of an asset
+// whose lien has not been released, after which its value counts towards the
+// loan-to-value ratio.
+func (cu *CollateralUsecase) UpdateCollateral(loanID string, collateralID string, adminID string, req dtos.CollateralDTO) (*models.Collateral, error) {
+	collateral, err := cu.collateralRepo.GetCollateral(collateralID)
+	if err != nil || collateral.LoanID.Hex() != loanID {
+		return nil, models.ErrCollateralNotFound
+	}
+	if collateral.LienStatus == models.LienStatusReleased {
+		return nil, fmt.Errorf("%w: the lien has been released", models.ErrInvalidCollateral)
+	}
+	loan, err := cu.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, models.ErrLoanNotFound
+	}
+	previous := collateral.AppraisedValue
+	if err := cu.fillCollateral(collateral, loan, req); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	collateral.UpdatedAt = now
+	collateral.AppraisedBy = adminID
+	collateral.AppraisedAt = &now
+	if err := cu.collateralRepo.UpdateCollateral(collateral); err != nil {
+		return nil, err
+	}
+	cu.logCollateral(adminID, loanID, "Collateral "+collateralID+" updated, appraised value "+previous.String()+" -> "+collateral.AppraisedValue.String())
+	return collateral, nil
+}
+
+// RemoveCollateral withdraws an asset before the loan is decided.
+func (cu *CollateralUsecase) RemoveCollateral(loanID string, collateralID string, actorID string) error {
+	collateral, err := cu.collateralRepo.GetCollateral(collateralID)
+	if err != nil || collateral.LoanID.Hex() != loanID {
+		return models.ErrCollateralNotFound
+	}
+	loan, err := cu.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return models.ErrLoanNotFound
+	}
+	if !acceptsDocuments(loan) || collateral.LienStatus != models.LienStatusPending {
+		return fmt.Errorf("%w: collateral cannot be removed from %s loans", models.ErrInvalidTransition, loan.Status)
+	}
+	if err := cu.collateralRepo.DeleteCollateral(collateralID); err != nil {
+		return err
+	}
+	cu.logCollateral(actorID, loanID, "Collateral removed: "+collateral.Type+" "+collateralID)
+	return nil
+}
+
+// fillCollateral validates req and copies it onto collateral. Values must be
+// in the loan's currency so the loan-to-value ratio needs no conversion.
+func (cu *CollateralUsecase) fillCollateral(collateral *models.Collateral, loan *models.Loan, req dtos.CollateralDTO) error {
+	collateralType := strings.ToLower(strings.TrimSpace(req.Type))
+	if !models.ValidCollateralType(collateralType) {
+		return fmt.Errorf("%w: unknown collateral type %q", models.ErrInvalidCollateral, req.Type)
+	}
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		return fmt.Errorf("%w: a description is required", models.ErrInvalidCollateral)
+	}
+	if req.AppraisedValue.IsNegative() || req.AppraisedValue.IsZero() {
+		return fmt.Errorf("%w: the appraised value must be positive", models.ErrInvalidCollateral)
+	}
+	if req.AppraisedValue.Currency != loan.Amount.Currency {
+		return fmt.Errorf("%w: the appraised value must be in %s", models.ErrInvalidCollateral, loan.Amount.Currency)
+	}
+	appraisalDate, err := time.Parse("2006-01-02", req.AppraisalDate)
+	if err != nil {
+		return fmt.Errorf("%w: appraisal_date must be YYYY-MM-DD", models.ErrInvalidCollateral)
+	}
+	if appraisalDate.After(time.Now()) {
+		return fmt.Errorf("%w: the appraisal date cannot be in the future", models.ErrInvalidCollateral)
+	}
+
+	documents := make([]primitive.ObjectID, 0, len(req.Documents))
+	for _, documentID := range req.Documents {
+		document, err := cu.documentRepo.GetDocument(documentID)
+		if err != nil || document.LoanID != loan.ID {
+			return fmt.Errorf("%w: document %s is not attached to this loan", models.ErrInvalidCollateral, documentID)
+		}
+		documents = append(documents, document.ID)
+	}
+
+	collateral.Type = collateralType
+	collateral.Description = description
+	collateral.AppraisedValue = req.AppraisedValue
+	collateral.AppraisalDate = appraisalDate
+	collateral.Documents = documents
+	return nil
+}
+
+func (cu *CollateralUsecase) logCollateral(actorID string, loanID string, action string) {
+	userID, _ := primitive.ObjectIDFromHex(actorID)
+	cu.logRepo.CreateLog(&models.SystemLog{
+		Action:    action,
+		Timestamp: time.Now(),
+		UserID:    userID,
+		LoanID:    loanID,
+	})
+}
+
+// releaseLiens releases every lien on the loan's collateral once the loan no
+// longer needs securing, because it was repaid, rejected or cancelled.
+func releaseLiens(collateralRepo repository_interface.ICollateralRepository, logRepo repository_interface.ILogRepository, loan *models.Loan, actorID string) error {
+	collateral, err := collateralRepo.GetCollateralByLoan(loan.ID.Hex())
+	if err != nil {
+		return err
+	}
+	pledged := false
+	for _, item := range collateral {
+		pledged = pledged || item.LienStatus != models.LienStatusReleased
+	}
+	if !pledged {
+		return nil
+	}
+	now := time.Now()
+	for _, from := range []string{models.LienStatusPending, models.LienStatusActive} {
+		if err := collateralRepo.UpdateLienStatus(loan.ID.Hex(), from, models.LienStatusReleased, now); err != nil {
+			return err
+		}
+	}
+	userID, _ := primitive.ObjectIDFromHex(actorID)
+	logRepo.CreateLog(&models.SystemLog{
+		Action:    "Collateral liens released on " + loan.Status + " loan",
+		Timestamp: now,
+		UserID:    userID,
+		LoanID:    loan.ID.Hex(),
+	})
+	return nil
+}
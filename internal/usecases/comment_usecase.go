@@ -0,0 +1,194 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/infrastructures/services/email_service"
+	"LoanGuard/internal/repository/interfaces"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// mentionPattern matches staff mentions written as @ followed by their email
+// address, e.g. "@jane.doe@lender.com".
+var mentionPattern = regexp.MustCompile(`@([A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,})`)
+
+const maxCommentLength = 5000
+
+type ICommentUsecase interface {
+	AddComment(loanID string, authorID string, role string, req dtos.CommentDTO) (*models.Comment, error)
+	GetComments(loanID string, role string) ([]models.Comment, error)
+	EditComment(loanID string, commentID string, authorID string, role string, req dtos.CommentDTO) (*models.Comment, error)
+}
+
+type CommentUsecase struct {
+	commentRepo repository_interface.ICommentRepository
+	userRepo    repository_interface.IUserRepository
+	logRepo     repository_interface.ILogRepository
+	emailSvc    email_service.IEmailService
+}
+
+func NewCommentUsecase(commentRepo repository_interface.ICommentRepository, userRepo repository_interface.IUserRepository, logRepo repository_interface.ILogRepository, emailSvc email_service.IEmailService) ICommentUsecase {
+	return &CommentUsecase{
+		commentRepo: commentRepo,
+		userRepo:    userRepo,
+		logRepo:     logRepo,
+		emailSvc:    emailSvc,
+	}
+}
+
+// AddComment posts a comment on the loan. Staff choose its visibility and
+// can mention each other, borrowers always post visible comments. Mentioned
+// staff are emailed.
+func (cu *CommentUsecase) AddComment(loanID string, authorID string, role string, req dtos.CommentDTO) (*models.Comment, error) {
+	author, err := cu.userRepo.GetUserByID(authorID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: author not found", models.ErrInvalidComment)
+	}
+	comment := &models.Comment{
+		AuthorID:   authorID,
+		AuthorName: author.Name,
+		CreatedAt:  time.Now(),
+	}
+	comment.LoanID, _ = primitive.ObjectIDFromHex(loanID)
+	mentioned, err := cu.fillComment(comment, role, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := cu.commentRepo.CreateComment(comment)
+	if err != nil {
+		return nil, err
+	}
+	cu.notifyMentioned(result, mentioned)
+	return result, nil
+}
+
+// GetComments lists the loan's comments. Borrowers only see the ones marked
+// visible to them.
+func (cu *CommentUsecase) GetComments(loanID string, role string) ([]models.Comment, error) {
+	return cu.commentRepo.GetComments(loanID, models.IsStaffRole(role))
+}
+
+// EditComment lets the author rewrite a comment within CommentEditWindow of
+// posting it. Only staff mentioned for the first time are emailed.
+func (cu *CommentUsecase) EditComment(loanID string, commentID string, authorID string, role string, req dtos.CommentDTO) (*models.Comment, error) {
+	comment, err := cu.commentRepo.GetComment(commentID)
+	if err != nil || comment.LoanID.Hex() != loanID {
+		return nil, models.ErrCommentNotFound
+	}
+	if comment.AuthorID != authorID || time.Since(comment.CreatedAt) > models.CommentEditWindow {
+		return nil, models.ErrCommentEditClosed
+	}
+
+	previous := make(map[string]bool, len(comment.Mentions))
+	for _, id := range comment.Mentions {
+		previous[id] = true
+	}
+	if req.Visibility == "" {
+		req.Visibility = comment.Visibility
+	}
+	mentioned, err := cu.fillComment(comment, role, req)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	comment.EditedAt = &now
+
+	if err := cu.commentRepo.UpdateComment(comment); err != nil {
+		return nil, err
+	}
+	var newlyMentioned []*models.User
+	for _, user := range mentioned {
+		if !previous[user.ID.Hex()] {
+			newlyMentioned = append(newlyMentioned, user)
+		}
+	}
+	cu.notifyMentioned(comment, newlyMentioned)
+	return comment, nil
+}
+
+// fillComment validates the request and sets the comment's body, visibility
+// and mentions, returning the mentioned staff.
+func (cu *CommentUsecase) fillComment(comment *models.Comment, role string, req dtos.CommentDTO) ([]*models.User, error) {
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, fmt.Errorf("%w: body is required", models.ErrInvalidComment)
+	}
+	if len(body) > maxCommentLength {
+		return nil, fmt.Errorf("%w: body is longer than %d characters", models.ErrInvalidComment, maxCommentLength)
+	}
+	comment.Body = body
+
+	if !models.IsStaffRole(role) {
+		comment.Visibility = models.CommentVisibilityBorrower
+		comment.Mentions = nil
+		return nil, nil
+	}
+	switch req.Visibility {
+	case "", models.CommentVisibilityInternal:
+		comment.Visibility = models.CommentVisibilityInternal
+	case models.CommentVisibilityBorrower:
+		comment.Visibility = models.CommentVisibilityBorrower
+	default:
+		return nil, fmt.Errorf("%w: visibility must be internal or borrower", models.ErrInvalidComment)
+	}
+
+	mentioned, err := cu.resolveMentions(body)
+	if err != nil {
+		return nil, err
+	}
+	comment.Mentions = nil
+	for _, user := range mentioned {
+		comment.Mentions = append(comment.Mentions, user.ID.Hex())
+	}
+	return mentioned, nil
+}
+
+// resolveMentions looks up every mentioned email address. Only staff can be
+// mentioned.
+func (cu *CommentUsecase) resolveMentions(body string) ([]*models.User, error) {
+	var mentioned []*models.User
+	seen := map[string]bool{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		email := strings.ToLower(match[1])
+		if seen[email] {
+			continue
+		}
+		seen[email] = true
+
+		user, err := cu.userRepo.GetUserByEmail(email)
+		if err != nil || !models.IsStaffRole(user.Role) {
+			return nil, fmt.Errorf("%w: %s is not a staff member", models.ErrInvalidComment, email)
+		}
+		mentioned = append(mentioned, user)
+	}
+	return mentioned, nil
+}
+
+// notifyMentioned emails the mentioned staff. The comment is saved either
+// way, so failed emails are only logged.
+func (cu *CommentUsecase) notifyMentioned(comment *models.Comment, mentioned []*models.User) {
+	for _, user := range mentioned {
+		if user.ID.Hex() == comment.AuthorID {
+			continue
+		}
+		err := cu.emailSvc.SendMentionEmail(user.Email, email_service.MentionEmail{
+			Name:       user.Name,
+			AuthorName: comment.AuthorName,
+			LoanID:     comment.LoanID.Hex(),
+			Comment:    comment.Body,
+		})
+		if err != nil {
+			cu.logRepo.CreateLog(&models.SystemLog{
+				Action:    "Mention email to " + user.Email + " not sent: " + err.Error(),
+				Timestamp: time.Now(),
+				LoanID:    comment.LoanID.Hex(),
+			})
+		}
+	}
+}
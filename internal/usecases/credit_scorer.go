@@ -0,0 +1,167 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// Scorer assesses a loan application. Implementations may call out to a
+// bureau or a model; the built-in ScorecardScorer applies admin configured
+// rules.
+type Scorer interface {
+	Score(loan *models.Loan) (*models.CreditScore, error)
+}
+
+type ScorecardScorer struct {
+	userRepo      repository_interface.IUserRepository
+	loanRepo      repository_interface.ILoanRepository
+	scorecardRepo repository_interface.IScorecardRepository
+}
+
+func NewScorecardScorer(userRepo repository_interface.IUserRepository, loanRepo repository_interface.ILoanRepository, scorecardRepo repository_interface.IScorecardRepository) Scorer {
+	return &ScorecardScorer{
+		userRepo:      userRepo,
+		loanRepo:      loanRepo,
+		scorecardRepo: scorecardRepo,
+	}
+}
+
+// Score starts from the scorecard's base score and adds the points of every
+// rule: applicant age, account verification, loans still open, repayment
+// history and the requested amount against declared monthly income.
+func (s *ScorecardScorer) Score(loan *models.Loan) (*models.CreditScore, error) {
+	scorecard, err := currentScorecard(s.scorecardRepo)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.GetUserByID(loan.UserId.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("cannot score loan: %w", err)
+	}
+	history, _, err := s.loanRepo.GetLoansByUser(models.LoanFilter{UserID: loan.UserId})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.CreditScore{
+		Score:            scorecard.BaseScore,
+		Scorer:           "scorecard",
+		ScorecardVersion: scorecard.Version,
+		Reasons:          []models.ScoreReason{},
+		ScoredAt:         time.Now(),
+	}
+	add := func(rule string, points int, detail string) {
+		result.Score += points
+		result.Reasons = append(result.Reasons, models.ScoreReason{Rule: rule, Points: points, Detail: detail})
+	}
+
+	if age := user.AgeAt(result.ScoredAt); age > 0 {
+		add("age", thresholdPoints(scorecard.AgeRules, float64(age)), fmt.Sprintf("applicant is %d", age))
+	} else {
+		add("age", 0, "date of birth not provided")
+	}
+
+	if user.IsVerified {
+		add("verification", scorecard.VerifiedPoints, "account is verified")
+	} else {
+		add("verification", scorecard.UnverifiedPoints, "account is not verified")
+	}
+
+	open, closed, defaulted := 0, 0, 0
+	for _, other := range history {
+		if other.ID == loan.ID {
+			continue
+		}
+		switch other.Status {
+		case models.LoanStatusApproved, models.LoanStatusDisbursed, models.LoanStatusActive:
+			open++
+		case models.LoanStatusClosed:
+			closed++
+		case models.LoanStatusDefaulted:
+			open++
+			defaulted++
+		case models.LoanStatusWrittenOff:
+			defaulted++
+		}
+	}
+	add("open_exposure", thresholdPoints(scorecard.OpenLoanRules, float64(open)), fmt.Sprintf("%d open loans", open))
+
+	closedPoints := closed * scorecard.ClosedLoanPoints
+	if scorecard.MaxClosedLoanPoints > 0 && closedPoints > scorecard.MaxClosedLoanPoints {
+		closedPoints = scorecard.MaxClosedLoanPoints
+	}
+	add("repayment_history", closedPoints, fmt.Sprintf("%d loans repaid in full", closed))
+	if defaulted > 0 {
+		add("repayment_history", defaulted*scorecard.DefaultedLoanPoints, fmt.Sprintf("%d loans defaulted or written off", defaulted))
+	}
+
+	if income, source := monthlyIncome(loan, user); income.Amount <= 0 {
+		add("income", scorecard.NoIncomePoints, "no monthly income in the loan currency")
+	} else {
+		ratio, _ := new(big.Rat).SetFrac64(loan.Amount.Amount, income.Amount).Float64()
+		add("income", thresholdPoints(scorecard.IncomeRatioRules, ratio), fmt.Sprintf("amount is %.1f times %s monthly income", ratio, source))
+	}
+
+	result.Band = scoreBand(scorecard.Bands, result.Score)
+	return result, nil
+}
+
+// monthlyIncome returns the income to weigh the loan against: the one
+// verified through KYC when it is in the loan currency, otherwise the one
+// declared with the application. The second value says which it is.
+func monthlyIncome(loan *models.Loan, user *models.User) (models.Money, string) {
+	if user != nil && user.KYCVerified() {
+		income := user.KYC.MonthlyIncome
+		if income.Amount > 0 && income.Currency == loan.Amount.Currency {
+			return income, "verified"
+		}
+	}
+	income := loan.DeclaredMonthlyIncome
+	if income.Amount > 0 && income.Currency == loan.Amount.Currency {
+		return income, "declared"
+	}
+	return models.Money{Currency: loan.Amount.Currency}, ""
+}
+
+// currentScorecard returns the latest saved scorecard, or the default one
+// while no admin has saved any.
+func currentScorecard(scorecardRepo repository_interface.IScorecardRepository) (*models.Scorecard, error) {
+	scorecard, err := scorecardRepo.GetLatestScorecard()
+	if err != nil {
+		return nil, err
+	}
+	if scorecard == nil {
+		return models.DefaultScorecard(), nil
+	}
+	return scorecard, nil
+}
+
+func thresholdPoints(rules []models.ThresholdRule, value float64) int {
+	if len(rules) == 0 {
+		return 0
+	}
+	for _, rule := range rules {
+		if value <= rule.UpTo {
+			return rule.Points
+		}
+	}
+	return rules[len(rules)-1].Points
+}
+
+func scoreBand(bands []models.ScoreBand, score int) string {
+	if len(bands) == 0 {
+		return ""
+	}
+	sorted := append([]models.ScoreBand(nil), bands...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].MinScore > sorted[j].MinScore })
+	for _, band := range sorted {
+		if score >= band.MinScore {
+			return band.Name
+		}
+	}
+	return sorted[len(sorted)-1].Name
+}
@@ -0,0 +1,209 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/models"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// decisionFields lists the attributes decision rules can test.
+var decisionFields = map[string]bool{
+	"loan.amount":         true,
+	"loan.term":           true,
+	"loan.currency":       true,
+	"loan.product_id":     true,
+	"loan.purpose":        true,
+	"loan.monthly_income": true,
+	"loan.income_ratio":   true,
+	"user.age":            true,
+	"user.verified":       true,
+	"user.kyc_status":     true,
+	"score.value":         true,
+	"score.band":          true,
+}
+
+// applicationAttributes flattens a loan, its applicant and its score into the
+// attributes decision rules test. Attributes that are unknown, such as the
+// income ratio without a declared income, are left out and fail every
+// condition on them.
+func applicationAttributes(loan *models.Loan, user *models.User) map[string]interface{} {
+	attrs := map[string]interface{}{
+		"loan.amount":     loan.Amount.Float64(),
+		"loan.term":       float64(loan.Term),
+		"loan.currency":   loan.Amount.Currency,
+		"loan.product_id": loan.ProductID.Hex(),
+		"loan.purpose":    loan.LoanPurpose,
+	}
+	if income, _ := monthlyIncome(loan, user); income.Amount > 0 {
+		attrs["loan.monthly_income"] = income.Float64()
+		attrs["loan.income_ratio"], _ = new(big.Rat).SetFrac64(loan.Amount.Amount, income.Amount).Float64()
+	}
+	if user != nil {
+		if age := user.AgeAt(time.Now()); age > 0 {
+			attrs["user.age"] = float64(age)
+		}
+		attrs["user.verified"] = user.IsVerified
+		attrs["user.kyc_status"] = user.KYCStatus()
+	}
+	if loan.CreditScore != nil {
+		attrs["score.value"] = float64(loan.CreditScore.Score)
+		attrs["score.band"] = loan.CreditScore.Band
+	}
+	return attrs
+}
+
+// evaluatePolicy runs every rule against the attributes. The first rule that
+// matches decides the outcome, but all matching rules are reported so their
+// hits can be logged.
+func evaluatePolicy(policy *models.DecisionPolicy, attrs map[string]interface{}) models.AutoDecision {
+	decision := models.AutoDecision{
+		Outcome:       policy.DefaultOutcome,
+		RulesHit:      []string{},
+		PolicyVersion: policy.Version,
+		DecidedAt:     time.Now(),
+	}
+	for _, rule := range policy.Rules {
+		if !ruleMatches(rule, attrs) {
+			continue
+		}
+		if len(decision.RulesHit) == 0 {
+			decision.Outcome = rule.Outcome
+			decision.Rule = rule.Name
+		}
+		decision.RulesHit = append(decision.RulesHit, rule.Name)
+	}
+	return decision
+}
+
+func ruleMatches(rule models.DecisionRule, attrs map[string]interface{}) bool {
+	for _, condition := range rule.Conditions {
+		value, ok := attrs[condition.Field]
+		if !ok || !conditionHolds(condition.Op, value, condition.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func conditionHolds(op string, actual interface{}, expected interface{}) bool {
+	if op == "in" {
+		for _, item := range toList(expected) {
+			if conditionHolds("eq", actual, item) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if a, ok := toFloat(actual); ok {
+		e, ok := toFloat(expected)
+		if !ok {
+			return false
+		}
+		switch op {
+		case "eq":
+			return a == e
+		case "ne":
+			return a != e
+		case "gt":
+			return a > e
+		case "gte":
+			return a >= e
+		case "lt":
+			return a < e
+		case "lte":
+			return a <= e
+		}
+		return false
+	}
+
+	switch op {
+	case "eq":
+		return fmt.Sprint(actual) == fmt.Sprint(expected)
+	case "ne":
+		return fmt.Sprint(actual) != fmt.Sprint(expected)
+	}
+	return false
+}
+
+// toList accepts a list decoded from JSON or from BSON.
+func toList(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case primitive.A:
+		return v
+	}
+	return nil
+}
+
+// toFloat accepts the numeric types a rule value may arrive as, from JSON or
+// from BSON.
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+func validateDecisionPolicy(policy *models.DecisionPolicy) error {
+	if policy.DefaultOutcome == "" {
+		policy.DefaultOutcome = models.DecisionRefer
+	}
+	if !validOutcome(policy.DefaultOutcome) {
+		return fmt.Errorf("unknown outcome %q", policy.DefaultOutcome)
+	}
+	names := map[string]bool{}
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+		if rule.Name == "" || names[rule.Name] {
+			return errors.New("decision rules need unique names")
+		}
+		names[rule.Name] = true
+		if !validOutcome(rule.Outcome) {
+			return fmt.Errorf("rule %q: unknown outcome %q", rule.Name, rule.Outcome)
+		}
+		if len(rule.Conditions) == 0 {
+			return fmt.Errorf("rule %q needs at least one condition", rule.Name)
+		}
+		for j := range rule.Conditions {
+			condition := &rule.Conditions[j]
+			if !decisionFields[condition.Field] {
+				return fmt.Errorf("rule %q: unknown field %q", rule.Name, condition.Field)
+			}
+			condition.Op = strings.ToLower(condition.Op)
+			switch condition.Op {
+			case "eq", "ne", "gt", "gte", "lt", "lte":
+			case "in":
+				if len(toList(condition.Value)) == 0 {
+					return fmt.Errorf("rule %q: in needs a list of values", rule.Name)
+				}
+			default:
+				return fmt.Errorf("rule %q: unknown operator %q", rule.Name, condition.Op)
+			}
+		}
+	}
+	return nil
+}
+
+func validOutcome(outcome string) bool {
+	switch outcome {
+	case models.DecisionApprove, models.DecisionReject, models.DecisionRefer:
+		return true
+	}
+	return false
+}
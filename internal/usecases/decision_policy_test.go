@@ -0,0 +1,127 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/models"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestConditionHolds(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       string
+		actual   interface{}
+		expected interface{}
+		want     bool
+	}{
+		{"numbers compare across decoded types", "eq", 650.0, int32(650), true},
+		{"gte at the bound", "gte", 650.0, int64(650), true},
+		{"gt at the bound", "gt", 650.0, 650, false},
+		{"lt", "lt", 0.35, 0.4, true},
+		{"lte above the bound", "lte", 0.45, 0.4, false},
+		{"ne on numbers", "ne", 12.0, 24, true},
+		{"number against a string", "eq", 650.0, "650", false},
+		{"strings", "eq", "A", "A", true},
+		{"ne on strings", "ne", "business", "personal", true},
+		{"booleans", "eq", true, true, true},
+		{"ordering does not apply to strings", "gt", "B", "A", false},
+		{"in a JSON list", "in", "B", []interface{}{"A", "B"}, true},
+		{"in a BSON list", "in", 24.0, primitive.A{int32(12), int32(24)}, true},
+		{"not in the list", "in", "C", []interface{}{"A", "B"}, false},
+		{"in without a list", "in", "A", "A", false},
+		{"unknown operator", "between", 1.0, 1.0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := conditionHolds(tt.op, tt.actual, tt.expected); got != tt.want {
+				t.Fatalf("conditionHolds(%q, %v, %v) = %v, want %v", tt.op, tt.actual, tt.expected, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluatePolicy(t *testing.T) {
+	policy := &models.DecisionPolicy{
+		Version:        3,
+		DefaultOutcome: models.DecisionRefer,
+		Rules: []models.DecisionRule{
+			{Name: "unverified", Outcome: models.DecisionReject, Conditions: []models.RuleCondition{
+				{Field: "user.verified", Op: "eq", Value: false},
+			}},
+			{Name: "low score", Outcome: models.DecisionReject, Conditions: []models.RuleCondition{
+				{Field: "score.value", Op: "lt", Value: 550},
+			}},
+			{Name: "prime", Outcome: models.DecisionApprove, Conditions: []models.RuleCondition{
+				{Field: "score.band", Op: "in", Value: []interface{}{"A", "B"}},
+				{Field: "loan.income_ratio", Op: "lte", Value: 3},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		attrs   map[string]interface{}
+		outcome string
+		rule    string
+		hits    []string
+	}{
+		{"first matching rule decides", map[string]interface{}{"user.verified": false, "score.value": 500.0}, models.DecisionReject, "unverified", []string{"unverified", "low score"}},
+		{"every condition must hold", map[string]interface{}{"user.verified": true, "score.value": 720.0, "score.band": "A", "loan.income_ratio": 2.5}, models.DecisionApprove, "prime", []string{"prime"}},
+		{"a missing attribute fails its condition", map[string]interface{}{"user.verified": true, "score.value": 720.0, "score.band": "A"}, models.DecisionRefer, "", []string{}},
+		{"no rule matches", map[string]interface{}{"user.verified": true, "score.value": 600.0, "score.band": "C", "loan.income_ratio": 1.0}, models.DecisionRefer, "", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := evaluatePolicy(policy, tt.attrs)
+			if decision.Outcome != tt.outcome || decision.Rule != tt.rule || !reflect.DeepEqual(decision.RulesHit, tt.hits) {
+				t.Fatalf("got %s by %q hitting %v, want %s by %q hitting %v", decision.Outcome, decision.Rule, decision.RulesHit, tt.outcome, tt.rule, tt.hits)
+			}
+			if decision.PolicyVersion != policy.Version {
+				t.Fatalf("policy version %d, want %d", decision.PolicyVersion, policy.Version)
+			}
+		})
+	}
+}
+
+func TestValidateDecisionPolicy(t *testing.T) {
+	condition := models.RuleCondition{Field: "score.value", Op: "GTE", Value: 650}
+	tests := []struct {
+		name    string
+		policy  models.DecisionPolicy
+		wantErr bool
+	}{
+		{"valid", models.DecisionPolicy{Rules: []models.DecisionRule{{Name: "r", Outcome: models.DecisionApprove, Conditions: []models.RuleCondition{condition}}}}, false},
+		{"unknown default outcome", models.DecisionPolicy{DefaultOutcome: "maybe"}, true},
+		{"unnamed rule", models.DecisionPolicy{Rules: []models.DecisionRule{{Outcome: models.DecisionApprove, Conditions: []models.RuleCondition{condition}}}}, true},
+		{"duplicate names", models.DecisionPolicy{Rules: []models.DecisionRule{
+			{Name: "r", Outcome: models.DecisionApprove, Conditions: []models.RuleCondition{condition}},
+			{Name: "r", Outcome: models.DecisionReject, Conditions: []models.RuleCondition{condition}},
+		}}, true},
+		{"rule without conditions", models.DecisionPolicy{Rules: []models.DecisionRule{{Name: "r", Outcome: models.DecisionApprove}}}, true},
+		{"unknown field", models.DecisionPolicy{Rules: []models.DecisionRule{{Name: "r", Outcome: models.DecisionApprove, Conditions: []models.RuleCondition{{Field: "user.name", Op: "eq", Value: "x"}}}}}, true},
+		{"unknown operator", models.DecisionPolicy{Rules: []models.DecisionRule{{Name: "r", Outcome: models.DecisionApprove, Conditions: []models.RuleCondition{{Field: "score.value", Op: "like", Value: 1}}}}}, true},
+		{"in without a list", models.DecisionPolicy{Rules: []models.DecisionRule{{Name: "r", Outcome: models.DecisionApprove, Conditions: []models.RuleCondition{{Field: "score.band", Op: "in", Value: "A"}}}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDecisionPolicy(&tt.policy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if tt.policy.DefaultOutcome != models.DecisionRefer {
+				t.Fatalf("default outcome %q, want refer", tt.policy.DefaultOutcome)
+			}
+			if op := tt.policy.Rules[0].Conditions[0].Op; op != "gte" {
+				t.Fatalf("operator %q not normalised", op)
+			}
+		})
+	}
+}
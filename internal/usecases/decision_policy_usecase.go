@@ -0,0 +1,120 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type IDecisionPolicyUsecase interface {
+	GetPolicy() (*models.DecisionPolicy, error)
+	UpdatePolicy(adminID string, policy *models.DecisionPolicy) (*models.DecisionPolicy, error)
+	DryRun(policy *models.DecisionPolicy, query models.ListQuery) (*dtos.DecisionDryRunDTO, error)
+}
+
+type DecisionPolicyUsecase struct {
+	policyRepo repository_interface.IDecisionPolicyRepository
+	loanRepo   repository_interface.ILoanRepository
+	userRepo   repository_interface.IUserRepository
+	logRepo    repository_interface.ILogRepository
+	scorer     Scorer
+}
+
+func NewDecisionPolicyUsecase(policyRepo repository_interface.IDecisionPolicyRepository, loanRepo repository_interface.ILoanRepository, userRepo repository_interface.IUserRepository, logRepo repository_interface.ILogRepository, scorer Scorer) IDecisionPolicyUsecase {
+	return &DecisionPolicyUsecase{
+		policyRepo: policyRepo,
+		loanRepo:   loanRepo,
+		userRepo:   userRepo,
+		logRepo:    logRepo,
+		scorer:     scorer,
+	}
+}
+
+// GetPolicy returns the policy in force, or a disabled empty one when none
+// has been saved.
+func (du *DecisionPolicyUsecase) GetPolicy() (*models.DecisionPolicy, error) {
+	policy, err := du.policyRepo.GetLatestPolicy()
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return &models.DecisionPolicy{Rules: []models.DecisionRule{}, DefaultOutcome: models.DecisionRefer}, nil
+	}
+	return policy, nil
+}
+
+// UpdatePolicy saves the policy as a new version; earlier versions are kept
+// so the decision recorded on a loan can be traced to the rules that made it.
+func (du *DecisionPolicyUsecase) UpdatePolicy(adminID string, policy *models.DecisionPolicy) (*models.DecisionPolicy, error) {
+	if err := validateDecisionPolicy(policy); err != nil {
+		return nil, err
+	}
+	current, err := du.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	policy.ID = primitive.NilObjectID
+	policy.Version = current.Version + 1
+	policy.UpdatedBy = adminID
+	policy.CreatedAt = time.Now()
+	result, err := du.policyRepo.CreatePolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, _ := primitive.ObjectIDFromHex(adminID)
+	du.logRepo.CreateLog(&models.SystemLog{
+		Action:    fmt.Sprintf("Decision policy updated to version %d (enabled: %t)", result.Version, result.Enabled),
+		Timestamp: result.CreatedAt,
+		UserID:    userID,
+	})
+	return result, nil
+}
+
+// DryRun evaluates a draft policy against one page of historical loans
+// without changing anything. Loans scored before are judged on their stored
+// score, others are scored on the fly.
+func (du *DecisionPolicyUsecase) DryRun(policy *models.DecisionPolicy, query models.ListQuery) (*dtos.DecisionDryRunDTO, error) {
+	if err := validateDecisionPolicy(policy); err != nil {
+		return nil, err
+	}
+	loans, nextCursor, err := du.loanRepo.ListLoans(query)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dtos.DecisionDryRunDTO{
+		Outcomes:   map[string]int{},
+		Loans:      make([]dtos.DecisionDryRunLoanDTO, 0, len(loans)),
+		NextCursor: nextCursor,
+	}
+	users := map[primitive.ObjectID]*models.User{}
+	for i := range loans {
+		loan := &loans[i]
+		user, seen := users[loan.UserId]
+		if !seen {
+			user, _ = du.userRepo.GetUserByID(loan.UserId.Hex())
+			users[loan.UserId] = user
+		}
+		if loan.CreditScore == nil && user != nil {
+			loan.CreditScore, _ = du.scorer.Score(loan)
+		}
+
+		decision := evaluatePolicy(policy, applicationAttributes(loan, user))
+		result.Evaluated++
+		result.Outcomes[decision.Outcome]++
+		result.Loans = append(result.Loans, dtos.DecisionDryRunLoanDTO{
+			LoanID:   loan.ID.Hex(),
+			Status:   loan.Status,
+			Outcome:  decision.Outcome,
+			Rule:     decision.Rule,
+			RulesHit: decision.RulesHit,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,236 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// servicedStatuses are the statuses of loans with money out whose schedule
+// is watched for missed payments.
+var servicedStatuses = []string{models.LoanStatusDisbursed, models.LoanStatusActive, models.LoanStatusDefaulted}
+
+type IDelinquencyUsecase interface {
+	RunDelinquency(asOf time.Time) error
+	GetDelinquentLoans(bucket string) (*dtos.DelinquencyReportDTO, error)
+}
+
+type DelinquencyUsecase struct {
+	loanRepo     repository_interface.ILoanRepository
+	scheduleRepo repository_interface.IScheduleRepository
+	productRepo  repository_interface.IProductRepository
+	ledgerRepo   repository_interface.ILedgerRepository
+	userRepo     repository_interface.IUserRepository
+	logRepo      repository_interface.ILogRepository
+}
+
+func NewDelinquencyUsecase(loanRepo repository_interface.ILoanRepository, scheduleRepo repository_interface.IScheduleRepository, productRepo repository_interface.IProductRepository, ledgerRepo repository_interface.ILedgerRepository, userRepo repository_interface.IUserRepository, logRepo repository_interface.ILogRepository) IDelinquencyUsecase {
+	return &DelinquencyUsecase{
+		loanRepo:     loanRepo,
+		scheduleRepo: scheduleRepo,
+		productRepo:  productRepo,
+		ledgerRepo:   ledgerRepo,
+		userRepo:     userRepo,
+		logRepo:      logRepo,
+	}
+}
+
+// RunDelinquency is the daily check of every serviced loan as of the given
+// day. It records the loan's days past due and bucket, charges the late fees
+// its product sets on installments past their grace period and defaults loans
+// past the product's threshold. Running it again on the same day changes
+// nothing, since each installment is charged a late fee only once. It carries
+// on past loans it fails on and reports them at the end.
+func (du *DelinquencyUsecase) RunDelinquency(asOf time.Time) error {
+	loans, err := du.loanRepo.GetLoansByStatus(servicedStatuses)
+	if err != nil {
+		return err
+	}
+	products := map[string]*models.LoanProduct{}
+	var failed []string
+	for i := range loans {
+		if err := du.checkLoan(&loans[i], products, asOf); err != nil {
+			failed = append(failed, loans[i].ID.Hex()+": "+err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("delinquency check failed for %d loans: %s", len(failed), strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// GetDelinquentLoans lists the serviced loans that were past due at the last
+// check, optionally only those in one bucket.
+func (du *DelinquencyUsecase) GetDelinquentLoans(bucket string) (*dtos.DelinquencyReportDTO, error) {
+	if bucket != "" && (bucket == models.BucketCurrent || !containsString(models.DelinquencyBuckets, bucket)) {
+		return nil, fmt.Errorf("%w: bucket must be one of 1-30, 31-60, 61-90 or 90+", models.ErrInvalidQuery)
+	}
+	loans, err := du.loanRepo.GetDelinquentLoans(bucket, servicedStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dtos.DelinquencyReportDTO{
+		Buckets: map[string]int{},
+		Loans:   make([]dtos.DelinquentLoanDTO, 0, len(loans)),
+	}
+	for _, name := range models.DelinquencyBuckets[1:] {
+		report.Buckets[name] = 0
+	}
+	for i := range loans {
+		loan := &loans[i]
+		report.Buckets[loan.Delinquency.Bucket]++
+		item := dtos.DelinquentLoanDTO{Loan: loan}
+		if user, err := du.userRepo.GetUserByID(loan.UserId.Hex()); err == nil {
+			item.BorrowerName = user.Name
+			item.BorrowerEmail = user.Email
+			item.BorrowerPhone = user.PhoneNum
+		}
+		report.Loans = append(report.Loans, item)
+	}
+	return report, nil
+}
+
+func (du *DelinquencyUsecase) checkLoan(loan *models.Loan, products map[string]*models.LoanProduct, asOf time.Time) error {
+	schedule, err := du.scheduleRepo.GetScheduleByLoanID(loan.ID.Hex())
+	if errors.Is(err, models.ErrScheduleNotFound) {
+		// nothing has fallen due on a loan without a schedule
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	product, err := du.product(loan, products)
+	if err != nil {
+		return err
+	}
+
+	delinquency := &models.Delinquency{
+		OverdueAmount: models.NewMoney(0, loan.Amount.Currency),
+		AsOf:          asOf,
+	}
+	var charged []int
+	for i := range schedule.Installments {
+		inst := &schedule.Installments[i]
+		daysLate := models.DaysBetween(inst.DueDate, asOf)
+		if inst.Paid || daysLate <= 0 {
+			continue
+		}
+		overdue := inst.Overdue()
+		delinquency.OverdueAmount = delinquency.OverdueAmount.Add(overdue)
+		if delinquency.OldestDueDate == nil {
+			dueDate := inst.DueDate
+			delinquency.OldestDueDate = &dueDate
+			delinquency.DaysPastDue = daysLate
+		}
+		if product != nil && product.LateFee != nil && inst.LateFee == nil && daysLate > product.LateFee.GraceDays {
+			fee := product.LateFee.Fee(overdue)
+			inst.LateFee = &fee
+			charged = append(charged, i)
+		}
+	}
+	delinquency.Bucket = models.DelinquencyBucket(delinquency.DaysPastDue)
+
+	if len(charged) > 0 {
+		if err := du.chargeLateFees(loan, schedule, charged); err != nil {
+			return err
+		}
+	}
+	if err := du.loanRepo.UpdateDelinquency(loan.ID.Hex(), delinquency); err != nil {
+		return err
+	}
+	loan.Delinquency = delinquency
+
+	threshold := models.DefaultAfterDays
+	if product != nil && product.DefaultAfterDays > 0 {
+		threshold = product.DefaultAfterDays
+	}
+	if delinquency.DaysPastDue >= threshold && loan.Status != models.LoanStatusDefaulted {
+		reason := fmt.Sprintf("%d days past due, default threshold is %d", delinquency.DaysPastDue, threshold)
+		return transitionLoan(du.loanRepo, du.logRepo, loan, models.LoanStatusDefaulted, systemActor, reason)
+	}
+	return nil
+}
+
+// chargeLateFees adds the late fees set on the given installments to what the
+// loan owes and books them, then records on the installments that they were
+// charged. Each fee is added to the balances and posted to the ledger at most
+// once, so a run that fails part way through is completed by the next one
+// without charging anything twice.
+func (du *DelinquencyUsecase) chargeLateFees(loan *models.Loan, schedule *models.RepaymentSchedule, charged []int) error {
+	entries, err := du.ledgerRepo.GetEntriesByLoanID(loan.ID.Hex())
+	if err != nil {
+		return err
+	}
+	posted := map[string]bool{}
+	for _, entry := range entries {
+		posted[entry.Description] = true
+	}
+	version := schedule.Version
+	if version == 0 {
+		version = 1
+	}
+
+	for _, i := range charged {
+		inst := &schedule.Installments[i]
+		fee := *inst.LateFee
+		key := fmt.Sprintf("v%d:%d", version, inst.Number)
+
+		previousBalance := loan.OutstandingBalance
+		loan.OutstandingFees = loan.OutstandingFees.Add(fee)
+		loan.OutstandingBalance = loan.OutstandingBalance.Add(fee)
+		applied, err := du.loanRepo.ChargeLateFee(loan, previousBalance, key)
+		if err != nil {
+			return err
+		}
+		if !applied {
+			// a previous run added it, the balances loaded already include it
+			loan.OutstandingFees = loan.OutstandingFees.Sub(fee)
+			loan.OutstandingBalance = previousBalance
+		}
+
+		description := fmt.Sprintf("Late fee on installment %d of schedule version %d", inst.Number, version)
+		if !posted[description] {
+			transaction, err := newLedgerTransaction(loan.ID, description,
+				debit(models.AccountFeesReceivable, fee),
+				credit(models.AccountLateFeeIncome, fee),
+			)
+			if err != nil {
+				return err
+			}
+			if err := du.ledgerRepo.CreateEntries(transaction); err != nil {
+				return err
+			}
+		}
+		if applied {
+			du.logRepo.CreateLog(&models.SystemLog{
+				Action:    fmt.Sprintf("Late fee of %s charged on installment %d", fee, inst.Number),
+				Timestamp: time.Now(),
+				LoanID:    loan.ID.Hex(),
+			})
+		}
+	}
+	return du.scheduleRepo.UpdateInstallments(schedule.ID.Hex(), schedule.Installments)
+}
+
+// product returns the loan's product, loading each product once per run.
+// Loans without a product have no late fees and the default threshold.
+func (du *DelinquencyUsecase) product(loan *models.Loan, products map[string]*models.LoanProduct) (*models.LoanProduct, error) {
+	if loan.ProductID.IsZero() {
+		return nil, nil
+	}
+	id := loan.ProductID.Hex()
+	if product, ok := products[id]; ok {
+		return product, nil
+	}
+	product, err := du.productRepo.GetProductByID(id)
+	if err != nil {
+		return nil, err
+	}
+	products[id] = product
+	return product, nil
+}
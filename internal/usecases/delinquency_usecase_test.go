@@ -0,0 +1,188 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/models"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type delinquencyFixture struct {
+	usecase   *DelinquencyUsecase
+	loan      *models.Loan
+	loans     *memoryLoanRepository
+	schedules *memoryScheduleRepository
+	ledger    *memoryLedgerRepository
+}
+
+// newDelinquencyFixture sets up an active loan of 10,000.00 at 12% over 12
+// months from January 1 2026 with nothing repaid, on product when it is not
+// nil. Every installment is 888.49 and the first falls due on February 1.
+func newDelinquencyFixture(t *testing.T, product *models.LoanProduct) *delinquencyFixture {
+	t.Helper()
+	_, loan, schedule := bookedLoan(t, usd(1000000), 0.12, 12, day(2026, 1, 1), 0, nil)
+	loan.Amount = usd(1000000)
+	loan.Status = models.LoanStatusActive
+	schedule.LoanID = loan.ID
+
+	f := &delinquencyFixture{
+		loan:      loan,
+		schedules: &memoryScheduleRepository{},
+		ledger:    &memoryLedgerRepository{},
+	}
+	f.usecase = &DelinquencyUsecase{
+		scheduleRepo: f.schedules,
+		ledgerRepo:   f.ledger,
+		logRepo:      &memoryLogRepository{},
+	}
+	if product != nil {
+		loan.ProductID = primitive.NewObjectID()
+		f.usecase.productRepo = &memoryProductRepository{product: product}
+	}
+	f.loans = newMemoryLoanRepository(loan)
+	f.usecase.loanRepo = f.loans
+	f.schedules.CreateSchedule(schedule)
+	return f
+}
+
+// lateFeeProduct charges 15.00 plus 5% of the overdue installment once it is
+// more than 5 days late.
+func lateFeeProduct(defaultAfterDays int) *models.LoanProduct {
+	return &models.LoanProduct{
+		LateFee:          &models.LateFeePolicy{GraceDays: 5, FlatFee: usd(1500), Rate: 0.05},
+		DefaultAfterDays: defaultAfterDays,
+	}
+}
+
+func (f *delinquencyFixture) check(t *testing.T, asOf time.Time) *models.Loan {
+	t.Helper()
+	if err := f.usecase.RunDelinquency(asOf); err != nil {
+		t.Fatal(err)
+	}
+	loan, _ := f.loans.GetLoanByID(f.loan.ID.Hex())
+	return loan
+}
+
+// assertLateFees checks the first charged installments were charged a late
+// fee, each added to the balances and booked exactly once.
+func (f *delinquencyFixture) assertLateFees(t *testing.T, loan *models.Loan, charged int) {
+	t.Helper()
+	schedule, _ := f.schedules.GetScheduleByLoanID(f.loan.ID.Hex())
+	fees := usd(0)
+	for i, inst := range schedule.Installments {
+		if (inst.LateFee != nil) != (i < charged) {
+			t.Fatalf("installment %d charged %v, want %v", inst.Number, inst.LateFee, i < charged)
+		}
+		if inst.LateFee == nil {
+			continue
+		}
+		if want := lateFeeProduct(0).LateFee.Fee(inst.Overdue()); inst.LateFee.Cmp(want) != 0 {
+			t.Fatalf("installment %d charged %s, want %s", inst.Number, inst.LateFee, want)
+		}
+		fees = fees.Add(*inst.LateFee)
+		description := fmt.Sprintf("Late fee on installment %d of schedule version %d", inst.Number, schedule.Version)
+		if n := f.ledger.transactions(description); n != 1 {
+			t.Fatalf("installment %d late fee booked %d times, want once", inst.Number, n)
+		}
+	}
+	if loan.OutstandingFees.Cmp(fees) != 0 || loan.OutstandingBalance.Cmp(f.loan.OutstandingBalance.Add(fees)) != 0 {
+		t.Fatalf("outstanding fees %s and balance %s, want %s more than %s", loan.OutstandingFees, loan.OutstandingBalance, fees, f.loan.OutstandingBalance)
+	}
+	if got := -f.ledger.balance(models.AccountLateFeeIncome); got != fees.Amount {
+		t.Fatalf("late fee income %d, want %d", got, fees.Amount)
+	}
+}
+
+func TestCheckLoan(t *testing.T) {
+	tests := []struct {
+		name    string
+		asOf    time.Time
+		product *models.LoanProduct
+		days    int
+		bucket  string
+		charged int
+		status  string
+	}{
+		{"nothing due yet", day(2026, 2, 1), lateFeeProduct(0), 0, models.BucketCurrent, 0, models.LoanStatusActive},
+		{"within the grace period", day(2026, 2, 4), lateFeeProduct(0), 3, models.Bucket1To30, 0, models.LoanStatusActive},
+		{"late fee after the grace period", day(2026, 2, 10), lateFeeProduct(0), 9, models.Bucket1To30, 1, models.LoanStatusActive},
+		{"every installment past its grace period", day(2026, 3, 15), lateFeeProduct(0), 42, models.Bucket31To60, 2, models.LoanStatusActive},
+		{"no late fee without a product", day(2026, 4, 20), nil, 78, models.Bucket61To90, 0, models.LoanStatusActive},
+		{"a day short of the default threshold", day(2026, 5, 1), nil, 89, models.Bucket61To90, 0, models.LoanStatusActive},
+		{"defaults after 90 days by default", day(2026, 5, 2), nil, 90, models.Bucket61To90, 0, models.LoanStatusDefaulted},
+		{"the product's own threshold", day(2026, 4, 2), lateFeeProduct(60), 60, models.Bucket31To60, 2, models.LoanStatusDefaulted},
+		{"over 90 days", day(2026, 6, 1), nil, 120, models.Bucket90Plus, 0, models.LoanStatusDefaulted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newDelinquencyFixture(t, tt.product)
+			loan := f.check(t, tt.asOf)
+
+			delinquency := loan.Delinquency
+			if delinquency == nil || delinquency.DaysPastDue != tt.days || delinquency.Bucket != tt.bucket {
+				t.Fatalf("delinquency %+v, want %d days in %s", delinquency, tt.days, tt.bucket)
+			}
+			if loan.Status != tt.status {
+				t.Fatalf("loan is %s, want %s", loan.Status, tt.status)
+			}
+			f.assertLateFees(t, loan, tt.charged)
+		})
+	}
+}
+
+func TestCheckLoanChargesLateFeesOnce(t *testing.T) {
+	tests := []struct {
+		name string
+		// the first run fails to book the fee
+		ledgerFails bool
+	}{
+		{"run again", false},
+		{"run again after failing part way", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newDelinquencyFixture(t, lateFeeProduct(0))
+			if tt.ledgerFails {
+				f.ledger.failNext = errors.New("ledger unavailable")
+				if err := f.usecase.RunDelinquency(day(2026, 2, 10)); err == nil {
+					t.Fatal("ledger failure not reported")
+				}
+			}
+			f.check(t, day(2026, 2, 10))
+			f.check(t, day(2026, 2, 10))
+			loan := f.check(t, day(2026, 2, 20))
+			f.assertLateFees(t, loan, 1)
+		})
+	}
+}
+
+func TestCheckLoanScheduleLookup(t *testing.T) {
+	lookupFailed := errors.New("connection reset")
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"loan without a schedule is skipped", models.ErrScheduleNotFound, nil},
+		{"lookup failure is reported", lookupFailed, lookupFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newDelinquencyFixture(t, nil)
+			f.schedules.failNext = tt.err
+			err := f.usecase.checkLoan(f.loan, map[string]*models.LoanProduct{}, day(2026, 6, 1))
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if loan, _ := f.loans.GetLoanByID(f.loan.ID.Hex()); loan.Delinquency != nil || loan.Status != models.LoanStatusActive {
+				t.Fatalf("loan changed: %+v, %s", loan.Delinquency, loan.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,343 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/infrastructures/services"
+	"LoanGuard/internal/repository/interfaces"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const maxIdempotencyKeyLength = 255
+
+type IDisbursementUsecase interface {
+	DisburseLoan(loanID string, adminID string, idempotencyKey string, req dtos.DisbursementDTO) (*models.Disbursement, bool, error)
+	GetDisbursements(loanID string) ([]models.Disbursement, error)
+	RetryDisbursement(disbursementID string, adminID string) (*models.Disbursement, error)
+	RetryDueDisbursements() error
+}
+
+type DisbursementUsecase struct {
+	disbursementRepo repository_interface.IDisbursementRepository
+	loanRepo         repository_interface.ILoanRepository
+	scheduleRepo     repository_interface.IScheduleRepository
+	ledgerRepo       repository_interface.ILedgerRepository
+	logRepo          repository_interface.ILogRepository
+	scheduleSvc      services.IScheduleService
+	provider         services.IPayoutProvider
+}
+
+func NewDisbursementUsecase(disbursementRepo repository_interface.IDisbursementRepository, loanRepo repository_interface.ILoanRepository, scheduleRepo repository_interface.IScheduleRepository, ledgerRepo repository_interface.ILedgerRepository, logRepo repository_interface.ILogRepository, scheduleSvc services.IScheduleService, provider services.IPayoutProvider) IDisbursementUsecase {
+	return &DisbursementUsecase{
+		disbursementRepo: disbursementRepo,
+		loanRepo:         loanRepo,
+		scheduleRepo:     scheduleRepo,
+		ledgerRepo:       ledgerRepo,
+		logRepo:          logRepo,
+		scheduleSvc:      scheduleSvc,
+		provider:         provider,
+	}
+}
+
+// DisburseLoan pays an approved loan out and makes the first payout
+// attempt. A request repeated with the same idempotency key returns the
+// disbursement it created instead of paying again; the boolean is true only
+// when this call created it. A failed attempt leaves the disbursement pending
+// for RetryDueDisbursements; it is not due a retry before then, so the retry
+// job cannot race this first attempt.
+func (du *DisbursementUsecase) DisburseLoan(loanID string, adminID string, idempotencyKey string, req dtos.DisbursementDTO) (*models.Disbursement, bool, error) {
+	idempotencyKey = strings.TrimSpace(idempotencyKey)
+	if idempotencyKey == "" || len(idempotencyKey) > maxIdempotencyKeyLength {
+		return nil, false, fmt.Errorf("%w: an Idempotency-Key header of at most %d characters is required", models.ErrInvalidDisbursement, maxIdempotencyKeyLength)
+	}
+	if existing, err := du.replay(loanID, idempotencyKey); existing != nil || err != nil {
+		return existing, false, err
+	}
+
+	loan, err := du.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, false, models.ErrLoanNotFound
+	}
+	if loan.Status != models.LoanStatusApproved {
+		return nil, false, fmt.Errorf("%w: only approved loans can be disbursed, this one is %s", models.ErrInvalidTransition, loan.Status)
+	}
+	destination, err := validateDestination(req.Destination)
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := time.Now()
+	disbursement := &models.Disbursement{
+		LoanID:         loan.ID,
+		IdempotencyKey: idempotencyKey,
+		Amount:         loan.Amount,
+		Destination:    destination,
+		Provider:       du.provider.Name(),
+		Status:         models.DisbursementStatusPending,
+		Active:         true,
+		Attempts:       []models.PayoutAttempt{},
+		RequestedBy:    adminID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if _, err := du.disbursementRepo.CreateDisbursement(disbursement); err != nil {
+		if errors.Is(err, models.ErrDisbursementExists) {
+			// lost a race against the same key, or the loan is already paid out
+			if existing, replayErr := du.replay(loanID, idempotencyKey); existing != nil || replayErr != nil {
+				return existing, false, replayErr
+			}
+		}
+		return nil, false, err
+	}
+	du.logDisbursement(adminID, loanID, "Disbursement of "+disbursement.Amount.String()+" requested")
+
+	if err := du.attempt(disbursement, loan); err != nil {
+		return nil, false, err
+	}
+	return disbursement, true, nil
+}
+
+// GetDisbursements lists every disbursement requested for the loan.
+func (du *DisbursementUsecase) GetDisbursements(loanID string) ([]models.Disbursement, error) {
+	return du.disbursementRepo.GetDisbursementsByLoan(loanID)
+}
+
+// RetryDisbursement makes another payout attempt right away. A failed
+// disbursement gets a fresh set of attempts as long as the loan has not been
+// paid out some other way meanwhile. A succeeded disbursement whose loan
+// could not be booked is booked again instead.
+func (du *DisbursementUsecase) RetryDisbursement(disbursementID string, adminID string) (*models.Disbursement, error) {
+	disbursement, err := du.disbursementRepo.GetDisbursement(disbursementID)
+	if err != nil {
+		return nil, models.ErrDisbursementNotFound
+	}
+	if disbursement.Status == models.DisbursementStatusSucceeded && disbursement.BookedAt != nil {
+		return nil, fmt.Errorf("%w: the disbursement already succeeded", models.ErrInvalidDisbursement)
+	}
+	loan, err := du.loanRepo.GetLoanByID(disbursement.LoanID.Hex())
+	if err != nil {
+		return nil, models.ErrLoanNotFound
+	}
+	if disbursement.Status == models.DisbursementStatusSucceeded {
+		du.logDisbursement(adminID, loan.ID.Hex(), "Booking of disbursement "+disbursementID+" retried")
+		if err := du.bookDisbursedLoan(loan, disbursement); err != nil {
+			return nil, err
+		}
+		return disbursement, nil
+	}
+	if loan.Status != models.LoanStatusApproved {
+		return nil, fmt.Errorf("%w: the loan is %s", models.ErrInvalidTransition, loan.Status)
+	}
+
+	if disbursement.Status == models.DisbursementStatusFailed {
+		if active, err := du.disbursementRepo.GetActiveDisbursement(loan.ID.Hex()); err == nil && active.ID != disbursement.ID {
+			return nil, models.ErrDisbursementExists
+		}
+		disbursement.Status = models.DisbursementStatusPending
+		disbursement.Active = true
+		disbursement.Attempts = []models.PayoutAttempt{}
+		if err := du.disbursementRepo.UpdateDisbursement(disbursement); err != nil {
+			return nil, err
+		}
+	}
+	du.logDisbursement(adminID, loan.ID.Hex(), "Disbursement "+disbursementID+" retried")
+	if err := du.attempt(disbursement, loan); err != nil {
+		return nil, err
+	}
+	return disbursement, nil
+}
+
+// RetryDueDisbursements makes the next payout attempt on every pending
+// disbursement that is due one and books the loans of succeeded
+// disbursements whose booking failed. It is run periodically and carries on
+// past individual failures.
+func (du *DisbursementUsecase) RetryDueDisbursements() error {
+	due, err := du.disbursementRepo.GetDueDisbursements(time.Now())
+	if err != nil {
+		return err
+	}
+	unbooked, err := du.disbursementRepo.GetUnbookedDisbursements()
+	if err != nil {
+		return err
+	}
+	var failed []string
+	for i := range due {
+		disbursement := &due[i]
+		loan, err := du.loanRepo.GetLoanByID(disbursement.LoanID.Hex())
+		if err == nil {
+			err = du.attempt(disbursement, loan)
+		}
+		if err != nil {
+			failed = append(failed, disbursement.ID.Hex()+": "+err.Error())
+		}
+	}
+	for i := range unbooked {
+		disbursement := &unbooked[i]
+		loan, err := du.loanRepo.GetLoanByID(disbursement.LoanID.Hex())
+		if err == nil {
+			err = du.bookDisbursedLoan(loan, disbursement)
+		}
+		if err != nil {
+			failed = append(failed, disbursement.ID.Hex()+": "+err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("disbursement retries failed: %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// attempt calls the payout provider once and records the outcome. Once the
+// money has moved the loan is marked disbursed and booked. Provider failures
+// are recorded on the disbursement rather than returned; only storage
+// errors are. Loans no longer approved, e.g. cancelled meanwhile, are not
+// paid out and their disbursement fails.
+func (du *DisbursementUsecase) attempt(disbursement *models.Disbursement, loan *models.Loan) error {
+	now := time.Now()
+	var reference string
+	var payoutErr error
+	payable := loan.Status == models.LoanStatusApproved
+	if payable {
+		reference, payoutErr = du.provider.Payout(services.PayoutRequest{
+			Reference:   disbursement.IdempotencyKey,
+			LoanID:      loan.ID.Hex(),
+			Amount:      disbursement.Amount,
+			Destination: disbursement.Destination,
+		})
+	} else {
+		payoutErr = fmt.Errorf("the loan is %s", loan.Status)
+	}
+
+	attempt := models.PayoutAttempt{At: now, ProviderReference: reference}
+	disbursement.UpdatedAt = now
+	switch {
+	case payoutErr == nil:
+		disbursement.Status = models.DisbursementStatusSucceeded
+		disbursement.ProviderReference = reference
+		disbursement.NextAttemptAt = nil
+		disbursement.DisbursedAt = &now
+	case !payable || len(disbursement.Attempts)+1 >= models.MaxPayoutAttempts:
+		attempt.Error = payoutErr.Error()
+		disbursement.Status = models.DisbursementStatusFailed
+		disbursement.Active = false
+		disbursement.NextAttemptAt = nil
+	default:
+		attempt.Error = payoutErr.Error()
+		next := now.Add(models.PayoutRetryDelay(len(disbursement.Attempts) + 1))
+		disbursement.NextAttemptAt = &next
+	}
+	disbursement.Attempts = append(disbursement.Attempts, attempt)
+	if err := du.disbursementRepo.UpdateDisbursement(disbursement); err != nil {
+		return err
+	}
+
+	if payoutErr != nil {
+		du.logDisbursement(systemActor, loan.ID.Hex(), fmt.Sprintf("Payout attempt %d via %s failed: %s", len(disbursement.Attempts), disbursement.Provider, payoutErr))
+		return nil
+	}
+	du.logDisbursement(systemActor, loan.ID.Hex(), "Loan paid out via "+disbursement.Provider+", reference "+reference)
+	return du.bookDisbursedLoan(loan, disbursement)
+}
+
+// bookDisbursedLoan marks the loan disbursed, generates its repayment
+// schedule from the day the money moved, opens the loan balances and posts
+// the principal, scheduled interest and origination fee receivables to the
+// ledger. The scheduled interest is unearned until it accrues. Each step is
+// skipped when an earlier booking that failed part way already did it, so a
+// failed booking is retried until the disbursement is marked booked.
+func (du *DisbursementUsecase) bookDisbursedLoan(loan *models.Loan, disbursement *models.Disbursement) error {
+	if loan.Status == models.LoanStatusApproved {
+		reason := "paid out via " + disbursement.Provider + ", reference " + disbursement.ProviderReference
+		if err := transitionLoan(du.loanRepo, du.logRepo, loan, models.LoanStatusDisbursed, systemActor, reason); err != nil {
+			return err
+		}
+	}
+
+	schedules, err := du.scheduleRepo.GetSchedulesByLoanID(loan.ID.Hex())
+	if err != nil {
+		return err
+	}
+	var schedule *models.RepaymentSchedule
+	if len(schedules) > 0 {
+		schedule = &schedules[0]
+	} else {
+		schedule, err = du.scheduleSvc.GenerateSchedule(loan.Amount, loan.Interest, loan.Term, loan.RepaymentMethod, *disbursement.DisbursedAt)
+		if err != nil {
+			return err
+		}
+		schedule.LoanID = loan.ID
+		schedule.Version = 1
+		if _, err := du.scheduleRepo.CreateSchedule(schedule); err != nil {
+			return err
+		}
+	}
+
+	if loan.Status == models.LoanStatusDisbursed && loan.OutstandingBalance.IsZero() {
+		previousBalance := loan.OutstandingBalance
+		loan.OutstandingPrincipal = loan.Amount
+		loan.OutstandingInterest = schedule.TotalInterest
+		loan.OutstandingFees = loan.OriginationFee
+		loan.OutstandingBalance = loan.OutstandingPrincipal.Add(loan.OutstandingInterest).Add(loan.OutstandingFees)
+		if err := du.loanRepo.UpdateLoanBalances(loan, previousBalance); err != nil {
+			return err
+		}
+	}
+
+	entries, err := newKeyedLedgerTransaction(loan.ID, "booking:"+disbursement.ID.Hex(), "Loan booked on disbursement "+disbursement.ProviderReference,
+		debit(models.AccountPrincipalReceivable, loan.Amount),
+		credit(models.AccountCash, loan.Amount),
+		debit(models.AccountInterestReceivable, schedule.TotalInterest),
+		credit(models.AccountUnearnedInterest, schedule.TotalInterest),
+		debit(models.AccountFeesReceivable, loan.OriginationFee),
+		credit(models.AccountFeeIncome, loan.OriginationFee),
+	)
+	if err != nil {
+		return err
+	}
+	if err := du.ledgerRepo.CreateEntries(entries); err != nil && !errors.Is(err, models.ErrDuplicateLedgerEntry) {
+		return err
+	}
+
+	now := time.Now()
+	disbursement.BookedAt = &now
+	disbursement.UpdatedAt = now
+	return du.disbursementRepo.UpdateDisbursement(disbursement)
+}
+
+// replay returns the disbursement an earlier request with the same key
+// created, or ErrIdempotencyConflict when that request was for another loan.
+func (du *DisbursementUsecase) replay(loanID string, idempotencyKey string) (*models.Disbursement, error) {
+	existing, err := du.disbursementRepo.GetDisbursementByKey(idempotencyKey)
+	if err != nil {
+		return nil, nil
+	}
+	if existing.LoanID.Hex() != loanID {
+		return nil, models.ErrIdempotencyConflict
+	}
+	return existing, nil
+}
+
+func (du *DisbursementUsecase) logDisbursement(actorID string, loanID string, action string) {
+	userID, _ := primitive.ObjectIDFromHex(actorID)
+	du.logRepo.CreateLog(&models.SystemLog{
+		Action:    action,
+		Timestamp: time.Now(),
+		UserID:    userID,
+		LoanID:    loanID,
+	})
+}
+
+func validateDestination(destination models.PayoutDestination) (models.PayoutDestination, error) {
+	destination.AccountName = strings.TrimSpace(destination.AccountName)
+	destination.AccountNumber = strings.TrimSpace(destination.AccountNumber)
+	destination.BankCode = strings.ToUpper(strings.TrimSpace(destination.BankCode))
+	if destination.AccountName == "" || destination.AccountNumber == "" || destination.BankCode == "" {
+		return destination, fmt.Errorf("%w: destination account_name, account_number and bank_code are required", models.ErrInvalidDisbursement)
+	}
+	return destination, nil
+}
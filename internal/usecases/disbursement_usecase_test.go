@@ -0,0 +1,247 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/infrastructures/services"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type disbursementFixture struct {
+	usecase       *DisbursementUsecase
+	loan          *models.Loan
+	loans         *memoryLoanRepository
+	schedules     *memoryScheduleRepository
+	ledger        *memoryLedgerRepository
+	disbursements *memoryDisbursementRepository
+}
+
+// newDisbursementFixture sets up an approved loan and a fake provider that
+// fails its first failures payouts.
+func newDisbursementFixture(failures int) *disbursementFixture {
+	loan := &models.Loan{
+		ID:                 primitive.NewObjectID(),
+		Amount:             usd(1200000),
+		Interest:           0.12,
+		Term:               12,
+		RepaymentMethod:    models.RepaymentMethodAnnuity,
+		OriginationFee:     usd(2500),
+		OutstandingBalance: usd(0),
+		Status:             models.LoanStatusApproved,
+	}
+	f := &disbursementFixture{
+		loan:          loan,
+		loans:         newMemoryLoanRepository(loan),
+		schedules:     &memoryScheduleRepository{},
+		ledger:        &memoryLedgerRepository{},
+		disbursements: &memoryDisbursementRepository{},
+	}
+	f.usecase = &DisbursementUsecase{
+		disbursementRepo: f.disbursements,
+		loanRepo:         f.loans,
+		scheduleRepo:     f.schedules,
+		ledgerRepo:       f.ledger,
+		logRepo:          &memoryLogRepository{},
+		scheduleSvc:      services.NewScheduleService(),
+		provider:         services.NewFakePayoutProvider(failures),
+	}
+	return f
+}
+
+func (f *disbursementFixture) disburse(key string) (*models.Disbursement, bool, error) {
+	return f.usecase.DisburseLoan(f.loan.ID.Hex(), primitive.NewObjectID().Hex(), key, dtos.DisbursementDTO{
+		Destination: models.PayoutDestination{AccountName: "A Borrower", AccountNumber: "0012345678", BankCode: "abc"},
+	})
+}
+
+// makeDue brings every pending retry forward to now, as if its delay had
+// passed.
+func (f *disbursementFixture) makeDue() {
+	past := time.Now().Add(-time.Second)
+	for i := range f.disbursements.disbursements {
+		if f.disbursements.disbursements[i].NextAttemptAt != nil {
+			f.disbursements.disbursements[i].NextAttemptAt = &past
+		}
+	}
+}
+
+// assertBookedOnce checks the loan was disbursed and booked exactly once.
+func (f *disbursementFixture) assertBookedOnce(t *testing.T, disbursement *models.Disbursement) {
+	t.Helper()
+	loan, _ := f.loans.GetLoanByID(f.loan.ID.Hex())
+	if loan.Status != models.LoanStatusDisbursed {
+		t.Fatalf("loan is %s, want disbursed", loan.Status)
+	}
+	schedules, _ := f.schedules.GetSchedulesByLoanID(f.loan.ID.Hex())
+	if len(schedules) != 1 {
+		t.Fatalf("%d schedules, want 1", len(schedules))
+	}
+	want := f.loan.Amount.Add(schedules[0].TotalInterest).Add(f.loan.OriginationFee)
+	if loan.OutstandingBalance.Cmp(want) != 0 {
+		t.Fatalf("outstanding balance %s, want %s", loan.OutstandingBalance, want)
+	}
+	if n := f.ledger.transactions("Loan booked on disbursement " + disbursement.ProviderReference); n != 1 {
+		t.Fatalf("booked %d times, want once", n)
+	}
+	if got := f.ledger.balance(models.AccountPrincipalReceivable); got != f.loan.Amount.Amount {
+		t.Fatalf("principal receivable %d, want %d", got, f.loan.Amount.Amount)
+	}
+	if got := -f.ledger.balance(models.AccountUnearnedInterest); got != schedules[0].TotalInterest.Amount {
+		t.Fatalf("unearned interest %d, want %d", got, schedules[0].TotalInterest.Amount)
+	}
+	stored, _ := f.disbursements.GetDisbursement(disbursement.ID.Hex())
+	if stored.BookedAt == nil {
+		t.Fatal("disbursement not marked booked")
+	}
+}
+
+func TestDisburseLoanRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		// background retry runs after the request
+		runs     int
+		attempts int
+		status   string
+	}{
+		{"pays out on the first attempt", 0, 0, 1, models.DisbursementStatusSucceeded},
+		{"transient failure then success", 2, 2, 3, models.DisbursementStatusSucceeded},
+		{"exhausted retries", models.MaxPayoutAttempts, models.MaxPayoutAttempts, models.MaxPayoutAttempts, models.DisbursementStatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newDisbursementFixture(tt.failures)
+			disbursement, created, err := f.disburse("key-1")
+			if err != nil || !created {
+				t.Fatalf("created %v, err %v", created, err)
+			}
+			for i := 0; i < tt.runs; i++ {
+				f.makeDue()
+				if err := f.usecase.RetryDueDisbursements(); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			stored, _ := f.disbursements.GetDisbursement(disbursement.ID.Hex())
+			if stored.Status != tt.status || len(stored.Attempts) != tt.attempts {
+				t.Fatalf("%s after %d attempts, want %s after %d", stored.Status, len(stored.Attempts), tt.status, tt.attempts)
+			}
+			if stored.NextAttemptAt != nil {
+				t.Fatalf("retry still scheduled at %s", stored.NextAttemptAt)
+			}
+			if tt.status == models.DisbursementStatusFailed {
+				loan, _ := f.loans.GetLoanByID(f.loan.ID.Hex())
+				if stored.Active || loan.Status != models.LoanStatusApproved || len(f.ledger.entries) != 0 {
+					t.Fatalf("failed disbursement left active %v, loan %s, %d ledger entries", stored.Active, loan.Status, len(f.ledger.entries))
+				}
+				return
+			}
+			f.assertBookedOnce(t, stored)
+		})
+	}
+}
+
+func TestDisburseLoanBacksOff(t *testing.T) {
+	f := newDisbursementFixture(models.MaxPayoutAttempts)
+	disbursement, _, err := f.disburse("key-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for failures := 1; failures < models.MaxPayoutAttempts; failures++ {
+		stored, _ := f.disbursements.GetDisbursement(disbursement.ID.Hex())
+		last := stored.Attempts[len(stored.Attempts)-1]
+		if stored.NextAttemptAt == nil || stored.NextAttemptAt.Sub(last.At) != models.PayoutRetryDelay(failures) {
+			t.Fatalf("after %d failures next attempt at %v, want %s after the last", failures, stored.NextAttemptAt, models.PayoutRetryDelay(failures))
+		}
+		// not due yet: the run leaves it alone
+		if err := f.usecase.RetryDueDisbursements(); err != nil {
+			t.Fatal(err)
+		}
+		if again, _ := f.disbursements.GetDisbursement(disbursement.ID.Hex()); len(again.Attempts) != failures {
+			t.Fatalf("retried before the delay passed: %d attempts", len(again.Attempts))
+		}
+		f.makeDue()
+		if err := f.usecase.RetryDueDisbursements(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDisburseLoanIdempotency(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		key      string
+		otherKey bool
+		created  bool
+		err      error
+	}{
+		{"replayed key returns the original", 0, "key-1", false, false, nil},
+		{"replayed key while the payout is pending", 1, "key-1", false, false, nil},
+		{"second disbursement while one is pending", 1, "key-2", false, false, models.ErrDisbursementExists},
+		{"second disbursement of a paid out loan", 0, "key-2", false, false, models.ErrInvalidTransition},
+		{"key reused for another loan", 0, "key-1", true, false, models.ErrIdempotencyConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newDisbursementFixture(tt.failures)
+			first, _, err := f.disburse("key-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.otherKey {
+				other := *f.loan
+				other.ID = primitive.NewObjectID()
+				f.loans.loans[other.ID] = other
+				f.loan = &other
+			}
+
+			again, created, err := f.disburse(tt.key)
+			if !errors.Is(err, tt.err) || created != tt.created {
+				t.Fatalf("created %v, err %v, want %v and %v", created, err, tt.created, tt.err)
+			}
+			if tt.err == nil && again.ID != first.ID {
+				t.Fatalf("replay returned disbursement %s, want %s", again.ID.Hex(), first.ID.Hex())
+			}
+			if len(f.disbursements.disbursements) != 1 {
+				t.Fatalf("%d disbursements, want 1", len(f.disbursements.disbursements))
+			}
+			stored, _ := f.disbursements.GetDisbursement(first.ID.Hex())
+			if len(stored.Attempts) != 1 {
+				t.Fatalf("%d payout attempts, want 1", len(stored.Attempts))
+			}
+		})
+	}
+}
+
+func TestBookDisbursedLoanOnce(t *testing.T) {
+	f := newDisbursementFixture(0)
+	f.ledger.failNext = errors.New("ledger unavailable")
+	disbursement, _, err := f.disburse("key-1")
+	if err == nil {
+		t.Fatal("booking failure not reported")
+	}
+
+	// the money moved, so the disbursement succeeded but is left unbooked
+	disbursements, _ := f.disbursements.GetUnbookedDisbursements()
+	if len(disbursements) != 1 || disbursements[0].Status != models.DisbursementStatusSucceeded {
+		t.Fatalf("unbooked disbursements %v", disbursements)
+	}
+	disbursement = &disbursements[0]
+
+	for run := 0; run < 2; run++ {
+		if err := f.usecase.RetryDueDisbursements(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := f.usecase.RetryDisbursement(disbursement.ID.Hex(), primitive.NewObjectID().Hex()); !errors.Is(err, models.ErrInvalidDisbursement) {
+		t.Fatalf("retrying a booked disbursement: err = %v", err)
+	}
+	f.assertBookedOnce(t, disbursement)
+}
@@ -0,0 +1,235 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/infrastructures/services"
+	"LoanGuard/internal/repository/interfaces"
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var documentTypePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type IDocumentUsecase interface {
+	UploadDocument(loanID string, userID string, docType string, fileName string, size int64, file io.Reader) (*models.LoanDocument, error)
+	GetDocuments(loanID string) (*dtos.LoanDocumentsDTO, error)
+	ReviewDocument(loanID string, documentID string, adminID string, req dtos.DocumentReviewDTO) (*models.LoanDocument, error)
+	OpenDocument(loanID string, documentID string, actorID string) (*models.LoanDocument, io.ReadCloser, error)
+}
+
+type DocumentUsecase struct {
+	documentRepo repository_interface.IDocumentRepository
+	loanRepo     repository_interface.ILoanRepository
+	productRepo  repository_interface.IProductRepository
+	logRepo      repository_interface.ILogRepository
+	storage      services.IFileStorage
+}
+
+func NewDocumentUsecase(documentRepo repository_interface.IDocumentRepository, loanRepo repository_interface.ILoanRepository, productRepo repository_interface.IProductRepository, logRepo repository_interface.ILogRepository, storage services.IFileStorage) IDocumentUsecase {
+	return &DocumentUsecase{
+		documentRepo: documentRepo,
+		loanRepo:     loanRepo,
+		productRepo:  productRepo,
+		logRepo:      logRepo,
+		storage:      storage,
+	}
+}
+
+// UploadDocument stores a document the loan's product asks for, or evidence
+// of collateral on secured products. The file
+// type is taken from its content rather than the name the client sent.
+// Uploading a type again, e.g. after a rejection, supersedes the earlier
+// upload on the checklist.
+func (du *DocumentUsecase) UploadDocument(loanID string, userID string, docType string, fileName string, size int64, file io.Reader) (*models.LoanDocument, error) {
+	loan, err := du.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, models.ErrLoanNotFound
+	}
+	if !acceptsDocuments(loan) {
+		return nil, fmt.Errorf("%w: documents cannot be added to %s loans", models.ErrInvalidTransition, loan.Status)
+	}
+	accepted, err := du.acceptedDocuments(loan)
+	if err != nil {
+		return nil, err
+	}
+	docType = strings.ToLower(strings.TrimSpace(docType))
+	if !containsString(accepted, docType) {
+		return nil, fmt.Errorf("%w: this loan does not need a %q document", models.ErrInvalidDocument, docType)
+	}
+	if size <= 0 || size > models.MaxDocumentSize {
+		return nil, fmt.Errorf("%w: documents must be at most %d MB", models.ErrInvalidDocument, models.MaxDocumentSize>>20)
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("%w: the file could not be read", models.ErrInvalidDocument)
+	}
+	head = head[:n]
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	extension, ok := models.DocumentContentTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: only PDF, JPEG and PNG files are accepted", models.ErrInvalidDocument)
+	}
+
+	document := &models.LoanDocument{
+		ID:          primitive.NewObjectID(),
+		LoanID:      loan.ID,
+		Type:        docType,
+		FileName:    filepath.Base(fileName),
+		ContentType: contentType,
+		Size:        size,
+		Status:      models.DocumentStatusPending,
+		UploadedBy:  userID,
+		UploadedAt:  time.Now(),
+	}
+	key := "loans/" + loanID + "/" + document.ID.Hex() + extension
+	content := io.MultiReader(bytes.NewReader(head), io.LimitReader(file, models.MaxDocumentSize-int64(n)))
+	document.Location, err = du.storage.Save(key, content)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := du.documentRepo.CreateDocument(document)
+	if err != nil {
+		return nil, err
+	}
+	du.logDocument(userID, loanID, "Loan document uploaded: "+docType)
+	return result, nil
+}
+
+// GetDocuments returns the loan's documents and where its checklist stands.
+func (du *DocumentUsecase) GetDocuments(loanID string) (*dtos.LoanDocumentsDTO, error) {
+	loan, err := du.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, models.ErrLoanNotFound
+	}
+	required, err := du.requiredDocuments(loan)
+	if err != nil {
+		return nil, err
+	}
+	documents, err := du.documentRepo.GetDocuments(loanID)
+	if err != nil {
+		return nil, err
+	}
+	checklist, complete := models.DocumentChecklist(required, documents)
+	return &dtos.LoanDocumentsDTO{
+		Checklist: checklist,
+		Complete:  complete,
+		Documents: documents,
+	}, nil
+}
+
+// ReviewDocument marks a document verified or rejected. Rejections need a
+// reason the borrower can act on.
+// OpenDocument fetches a document's file from storage so staff can look at
+// it before reviewing it. The caller closes the file.
+func (du *DocumentUsecase) OpenDocument(loanID string, documentID string, actorID string) (*models.LoanDocument, io.ReadCloser, error) {
+	document, err := du.documentRepo.GetDocument(documentID)
+	if err != nil || document.LoanID.Hex() != loanID {
+		return nil, nil, models.ErrDocumentNotFound
+	}
+	file, err := du.storage.Open(document.Location)
+	if err != nil {
+		return nil, nil, err
+	}
+	du.logDocument(actorID, loanID, "Loan document downloaded: "+document.Type+" "+documentID)
+	return document, file, nil
+}
+
+func (du *DocumentUsecase) ReviewDocument(loanID string, documentID string, adminID string, req dtos.DocumentReviewDTO) (*models.LoanDocument, error) {
+	document, err := du.documentRepo.GetDocument(documentID)
+	if err != nil || document.LoanID.Hex() != loanID {
+		return nil, models.ErrDocumentNotFound
+	}
+	reason := strings.TrimSpace(req.Reason)
+	switch req.Status {
+	case models.DocumentStatusVerified:
+		reason = ""
+	case models.DocumentStatusRejected:
+		if reason == "" {
+			return nil, fmt.Errorf("%w: a rejection needs a reason", models.ErrInvalidDocument)
+		}
+	default:
+		return nil, fmt.Errorf("%w: status must be verified or rejected", models.ErrInvalidDocument)
+	}
+
+	now := time.Now()
+	document.Status = req.Status
+	document.RejectionReason = reason
+	document.ReviewedBy = adminID
+	document.ReviewedAt = &now
+	if err := du.documentRepo.UpdateDocument(document); err != nil {
+		return nil, err
+	}
+
+	action := "Loan document " + req.Status + ": " + document.Type
+	if reason != "" {
+		action += " (" + reason + ")"
+	}
+	du.logDocument(adminID, loanID, action)
+	return document, nil
+}
+
+func (du *DocumentUsecase) requiredDocuments(loan *models.Loan) ([]string, error) {
+	if loan.ProductID.IsZero() {
+		return nil, nil
+	}
+	product, err := du.productRepo.GetProductByID(loan.ProductID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	return product.RequiredDocuments, nil
+}
+
+// acceptedDocuments is the document types the loan takes: those its product
+// requires and, on secured products, collateral evidence.
+func (du *DocumentUsecase) acceptedDocuments(loan *models.Loan) ([]string, error) {
+	if loan.ProductID.IsZero() {
+		return nil, nil
+	}
+	product, err := du.productRepo.GetProductByID(loan.ProductID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	accepted := product.RequiredDocuments
+	if product.MaxLTV > 0 && !containsString(accepted, models.DocumentTypeCollateral) {
+		accepted = append(accepted[:len(accepted):len(accepted)], models.DocumentTypeCollateral)
+	}
+	return accepted, nil
+}
+
+func (du *DocumentUsecase) logDocument(actorID string, loanID string, action string) {
+	userID, _ := primitive.ObjectIDFromHex(actorID)
+	du.logRepo.CreateLog(&models.SystemLog{
+		Action:    action,
+		Timestamp: time.Now(),
+		UserID:    userID,
+		LoanID:    loanID,
+	})
+}
+
+// acceptsDocuments reports whether the loan is still being applied for or
+// reviewed, the only time documents can be added.
+func acceptsDocuments(loan *models.Loan) bool {
+	return loan.Status == models.LoanStatusDraft || inReview(loan)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
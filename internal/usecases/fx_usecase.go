@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type IFxUsecase interface {
+	UploadRates(adminID string, upload dtos.FXRateUploadDTO) ([]models.FXRate, error)
+	GetRates(asOf time.Time) (map[string]models.FXRate, error)
+}
+
+type FxUsecase struct {
+	fxRateRepo        repository_interface.IFxRateRepository
+	logRepo           repository_interface.ILogRepository
+	reportingCurrency string
+}
+
+func NewFxUsecase(fxRateRepo repository_interface.IFxRateRepository, logRepo repository_interface.ILogRepository, reportingCurrency string) IFxUsecase {
+	return &FxUsecase{
+		fxRateRepo:        fxRateRepo,
+		logRepo:           logRepo,
+		reportingCurrency: strings.ToUpper(reportingCurrency),
+	}
+}
+
+// UploadRates stores one effective date's rate table. A rate that already
+// exists for the same currency and date is refused rather than replaced,
+// otherwise reports already run for that date would change underneath.
+func (fu *FxUsecase) UploadRates(adminID string, upload dtos.FXRateUploadDTO) ([]models.FXRate, error) {
+	base := strings.ToUpper(upload.BaseCurrency)
+	if base == "" {
+		base = fu.reportingCurrency
+	}
+	if !models.IsCurrencyCode(base) {
+		return nil, errors.New("base currency must be a three letter ISO 4217 code")
+	}
+	if upload.EffectiveDate.IsZero() {
+		return nil, errors.New("effective_date is required")
+	}
+	if len(upload.Rates) == 0 {
+		return nil, errors.New("at least one rate is required")
+	}
+	effective := startOfDay(upload.EffectiveDate)
+
+	now := time.Now()
+	rates := make([]models.FXRate, 0, len(upload.Rates))
+	for currency, value := range upload.Rates {
+		currency = strings.ToUpper(currency)
+		if !models.IsCurrencyCode(currency) || currency == base {
+			return nil, fmt.Errorf("invalid currency %q", currency)
+		}
+		if _, err := parseRate(value); err != nil {
+			return nil, fmt.Errorf("%s: %w", currency, err)
+		}
+		exists, err := fu.fxRateRepo.RateExists(base, currency, effective)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, fmt.Errorf("a %s rate effective %s already exists", currency, effective.Format("2006-01-02"))
+		}
+		rates = append(rates, models.FXRate{
+			BaseCurrency:  base,
+			Currency:      currency,
+			Rate:          strings.TrimSpace(value),
+			EffectiveDate: effective,
+			UploadedBy:    adminID,
+			CreatedAt:     now,
+		})
+	}
+
+	if err := fu.fxRateRepo.CreateRates(rates); err != nil {
+		return nil, err
+	}
+
+	userID, _ := primitive.ObjectIDFromHex(adminID)
+	fu.logRepo.CreateLog(&models.SystemLog{
+		Action:    fmt.Sprintf("FX rates uploaded: %d rates against %s effective %s", len(rates), base, effective.Format("2006-01-02")),
+		Timestamp: now,
+		UserID:    userID,
+	})
+	return rates, nil
+}
+
+func (fu *FxUsecase) GetRates(asOf time.Time) (map[string]models.FXRate, error) {
+	return fu.fxRateRepo.GetRatesAsOf(fu.reportingCurrency, asOf)
+}
+
+// parseRate reads a decimal rate exactly; rates are kept as strings so no
+// precision is lost between upload and conversion.
+func parseRate(value string) (*big.Rat, error) {
+	rate, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok || rate.Sign() <= 0 {
+		return nil, errors.New("rate must be a positive decimal number")
+	}
+	return rate, nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,196 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// minimumBorrowerAge is the youngest a user can be to pass KYC.
+const minimumBorrowerAge = 18
+
+var nationalIDPattern = regexp.MustCompile(`^[A-Z0-9-]{5,20}$`)
+
+type IKYCUsecase interface {
+	SubmitKYC(userID string, req dtos.KYCSubmissionDTO) (*dtos.KYCDTO, error)
+	GetKYC(userID string) (*dtos.KYCDTO, error)
+	GetKYCByStatus(status string) ([]dtos.KYCDTO, error)
+	ReviewKYC(userID string, adminID string, req dtos.KYCReviewDTO) (*dtos.KYCDTO, error)
+}
+
+type KYCUsecase struct {
+	userRepo repository_interface.IUserRepository
+	logRepo  repository_interface.ILogRepository
+}
+
+func NewKYCUsecase(userRepo repository_interface.IUserRepository, logRepo repository_interface.ILogRepository) IKYCUsecase {
+	return &KYCUsecase{
+		userRepo: userRepo,
+		logRepo:  logRepo,
+	}
+}
+
+// SubmitKYC stores the borrower's identity details and puts them in the
+// queue for an admin. Submitting again, even once verified, starts a new
+// review.
+func (ku *KYCUsecase) SubmitKYC(userID string, req dtos.KYCSubmissionDTO) (*dtos.KYCDTO, error) {
+	user, err := ku.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	dateOfBirth, kyc, err := validateKYC(req)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	kyc.Status = models.KYCStatusPending
+	kyc.SubmittedAt = &now
+
+	if err := ku.userRepo.UpdateKYC(userID, dateOfBirth, kyc); err != nil {
+		return nil, err
+	}
+	user.DateOfBirth = dateOfBirth
+	user.KYC = *kyc
+	ku.logKYC(userID, "KYC details submitted")
+	return kycView(user), nil
+}
+
+func (ku *KYCUsecase) GetKYC(userID string) (*dtos.KYCDTO, error) {
+	user, err := ku.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	return kycView(user), nil
+}
+
+// GetKYCByStatus lists the users in a KYC status, pending by default.
+func (ku *KYCUsecase) GetKYCByStatus(status string) ([]dtos.KYCDTO, error) {
+	if status == "" {
+		status = models.KYCStatusPending
+	}
+	switch status {
+	case models.KYCStatusUnverified, models.KYCStatusPending, models.KYCStatusVerified, models.KYCStatusRejected:
+	default:
+		return nil, fmt.Errorf("%w: unknown KYC status %q", models.ErrInvalidKYC, status)
+	}
+	users, err := ku.userRepo.GetUsersByKYCStatus(status)
+	if err != nil {
+		return nil, err
+	}
+	views := make([]dtos.KYCDTO, 0, len(users))
+	for _, user := range users {
+		views = append(views, *kycView(user))
+	}
+	return views, nil
+}
+
+// ReviewKYC verifies or rejects the details a user submitted. Rejections
+// need a reason the user can act on.
+func (ku *KYCUsecase) ReviewKYC(userID string, adminID string, req dtos.KYCReviewDTO) (*dtos.KYCDTO, error) {
+	user, err := ku.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if user.KYCStatus() != models.KYCStatusPending {
+		return nil, fmt.Errorf("%w: only pending KYC details can be reviewed", models.ErrInvalidKYC)
+	}
+	reason := strings.TrimSpace(req.Reason)
+	switch req.Status {
+	case models.KYCStatusVerified:
+		reason = ""
+	case models.KYCStatusRejected:
+		if reason == "" {
+			return nil, fmt.Errorf("%w: a rejection needs a reason", models.ErrInvalidKYC)
+		}
+	default:
+		return nil, fmt.Errorf("%w: status must be verified or rejected", models.ErrInvalidKYC)
+	}
+
+	now := time.Now()
+	kyc := user.KYC
+	kyc.Status = req.Status
+	kyc.RejectionReason = reason
+	kyc.ReviewedBy = adminID
+	kyc.ReviewedAt = &now
+	if err := ku.userRepo.UpdateKYC(userID, user.DateOfBirth, &kyc); err != nil {
+		return nil, err
+	}
+	user.KYC = kyc
+
+	action := "KYC " + req.Status + " for user " + userID
+	if reason != "" {
+		action += ": " + reason
+	}
+	ku.logKYC(adminID, action)
+	return kycView(user), nil
+}
+
+func (ku *KYCUsecase) logKYC(actorID string, action string) {
+	userID, _ := primitive.ObjectIDFromHex(actorID)
+	ku.logRepo.CreateLog(&models.SystemLog{
+		Action:    action,
+		Timestamp: time.Now(),
+		UserID:    userID,
+	})
+}
+
+func kycView(user *models.User) *dtos.KYCDTO {
+	kyc := user.KYC
+	kyc.Status = user.KYCStatus()
+	return &dtos.KYCDTO{
+		UserID:      user.ID.Hex(),
+		Name:        user.Name,
+		Email:       user.Email,
+		DateOfBirth: user.DateOfBirth,
+		KYC:         kyc,
+	}
+}
+
+func validateKYC(req dtos.KYCSubmissionDTO) (*time.Time, *models.KYCProfile, error) {
+	nationalID := strings.ToUpper(strings.TrimSpace(req.NationalID))
+	if !nationalIDPattern.MatchString(nationalID) {
+		return nil, nil, fmt.Errorf("%w: national_id must be 5 to 20 letters, digits or dashes", models.ErrInvalidKYC)
+	}
+
+	dateOfBirth, err := time.Parse("2006-01-02", req.DateOfBirth)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: date_of_birth must be a YYYY-MM-DD date", models.ErrInvalidKYC)
+	}
+	if age := (&models.User{DateOfBirth: &dateOfBirth}).AgeAt(time.Now()); age < minimumBorrowerAge {
+		return nil, nil, fmt.Errorf("%w: borrowers must be at least %d years old", models.ErrInvalidKYC, minimumBorrowerAge)
+	}
+
+	address := req.Address
+	address.Country = strings.ToUpper(strings.TrimSpace(address.Country))
+	if strings.TrimSpace(address.Line1) == "" || strings.TrimSpace(address.City) == "" || len(address.Country) != 2 {
+		return nil, nil, fmt.Errorf("%w: address needs line1, city and a two letter country code", models.ErrInvalidKYC)
+	}
+
+	employment := req.Employment
+	employment.Status = strings.ToLower(employment.Status)
+	if !models.ValidEmploymentStatus(employment.Status) {
+		return nil, nil, fmt.Errorf("%w: employment status must be employed, self_employed, unemployed, retired or student", models.ErrInvalidKYC)
+	}
+	if employment.Status == models.EmploymentEmployed && strings.TrimSpace(employment.Employer) == "" {
+		return nil, nil, fmt.Errorf("%w: employer is required for employed users", models.ErrInvalidKYC)
+	}
+
+	income := req.MonthlyIncome
+	if income.IsNegative() || !models.IsCurrencyCode(income.Currency) {
+		return nil, nil, fmt.Errorf("%w: monthly_income needs a non-negative amount and a currency", models.ErrInvalidKYC)
+	}
+
+	return &dateOfBirth, &models.KYCProfile{
+		NationalID:    nationalID,
+		Address:       address,
+		Employment:    employment,
+		MonthlyIncome: income,
+	}, nil
+}
@@ -0,0 +1,153 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// loanAssigner hands loans under review to loan officers. Loans are assigned
+// automatically on submission and by admins, both through it.
+type loanAssigner struct {
+	loanRepo repository_interface.ILoanRepository
+	userRepo repository_interface.IUserRepository
+	logRepo  repository_interface.ILogRepository
+}
+
+func newLoanAssigner(loanRepo repository_interface.ILoanRepository, userRepo repository_interface.IUserRepository, logRepo repository_interface.ILogRepository) *loanAssigner {
+	return &loanAssigner{
+		loanRepo: loanRepo,
+		userRepo: userRepo,
+		logRepo:  logRepo,
+	}
+}
+
+// workloads returns every officer with the loans they hold under review,
+// including officers who have never been assigned one.
+func (a *loanAssigner) workloads() ([]models.OfficerWorkload, error) {
+	officers, err := a.userRepo.GetUsersByRole(models.RoleOfficer)
+	if err != nil {
+		return nil, err
+	}
+	held, err := a.loanRepo.GetOfficerWorkloads(models.ReviewStatuses)
+	if err != nil {
+		return nil, err
+	}
+	byOfficer := make(map[string]models.OfficerWorkload, len(held))
+	for _, workload := range held {
+		byOfficer[workload.OfficerID] = workload
+	}
+
+	workloads := make([]models.OfficerWorkload, 0, len(officers))
+	for _, officer := range officers {
+		workload := byOfficer[officer.ID.Hex()]
+		workload.OfficerID = officer.ID.Hex()
+		workload.Name = officer.Name
+		workloads = append(workloads, workload)
+	}
+	return workloads, nil
+}
+
+// chooseOfficer returns the index of the officer strategy picks, leaving out
+// exclude. Round robin takes the officer who went longest without a new
+// loan, least loaded the one holding the fewest loans under review.
+func chooseOfficer(workloads []models.OfficerWorkload, strategy string, exclude string) (int, error) {
+	candidates := make([]int, 0, len(workloads))
+	for i, workload := range workloads {
+		if workload.OfficerID != exclude {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return -1, models.ErrNoOfficers
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := workloads[candidates[i]], workloads[candidates[j]]
+		if strategy == models.AssignmentLeastLoaded && a.OpenLoans != b.OpenLoans {
+			return a.OpenLoans < b.OpenLoans
+		}
+		if !a.LastAssignedAt.Equal(b.LastAssignedAt) {
+			return a.LastAssignedAt.Before(b.LastAssignedAt)
+		}
+		return a.OfficerID < b.OfficerID
+	})
+	return candidates[0], nil
+}
+
+// assign gives the loan to officerID and logs the assignment, or the
+// reassignment when the loan already had an officer.
+func (a *loanAssigner) assign(loan *models.Loan, officerID string, strategy string, actorID string, reason string) error {
+	previous := loan.Assignment
+	assignment := &models.LoanAssignment{
+		OfficerID:  officerID,
+		Strategy:   strategy,
+		AssignedBy: actorID,
+		AssignedAt: time.Now(),
+	}
+	if err := a.loanRepo.AssignLoan(loan.ID.Hex(), assignment, models.ReviewStatuses); err != nil {
+		return err
+	}
+	loan.Assignment = assignment
+
+	action := fmt.Sprintf("Loan assigned to officer %s (%s)", officerID, strategy)
+	if previous != nil {
+		action = fmt.Sprintf("Loan reassigned from officer %s to officer %s (%s)", previous.OfficerID, officerID, strategy)
+	}
+	if reason != "" {
+		action += ": " + reason
+	}
+	userID, _ := primitive.ObjectIDFromHex(actorID)
+	a.logRepo.CreateLog(&models.SystemLog{
+		Action:    action,
+		Timestamp: assignment.AssignedAt,
+		UserID:    userID,
+		LoanID:    loan.ID.Hex(),
+	})
+	return nil
+}
+
+// autoAssign gives a loan that has just been submitted for review to an
+// officer picked by strategy. Nobody can have assigned the loan before it was
+// submitted, so any assignment it carries is replaced. Decided loans and
+// manual assignment are left alone.
+func (a *loanAssigner) autoAssign(loan *models.Loan, strategy string) error {
+	if strategy == "" || strategy == models.AssignmentManual || !inReview(loan) {
+		return nil
+	}
+	workloads, err := a.workloads()
+	if err != nil {
+		return err
+	}
+	chosen, err := chooseOfficer(workloads, strategy, "")
+	if err != nil {
+		return err
+	}
+	return a.assign(loan, workloads[chosen].OfficerID, strategy, systemActor, "")
+}
+
+func inReview(loan *models.Loan) bool {
+	for _, status := range models.ReviewStatuses {
+		if loan.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+func validAssignmentStrategy(strategy string) bool {
+	switch strategy {
+	case models.AssignmentRoundRobin, models.AssignmentLeastLoaded:
+		return true
+	}
+	return false
+}
+
+func isOfficer(user *models.User) bool {
+	return strings.EqualFold(user.Role, models.RoleOfficer)
+}
@@ -0,0 +1,237 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/infrastructures/services/email_service"
+	"LoanGuard/internal/repository/interfaces"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// loanDecider approves or rejects loans under review. Admin decisions and
+// the automated decision policy both go through it so every approval is
+// checked the same way.
+type loanDecider struct {
+	loanRepo       repository_interface.ILoanRepository
+	logRepo        repository_interface.ILogRepository
+	productRepo    repository_interface.IProductRepository
+	userRepo       repository_interface.IUserRepository
+	documentRepo   repository_interface.IDocumentRepository
+	collateralRepo repository_interface.ICollateralRepository
+	emailSvc       email_service.IEmailService
+}
+
+func newLoanDecider(loanRepo repository_interface.ILoanRepository, logRepo repository_interface.ILogRepository, productRepo repository_interface.IProductRepository, userRepo repository_interface.IUserRepository, documentRepo repository_interface.IDocumentRepository, collateralRepo repository_interface.ICollateralRepository, emailSvc email_service.IEmailService) *loanDecider {
+	return &loanDecider{
+		loanRepo:       loanRepo,
+		logRepo:        logRepo,
+		productRepo:    productRepo,
+		userRepo:       userRepo,
+		documentRepo:   documentRepo,
+		collateralRepo: collateralRepo,
+		emailSvc:       emailSvc,
+	}
+}
+
+// decide moves the loan to approved or rejected. Approved loans are booked
+// when they are disbursed. Loans above their product's second approval
+// threshold are only parked in pending_second_approval by the first approval
+// and need a second one from a different admin. No approval is given
+// before the documents the product requires are verified, every guarantor
+// and co-borrower has accepted their invitation and, on secured products, the
+// collateral keeps the loan-to-value ratio within the product maximum.
+// The final decision is stored on the loan with its reason, in the same
+// write as its status and approval, and the borrower is emailed about it.
+// Approval then puts the liens on the collateral into effect and rejection
+// releases them; should that fail, repeating the decision completes it.
+func (d *loanDecider) decide(loan *models.Loan, actorID string, decision models.LoanDecision) error {
+	status := decision.Status
+	reason := decision.Summary()
+	if status != models.LoanStatusApproved && status != models.LoanStatusRejected {
+		return errors.New("invalid status")
+	}
+	if loan.Status == status && loan.Decision != nil {
+		return d.settleLiens(loan, actorID)
+	}
+	if status == models.LoanStatusApproved {
+		if !loan.PartiesAccepted() {
+			return models.ErrPartiesPending
+		}
+		complete, err := d.documentsComplete(loan)
+		if err != nil {
+			return err
+		}
+		if !complete {
+			return models.ErrDocumentsIncomplete
+		}
+		if err := d.checkCollateral(loan); err != nil {
+			return err
+		}
+	}
+	if status == models.LoanStatusApproved && loan.Status == models.LoanStatusUnderReview {
+		needsSecond, err := d.requiresSecondApproval(loan)
+		if err != nil {
+			return err
+		}
+		if needsSecond {
+			return d.firstApproval(loan, actorID, reason)
+		}
+	}
+	if !models.CanTransitionLoan(loan.Status, status) {
+		return fmt.Errorf("%w: cannot move loan from %s to %s", models.ErrInvalidTransition, loan.Status, status)
+	}
+
+	if status == models.LoanStatusApproved && loan.Status == models.LoanStatusPendingSecondApproval {
+		first := firstApprover(loan)
+		if first == actorID {
+			return models.ErrSameApprover
+		}
+		reason = strings.TrimSpace("second approval, first approval by " + first + ". " + reason)
+	}
+
+	decision.DecidedBy = actorID
+	decision.DecidedAt = time.Now()
+	var approval *models.LoanApproval
+	if status == models.LoanStatusApproved {
+		approval = &models.LoanApproval{AdminID: actorID, At: decision.DecidedAt}
+	}
+	if err := d.changeStatus(loan, status, actorID, reason, &decision, approval); err != nil {
+		return err
+	}
+	loan.Decision = &decision
+	d.notifyBorrower(loan)
+	return d.settleLiens(loan, actorID)
+}
+
+// settleLiens puts the liens on an approved loan's collateral into effect
+// and releases those on a rejected loan's. Both are no-ops once done.
+func (d *loanDecider) settleLiens(loan *models.Loan, actorID string) error {
+	if loan.Status == models.LoanStatusApproved {
+		return d.collateralRepo.UpdateLienStatus(loan.ID.Hex(), models.LienStatusPending, models.LienStatusActive, time.Now())
+	}
+	return releaseLiens(d.collateralRepo, d.logRepo, loan, actorID)
+}
+
+// notifyBorrower emails the decision to the borrower. The decision already
+// stands at this point, so a failed email is only logged.
+func (d *loanDecider) notifyBorrower(loan *models.Loan) {
+	user, err := d.userRepo.GetUserByID(loan.UserId.Hex())
+	if err == nil {
+		err = d.emailSvc.SendLoanDecisionEmail(user.Email, email_service.LoanDecisionEmail{
+			Name:        user.Name,
+			LoanID:      loan.ID.Hex(),
+			Amount:      loan.Amount.String(),
+			Approved:    loan.Decision.Status == models.LoanStatusApproved,
+			ReasonLabel: loan.Decision.ReasonLabel,
+			Note:        loan.Decision.Note,
+		})
+	}
+	if err != nil {
+		d.logRepo.CreateLog(&models.SystemLog{
+			Action:    "Loan decision email not sent: " + err.Error(),
+			Timestamp: time.Now(),
+			LoanID:    loan.ID.Hex(),
+		})
+	}
+}
+
+func (d *loanDecider) firstApproval(loan *models.Loan, actorID string, reason string) error {
+	reason = strings.TrimSpace("first of two approvals. " + reason)
+	approval := &models.LoanApproval{AdminID: actorID, At: time.Now()}
+	return d.changeStatus(loan, models.LoanStatusPendingSecondApproval, actorID, reason, nil, approval)
+}
+
+// changeStatus moves the loan to status and stores the decision and
+// approval, when given, in the same write.
+func (d *loanDecider) changeStatus(loan *models.Loan, status string, actorID string, reason string, decision *models.LoanDecision, approval *models.LoanApproval) error {
+	err := changeLoanStatus(d.logRepo, loan, status, actorID, reason, func(change models.StatusChange) error {
+		return d.loanRepo.DecideLoan(loan.ID.Hex(), change, decision, approval)
+	})
+	if err != nil {
+		return err
+	}
+	if approval != nil {
+		loan.Approvals = append(loan.Approvals, *approval)
+	}
+	return nil
+}
+
+// requiresSecondApproval reports whether the loan amount is above its
+// product's second approval threshold. When the threshold cannot be checked
+// the loan is not approved at all, and a threshold in another currency than
+// the loan always asks for a second approval.
+func (d *loanDecider) requiresSecondApproval(loan *models.Loan) (bool, error) {
+	if loan.ProductID.IsZero() {
+		return false, fmt.Errorf("%w: the loan has no product to check its approval threshold against", models.ErrInvalidTransition)
+	}
+	product, err := d.productRepo.GetProductByID(loan.ProductID.Hex())
+	if err != nil {
+		return false, err
+	}
+	if product.SecondApprovalThreshold == nil {
+		return false, nil
+	}
+	threshold := *product.SecondApprovalThreshold
+	if threshold.Currency != loan.Amount.Currency {
+		return true, nil
+	}
+	return loan.Amount.Cmp(threshold) > 0, nil
+}
+
+// documentsComplete reports whether every document the loan's product
+// requires has been verified.
+func (d *loanDecider) documentsComplete(loan *models.Loan) (bool, error) {
+	if loan.ProductID.IsZero() {
+		return true, nil
+	}
+	product, err := d.productRepo.GetProductByID(loan.ProductID.Hex())
+	if err != nil {
+		return false, err
+	}
+	if len(product.RequiredDocuments) == 0 {
+		return true, nil
+	}
+	documents, err := d.documentRepo.GetDocuments(loan.ID.Hex())
+	if err != nil {
+		return false, err
+	}
+	_, complete := models.DocumentChecklist(product.RequiredDocuments, documents)
+	return complete, nil
+}
+
+// checkCollateral returns ErrLTVExceeded when the loan's product is secured
+// and its collateral does not cover the loan within the product's maximum
+// loan-to-value ratio.
+func (d *loanDecider) checkCollateral(loan *models.Loan) error {
+	if loan.ProductID.IsZero() {
+		return nil
+	}
+	product, err := d.productRepo.GetProductByID(loan.ProductID.Hex())
+	if err != nil {
+		return err
+	}
+	if product.MaxLTV <= 0 {
+		return nil
+	}
+	collateral, err := d.collateralRepo.GetCollateralByLoan(loan.ID.Hex())
+	if err != nil {
+		return err
+	}
+	ltv, ok := models.LoanToValue(loan.Amount, models.CollateralValue(collateral, loan.Amount.Currency))
+	if !ok {
+		return fmt.Errorf("%w: no collateral is recorded", models.ErrLTVExceeded)
+	}
+	if ltv > product.MaxLTV {
+		return fmt.Errorf("%w: loan-to-value %.4g is above %.4g", models.ErrLTVExceeded, ltv, product.MaxLTV)
+	}
+	return nil
+}
+
+func firstApprover(loan *models.Loan) string {
+	if len(loan.Approvals) == 0 {
+		return ""
+	}
+	return loan.Approvals[len(loan.Approvals)-1].AdminID
+}
@@ -0,0 +1,189 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/infrastructures/services/email_service"
+	"LoanGuard/internal/repository/interfaces"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var partyEmailPattern = regexp.MustCompile(`^[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}$`)
+
+type ILoanPartyUsecase interface {
+	InviteParty(loanID string, actorID string, req dtos.PartyInvitationDTO) (*models.LoanParty, error)
+	WithdrawParty(loanID string, partyID string, actorID string) error
+	RespondToInvitation(loanID string, userID string, accept bool, req dtos.PartyResponseDTO) (*models.LoanParty, error)
+}
+
+type LoanPartyUsecase struct {
+	loanRepo repository_interface.ILoanRepository
+	userRepo repository_interface.IUserRepository
+	logRepo  repository_interface.ILogRepository
+	emailSvc email_service.IEmailService
+}
+
+func NewLoanPartyUsecase(loanRepo repository_interface.ILoanRepository, userRepo repository_interface.IUserRepository, logRepo repository_interface.ILogRepository, emailSvc email_service.IEmailService) ILoanPartyUsecase {
+	return &LoanPartyUsecase{
+		loanRepo: loanRepo,
+		userRepo: userRepo,
+		logRepo:  logRepo,
+		emailSvc: emailSvc,
+	}
+}
+
+// InviteParty nominates a guarantor or co-borrower for the loan and emails
+// them an invitation. Nominations are only taken until the loan is decided,
+// and each email address can be nominated once.
+func (pu *LoanPartyUsecase) InviteParty(loanID string, actorID string, req dtos.PartyInvitationDTO) (*models.LoanParty, error) {
+	loan, err := pu.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, models.ErrLoanNotFound
+	}
+	if !acceptsParties(loan) {
+		return nil, fmt.Errorf("%w: parties cannot be added to a %s loan", models.ErrInvalidParty, loan.Status)
+	}
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if !partyEmailPattern.MatchString(email) {
+		return nil, fmt.Errorf("%w: a valid email is required", models.ErrInvalidParty)
+	}
+	consent, ok := models.ConsentStatements[req.Role]
+	if !ok {
+		return nil, fmt.Errorf("%w: role must be %s or %s", models.ErrInvalidParty, models.PartyRoleGuarantor, models.PartyRoleCoBorrower)
+	}
+	borrower, err := pu.userRepo.GetUserByID(loan.UserId.Hex())
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(borrower.Email, email) {
+		return nil, fmt.Errorf("%w: the borrower cannot be their own %s", models.ErrInvalidParty, req.Role)
+	}
+	for _, party := range loan.Parties {
+		if strings.EqualFold(party.Email, email) {
+			return nil, fmt.Errorf("%w: %s has already been invited", models.ErrInvalidParty, email)
+		}
+	}
+
+	party := models.LoanParty{
+		ID:        primitive.NewObjectID(),
+		Email:     email,
+		Role:      req.Role,
+		Status:    models.PartyStatusInvited,
+		InvitedAt: time.Now(),
+	}
+	if user, err := pu.userRepo.GetUserByEmail(email); err == nil {
+		party.UserID = user.ID
+	}
+	if err := pu.loanRepo.AddLoanParty(loanID, party); err != nil {
+		return nil, err
+	}
+	pu.logParty(actorID, loanID, "Invited "+email+" as "+req.Role)
+
+	err = pu.emailSvc.SendPartyInvitationEmail(email, email_service.PartyInvitationEmail{
+		BorrowerName: borrower.Name,
+		Role:         strings.ReplaceAll(req.Role, "_", "-"),
+		LoanID:       loanID,
+		Amount:       loan.Amount.String(),
+		Consent:      consent,
+	})
+	if err != nil {
+		pu.logParty(actorID, loanID, "Invitation email to "+email+" not sent: "+err.Error())
+	}
+	return &party, nil
+}
+
+// WithdrawParty removes a nomination before the loan is decided, which is
+// how a declined invitation stops blocking approval.
+func (pu *LoanPartyUsecase) WithdrawParty(loanID string, partyID string, actorID string) error {
+	loan, err := pu.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return models.ErrLoanNotFound
+	}
+	if !acceptsParties(loan) {
+		return fmt.Errorf("%w: parties cannot be removed from a %s loan", models.ErrInvalidParty, loan.Status)
+	}
+	party := findParty(loan, func(p models.LoanParty) bool { return p.ID.Hex() == partyID })
+	if party == nil {
+		return models.ErrPartyNotFound
+	}
+	if err := pu.loanRepo.RemoveLoanParty(loanID, partyID); err != nil {
+		return err
+	}
+	pu.logParty(actorID, loanID, "Withdrew invitation of "+party.Email+" as "+party.Role)
+	return nil
+}
+
+// RespondToInvitation records the caller's answer to their invitation on
+// the loan. Invitations are matched on the caller's account email. Accepting
+// needs explicit consent and verified KYC, the same as borrowing would.
+func (pu *LoanPartyUsecase) RespondToInvitation(loanID string, userID string, accept bool, req dtos.PartyResponseDTO) (*models.LoanParty, error) {
+	user, err := pu.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, models.ErrPartyNotFound
+	}
+	loan, err := pu.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, models.ErrPartyNotFound
+	}
+	party := findParty(loan, func(p models.LoanParty) bool { return strings.EqualFold(p.Email, user.Email) })
+	if party == nil {
+		return nil, models.ErrPartyNotFound
+	}
+	if party.Status != models.PartyStatusInvited {
+		return nil, fmt.Errorf("%w: invitation already %s", models.ErrInvalidParty, party.Status)
+	}
+	if !acceptsParties(loan) {
+		return nil, fmt.Errorf("%w: the loan is already %s", models.ErrInvalidParty, loan.Status)
+	}
+
+	now := time.Now()
+	party.UserID = user.ID
+	party.RespondedAt = &now
+	party.Status = models.PartyStatusDeclined
+	if accept {
+		if !req.Consent {
+			return nil, fmt.Errorf("%w: consent is required to accept", models.ErrInvalidParty)
+		}
+		if !user.KYCVerified() {
+			return nil, models.ErrKYCNotVerified
+		}
+		party.Status = models.PartyStatusAccepted
+		party.Consent = models.ConsentStatements[party.Role]
+	}
+	if err := pu.loanRepo.UpdateLoanParty(loanID, *party); err != nil {
+		return nil, err
+	}
+	pu.logParty(userID, loanID, "Invitation as "+party.Role+" "+party.Status)
+	return party, nil
+}
+
+func (pu *LoanPartyUsecase) logParty(actorID string, loanID string, action string) {
+	userID, _ := primitive.ObjectIDFromHex(actorID)
+	pu.logRepo.CreateLog(&models.SystemLog{
+		Action:    action,
+		Timestamp: time.Now(),
+		UserID:    userID,
+		LoanID:    loanID,
+	})
+}
+
+// acceptsParties reports whether the loan is still being applied for or
+// reviewed, the only time parties can join or leave it.
+func acceptsParties(loan *models.Loan) bool {
+	return loan.Status == models.LoanStatusDraft || inReview(loan)
+}
+
+func findParty(loan *models.Loan, match func(models.LoanParty) bool) *models.LoanParty {
+	for i := range loan.Parties {
+		if match(loan.Parties[i]) {
+			party := loan.Parties[i]
+			return &party
+		}
+	}
+	return nil
+}
@@ -1,39 +1,116 @@
 package usecases
 
 import (
+	"LoanGuard/internal/domain/dtos"
 	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/infrastructures/services"
+	"LoanGuard/internal/infrastructures/services/email_service"
 	"LoanGuard/internal/repository/interfaces"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// systemActor is recorded as the actor of changes nobody made by hand.
+const systemActor = "system"
+
+const (
+	defaultLoansPageSize = 20
+	maxLoansPageSize     = 100
+)
+
 type ILoanUsecase interface {
-	RequestLoan(userID string, loan *models.Loan) (*models.Loan, error)
-	ViewLoanStatus(loanID string) (string, error)
+	RequestLoan(userID string, req dtos.LoanRequestDTO, draft bool) (*dtos.LoanViewDTO, error)
+	SubmitLoan(loanID string, userID string) error
+	CancelLoan(loanID string, userID string, reason string) error
+	GetLoanDetail(loanID string) (*dtos.LoanDetailDTO, error)
+	GetBorrowerLoanDetail(loanID string, userID string) (*dtos.BorrowerLoanDetailDTO, error)
+	GetMyLoans(userID string, query dtos.MyLoansQueryDTO) (*dtos.MyLoansPageDTO, error)
+	GetLoanSchedule(loanID string) (*models.RepaymentSchedule, error)
+	GetLoanSchedules(loanID string) ([]models.RepaymentSchedule, error)
 }
 
 type LoanUsecase struct {
-	loanRepo repository_interface.ILoanRepository
-	logRepo  repository_interface.ILogRepository
+	loanRepo     repository_interface.ILoanRepository
+	logRepo      repository_interface.ILogRepository
+	scheduleRepo repository_interface.IScheduleRepository
+	productRepo  repository_interface.IProductRepository
+	userRepo     repository_interface.IUserRepository
+	policyRepo   repository_interface.IDecisionPolicyRepository
+	collateralRepo repository_interface.ICollateralRepository
+	disbursementRepo repository_interface.IDisbursementRepository
+	scheduleSvc  services.IScheduleService
+	scorer       Scorer
+	decider      *loanDecider
+	assigner     *loanAssigner
+	assignmentStrategy string
 }
 
-func NewLoanUsecase(loanRepo repository_interface.ILoanRepository, logRepo repository_interface.ILogRepository) ILoanUsecase {
+func NewLoanUsecase(loanRepo repository_interface.ILoanRepository, logRepo repository_interface.ILogRepository, scheduleRepo repository_interface.IScheduleRepository, productRepo repository_interface.IProductRepository, userRepo repository_interface.IUserRepository, policyRepo repository_interface.IDecisionPolicyRepository, documentRepo repository_interface.IDocumentRepository, collateralRepo repository_interface.ICollateralRepository, disbursementRepo repository_interface.IDisbursementRepository, scheduleSvc services.IScheduleService, emailSvc email_service.IEmailService, scorer Scorer, assignmentStrategy string) ILoanUsecase {
 	return &LoanUsecase{
 		loanRepo: loanRepo,
 		logRepo: logRepo,
+		scheduleRepo: scheduleRepo,
+		productRepo: productRepo,
+		userRepo: userRepo,
+		policyRepo: policyRepo,
+		collateralRepo: collateralRepo,
+		disbursementRepo: disbursementRepo,
+		scheduleSvc: scheduleSvc,
+		scorer: scorer,
+		decider: newLoanDecider(loanRepo, logRepo, productRepo, userRepo, documentRepo, collateralRepo, emailSvc),
+		assigner: newLoanAssigner(loanRepo, userRepo, logRepo),
+		assignmentStrategy: assignmentStrategy,
 	}
 }
 
-func (lu *LoanUsecase) RequestLoan(userID string, loan *models.Loan) (*models.Loan, error) {
-	loan.Status = "pending"
-	loan.CreatedAt = time.Now()
+// RequestLoan stores a new application from a KYC-verified borrower. Drafts
+// stay editable by the borrower until they are submitted, everything else is
+// scored and goes straight to pending. Only the fields in the request come
+// from the borrower; the rest of the loan starts empty.
+func (lu *LoanUsecase) RequestLoan(userID string, req dtos.LoanRequestDTO, draft bool) (*dtos.LoanViewDTO, error) {
+	if err := lu.requireKYC(userID); err != nil {
+		return nil, err
+	}
+	loan := &models.Loan{
+		ProductID:             req.ProductID,
+		Amount:                req.Amount,
+		Currency:              req.Currency,
+		Term:                  req.Term,
+		LoanPurpose:           req.LoanPurpose,
+		DeclaredMonthlyIncome: req.DeclaredMonthlyIncome,
+		CreatedAt:             time.Now(),
+		Status:                models.LoanStatusDraft,
+		StatusHistory:         []models.StatusChange{},
+	}
+	if !draft {
+		loan.Status = models.LoanStatusPending
+		loan.StatusHistory = append(loan.StatusHistory, models.StatusChange{
+			From:   models.LoanStatusDraft,
+			To:     models.LoanStatusPending,
+			Actor:  userID,
+			Reason: "submitted by borrower",
+			At:     loan.CreatedAt,
+		})
+	}
 	id, _ := primitive.ObjectIDFromHex(userID)
-	loan.Interest = 0.05 
-	
-	loan.Total = float32(loan.Amount) + (float32(loan.Amount) * loan.Interest)
 	loan.UserId = id
 
+	if err := lu.priceLoan(loan); err != nil {
+		return nil, err
+	}
+	if !draft {
+		score, err := lu.scorer.Score(loan)
+		if err != nil {
+			return nil, err
+		}
+		loan.CreditScore = score
+	}
+
 	result, err := lu.loanRepo.RequestLoan(loan)
 	if err != nil {
 		return nil, err
@@ -45,13 +122,448 @@ func (lu *LoanUsecase) RequestLoan(userID string, loan *models.Loan) (*models.Lo
 	}
 	lu.logRepo.CreateLog(&Newlog)
 
-	return result, nil
+	if !draft {
+		lu.autoDecide(result)
+		lu.autoAssign(result)
+	}
+	view := loanView(result, userID)
+	return &view, nil
+}
+
+// requireKYC refuses borrowers whose identity has not been verified.
+func (lu *LoanUsecase) requireKYC(userID string) error {
+	user, err := lu.userRepo.GetUserByID(userID)
+	if err != nil || !user.KYCVerified() {
+		return models.ErrKYCNotVerified
+	}
+	return nil
+}
+
+// priceLoan checks the request against its product and fills in the rate,
+// repayment method, origination fee and total repayable amount.
+func (lu *LoanUsecase) priceLoan(loan *models.Loan) error {
+	if loan.ProductID.IsZero() {
+		return errors.New("product_id is required")
+	}
+	product, err := lu.productRepo.GetProductByID(loan.ProductID.Hex())
+	if err != nil || !product.Active {
+		return errors.New("loan product not found")
+	}
+	if loan.Amount.Amount <= 0 {
+		return errors.New("amount must be greater than zero")
+	}
+	if loan.Currency == "" {
+		loan.Currency = loan.Amount.Currency
+	}
+	loan.Currency = strings.ToUpper(loan.Currency)
+	if loan.Currency != product.Currency || loan.Amount.Currency != product.Currency {
+		return fmt.Errorf("this product is only offered in %s", product.Currency)
+	}
+	if loan.Amount.Cmp(product.MinAmount) < 0 || loan.Amount.Cmp(product.MaxAmount) > 0 {
+		return fmt.Errorf("amount must be between %s and %s for this product", product.MinAmount, product.MaxAmount)
+	}
+	if !product.AllowsTerm(loan.Term) {
+		return fmt.Errorf("term of %d months is not offered for this product", loan.Term)
+	}
+
+	rate, err := product.RateFor(loan.Amount)
+	if err != nil {
+		return err
+	}
+	loan.Interest = rate
+	loan.RepaymentMethod = product.RepaymentMethod
+	loan.OriginationFee = loan.Amount.MulRate(product.OriginationFeeRate)
+
+	// the schedule is only persisted on approval, here it just prices the loan
+	preview, err := lu.scheduleSvc.GenerateSchedule(loan.Amount, rate, loan.Term, loan.RepaymentMethod, loan.CreatedAt)
+	if err != nil {
+		return err
+	}
+	loan.Total = loan.Amount.Add(preview.TotalInterest).Add(loan.OriginationFee)
+	return nil
+}
+
+func (lu *LoanUsecase) SubmitLoan(loanID string, userID string) error {
+	loan, err := lu.loanRepo.GetLoanByID(loanID)
+	if err != nil || loan.UserId.Hex() != userID {
+		return models.ErrLoanNotFound
+	}
+	if !models.CanTransitionLoan(loan.Status, models.LoanStatusPending) {
+		return fmt.Errorf("%w: cannot move loan from %s to %s", models.ErrInvalidTransition, loan.Status, models.LoanStatusPending)
+	}
+	if err := lu.requireKYC(userID); err != nil {
+		return err
+	}
+
+	score, err := lu.scorer.Score(loan)
+	if err != nil {
+		return err
+	}
+	if err := lu.loanRepo.UpdateCreditScore(loanID, score); err != nil {
+		return err
+	}
+	loan.CreditScore = score
+	// drafts cannot be assigned, so an officer on one came in with the request
+	if loan.Assignment != nil {
+		if err := lu.loanRepo.AssignLoan(loanID, nil, []string{models.LoanStatusDraft}); err != nil {
+			return err
+		}
+		loan.Assignment = nil
+	}
+	if err := transitionLoan(lu.loanRepo, lu.logRepo, loan, models.LoanStatusPending, userID, "submitted by borrower"); err != nil {
+		return err
+	}
+	lu.autoDecide(loan)
+	lu.autoAssign(loan)
+	return nil
+}
+
+// autoDecide runs the decision policy on a freshly submitted application:
+// it is approved, rejected or referred to manual review. Every matching rule
+// is logged. Without an enabled policy, or if deciding fails, the loan simply
+// stays pending for an admin.
+func (lu *LoanUsecase) autoDecide(loan *models.Loan) {
+	policy, err := lu.policyRepo.GetLatestPolicy()
+	if err != nil || policy == nil || !policy.Enabled {
+		return
+	}
+	user, _ := lu.userRepo.GetUserByID(loan.UserId.Hex())
+	decision := evaluatePolicy(policy, applicationAttributes(loan, user))
+
+	for _, rule := range decision.RulesHit {
+		lu.logRepo.CreateLog(&models.SystemLog{
+			Action:    fmt.Sprintf("Decision rule %q matched (policy version %d)", rule, policy.Version),
+			Timestamp: decision.DecidedAt,
+			LoanID:    loan.ID.Hex(),
+		})
+	}
+
+	if err := lu.applyAutoDecision(loan, &decision); err != nil {
+		lu.logRepo.CreateLog(&models.SystemLog{
+			Action:    "Automated decision failed, loan left for manual review: " + err.Error(),
+			Timestamp: time.Now(),
+			LoanID:    loan.ID.Hex(),
+		})
+	}
+}
+
+func (lu *LoanUsecase) applyAutoDecision(loan *models.Loan, decision *models.AutoDecision) error {
+	if err := lu.loanRepo.UpdateAutoDecision(loan.ID.Hex(), decision); err != nil {
+		return err
+	}
+	loan.AutoDecision = decision
+
+	reason := "decision policy: " + decision.Outcome
+	if decision.Rule != "" {
+		reason += " by rule " + strconv.Quote(decision.Rule)
+	}
+	// loans that need two admins, document checks, party consent or more
+	// collateral to approve are left for the admins
+	needsAdmins := false
+	if decision.Outcome == models.DecisionApprove {
+		documentsComplete, err := lu.decider.documentsComplete(loan)
+		if err != nil {
+			return err
+		}
+		collateralErr := lu.decider.checkCollateral(loan)
+		if collateralErr != nil && !errors.Is(collateralErr, models.ErrLTVExceeded) {
+			return collateralErr
+		}
+		needsSecond, err := lu.decider.requiresSecondApproval(loan)
+		if err != nil {
+			return err
+		}
+		switch {
+		case needsSecond:
+			needsAdmins = true
+			reason += ", referred because the amount needs two admin approvals"
+		case !documentsComplete:
+			needsAdmins = true
+			reason += ", referred because required documents are not verified yet"
+		case !loan.PartiesAccepted():
+			needsAdmins = true
+			reason += ", referred because guarantors or co-borrowers have not accepted yet"
+		case collateralErr != nil:
+			needsAdmins = true
+			reason += ", referred because the collateral does not cover the loan"
+		}
+	}
+	if err := transitionLoan(lu.loanRepo, lu.logRepo, loan, models.LoanStatusUnderReview, systemActor, reason); err != nil {
+		return err
+	}
+	switch {
+	case needsAdmins:
+		return nil
+	case decision.Outcome == models.DecisionApprove:
+		return lu.decider.decide(loan, systemActor, automatedDecision(models.LoanStatusApproved, reason))
+	case decision.Outcome == models.DecisionReject:
+		return lu.decider.decide(loan, systemActor, automatedDecision(models.LoanStatusRejected, reason))
+	}
+	return nil
+}
+
+// autoAssign gives a loan the decision policy left for review to an officer.
+// A loan that cannot be assigned waits for an admin to do it.
+func (lu *LoanUsecase) autoAssign(loan *models.Loan) {
+	if err := lu.assigner.autoAssign(loan, lu.assignmentStrategy); err != nil {
+		lu.logRepo.CreateLog(&models.SystemLog{
+			Action:    "Loan left unassigned: " + err.Error(),
+			Timestamp: time.Now(),
+			LoanID:    loan.ID.Hex(),
+		})
+	}
+}
+
+func automatedDecision(status string, note string) models.LoanDecision {
+	label := "Your application was rejected by our automated assessment"
+	if status == models.LoanStatusApproved {
+		label = "Your application was approved by our automated assessment"
+	}
+	return models.LoanDecision{
+		Status:      status,
+		ReasonCode:  models.ReasonCodeAutomated,
+		ReasonLabel: label,
+		Note:        note,
+	}
+}
+
+func (lu *LoanUsecase) CancelLoan(loanID string, userID string, reason string) error {
+	loan, err := lu.loanRepo.GetLoanByID(loanID)
+	if err != nil || loan.UserId.Hex() != userID {
+		return models.ErrLoanNotFound
+	}
+	if reason == "" {
+		reason = "cancelled by borrower"
+	}
+	// money may already be on its way once a payout has been requested
+	if _, err := lu.disbursementRepo.GetActiveDisbursement(loanID); err == nil {
+		return fmt.Errorf("%w: the loan is being paid out", models.ErrInvalidTransition)
+	}
+	if err := transitionLoan(lu.loanRepo, lu.logRepo, loan, models.LoanStatusCancelled, userID, reason); err != nil {
+		return err
+	}
+	return releaseLiens(lu.collateralRepo, lu.logRepo, loan, userID)
+}
+
+// GetLoanDetail returns the whole loan with its product, a summary of its
+// schedule and the next installment due, for admins.
+func (lu *LoanUsecase) GetLoanDetail(loanID string) (*dtos.LoanDetailDTO, error) {
+	loan, err := lu.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, models.ErrLoanNotFound
+	}
+	detail := &dtos.LoanDetailDTO{Loan: loan}
+	detail.Product, detail.Schedule, detail.NextDue = lu.loanSummary(loan)
+	return detail, nil
+}
+
+// GetBorrowerLoanDetail is GetLoanDetail for the borrower and the loan's
+// accepted parties, showing only the borrower's view of the loan.
+func (lu *LoanUsecase) GetBorrowerLoanDetail(loanID string, userID string) (*dtos.BorrowerLoanDetailDTO, error) {
+	loan, err := lu.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, models.ErrLoanNotFound
+	}
+	detail := &dtos.BorrowerLoanDetailDTO{LoanViewDTO: loanView(loan, userID)}
+	detail.Product, detail.Schedule, detail.NextDue = lu.loanSummary(loan)
+	return detail, nil
+}
+
+// loanSummary looks up the loan's product and summarises its schedule. Parts
+// that are not available yet are nil.
+func (lu *LoanUsecase) loanSummary(loan *models.Loan) (*dtos.LoanProductSummaryDTO, *dtos.ScheduleSummaryDTO, *dtos.NextDueDTO) {
+	var product *dtos.LoanProductSummaryDTO
+	if !loan.ProductID.IsZero() {
+		if found, err := lu.productRepo.GetProductByID(loan.ProductID.Hex()); err == nil {
+			product = &dtos.LoanProductSummaryDTO{
+				ID:          found.ID.Hex(),
+				Name:        found.Name,
+				Description: found.Description,
+				Currency:    found.Currency,
+			}
+		}
+	}
+
+	schedule, err := lu.scheduleRepo.GetScheduleByLoanID(loan.ID.Hex())
+	if err != nil || len(schedule.Installments) == 0 {
+		return product, nil, nil
+	}
+	summary := &dtos.ScheduleSummaryDTO{
+		Method:        schedule.Method,
+		Term:          schedule.Term,
+		Installments:  len(schedule.Installments),
+		TotalInterest: schedule.TotalInterest,
+		TotalPayment:  schedule.TotalPayment,
+		FinalDueDate:  schedule.Installments[len(schedule.Installments)-1].DueDate,
+	}
+	var nextDue *dtos.NextDueDTO
+	for _, inst := range schedule.Installments {
+		if inst.Paid {
+			summary.PaidInstallments++
+			continue
+		}
+		if nextDue == nil {
+			nextDue = &dtos.NextDueDTO{
+				Number:    inst.Number,
+				DueDate:   inst.DueDate,
+				AmountDue: inst.Payment.Sub(inst.PaidPrincipal).Sub(inst.PaidInterest),
+			}
+		}
+	}
+	return product, summary, nextDue
+}
+
+// loanView is the loan as viewerID, its borrower or one of its parties, may
+// see it. Nominees' emails are only shown to the borrower.
+func loanView(loan *models.Loan, viewerID string) dtos.LoanViewDTO {
+	view := dtos.LoanViewDTO{
+		ID:                   loan.ID.Hex(),
+		ProductID:            loan.ProductID.Hex(),
+		Amount:               loan.Amount,
+		Currency:             loan.Currency,
+		Interest:             loan.Interest,
+		Total:                loan.Total,
+		OriginationFee:       loan.OriginationFee,
+		Term:                 loan.Term,
+		RepaymentMethod:      loan.RepaymentMethod,
+		Status:               loan.Status,
+		LoanPurpose:          loan.LoanPurpose,
+		CreatedAt:            loan.CreatedAt,
+		OutstandingPrincipal: loan.OutstandingPrincipal,
+		OutstandingInterest:  loan.OutstandingInterest,
+		OutstandingFees:      loan.OutstandingFees,
+		OutstandingBalance:   loan.OutstandingBalance,
+		StatusHistory:        make([]dtos.StatusChangeDTO, 0, len(loan.StatusHistory)),
+	}
+	if loan.Decision != nil {
+		view.Decision = &dtos.DecisionViewDTO{
+			Status:      loan.Decision.Status,
+			ReasonCode:  loan.Decision.ReasonCode,
+			ReasonLabel: loan.Decision.ReasonLabel,
+			Note:        loan.Decision.Note,
+			DecidedAt:   loan.Decision.DecidedAt,
+		}
+	}
+	isBorrower := loan.UserId.Hex() == viewerID
+	for _, party := range loan.Parties {
+		partyView := dtos.PartyViewDTO{
+			ID:          party.ID.Hex(),
+			Role:        party.Role,
+			Status:      party.Status,
+			InvitedAt:   party.InvitedAt,
+			RespondedAt: party.RespondedAt,
+		}
+		if isBorrower {
+			partyView.Email = party.Email
+		}
+		view.Parties = append(view.Parties, partyView)
+	}
+	for _, change := range loan.StatusHistory {
+		view.StatusHistory = append(view.StatusHistory, dtos.StatusChangeDTO{From: change.From, To: change.To, At: change.At})
+	}
+	return view
 }
 
-func (lu *LoanUsecase) ViewLoanStatus(loanID string) (string, error) {
-	loanStatus, err := lu.loanRepo.ViewLoanStatus(loanID)
+// GetMyLoans lists the caller's loans newest first, including those they
+// accepted as a guarantor or co-borrower. Pages start at 1 and hold at most
+// maxLoansPageSize loans.
+func (lu *LoanUsecase) GetMyLoans(userID string, query dtos.MyLoansQueryDTO) (*dtos.MyLoansPageDTO, error) {
+	id, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		return "", err
+		return nil, errors.New("invalid user id")
+	}
+	if query.Page < 1 {
+		query.Page = 1
 	}
-	return loanStatus, nil
-}
\ No newline at end of file
+	if query.Limit < 1 {
+		query.Limit = defaultLoansPageSize
+	}
+	if query.Limit > maxLoansPageSize {
+		query.Limit = maxLoansPageSize
+	}
+
+	loans, total, err := lu.loanRepo.GetLoansByUser(models.LoanFilter{
+		UserID:      id,
+		Status:      query.Status,
+		CreatedFrom: query.CreatedFrom,
+		CreatedTo:   query.CreatedTo,
+		Skip:        int64((query.Page - 1) * query.Limit),
+		Limit:       int64(query.Limit),
+
+		IncludeParties: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]dtos.MyLoanDTO, 0, len(loans))
+	for _, loan := range loans {
+		role := loan.PartyRole(userID)
+		if loan.UserId == id {
+			role = "borrower"
+		}
+		items = append(items, dtos.MyLoanDTO{LoanViewDTO: loanView(&loan, userID), Role: role})
+	}
+	return &dtos.MyLoansPageDTO{
+		Loans: items,
+		Page:  query.Page,
+		Limit: query.Limit,
+		Total: total,
+	}, nil
+}
+
+func (lu *LoanUsecase) GetLoanSchedule(loanID string) (*models.RepaymentSchedule, error) {
+	schedule, err := lu.scheduleRepo.GetScheduleByLoanID(loanID)
+	if err != nil {
+		return nil, errors.New("repayment schedule not available until the loan is disbursed")
+	}
+	return schedule, nil
+}
+
+// GetLoanSchedules returns every version of the loan's schedule, oldest
+// first; the last one is in force.
+func (lu *LoanUsecase) GetLoanSchedules(loanID string) ([]models.RepaymentSchedule, error) {
+	return lu.scheduleRepo.GetSchedulesByLoanID(loanID)
+}
+
+// transitionLoan is the single place a loan changes status. It checks the
+// move against the lifecycle, writes it conditionally on the status the
+// loan was read with and records who made it and why.
+func transitionLoan(loanRepo repository_interface.ILoanRepository, logRepo repository_interface.ILogRepository, loan *models.Loan, to string, actor string, reason string) error {
+	return changeLoanStatus(logRepo, loan, to, actor, reason, func(change models.StatusChange) error {
+		return loanRepo.UpdateLoanStatus(loan.ID.Hex(), change)
+	})
+}
+
+// changeLoanStatus does the work of transitionLoan with write storing the
+// change, for status changes that store more along with it.
+func changeLoanStatus(logRepo repository_interface.ILogRepository, loan *models.Loan, to string, actor string, reason string, write func(models.StatusChange) error) error {
+	if !models.CanTransitionLoan(loan.Status, to) {
+		return fmt.Errorf("%w: cannot move loan from %s to %s", models.ErrInvalidTransition, loan.Status, to)
+	}
+
+	change := models.StatusChange{
+		From:   loan.Status,
+		To:     to,
+		Actor:  actor,
+		Reason: reason,
+		At:     time.Now(),
+	}
+	if err := write(change); err != nil {
+		return err
+	}
+	loan.Status = to
+	loan.StatusHistory = append(loan.StatusHistory, change)
+
+	actorID, _ := primitive.ObjectIDFromHex(actor)
+	action := "Loan status changed from " + change.From + " to " + change.To
+	if reason != "" {
+		action += ": " + reason
+	}
+	logRepo.CreateLog(&models.SystemLog{
+		Action:    action,
+		Timestamp: change.At,
+		UserID:    actorID,
+		LoanID:    loan.ID.Hex(),
+	})
+	return nil
+}
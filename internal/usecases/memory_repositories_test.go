@@ -0,0 +1,404 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/models"
+	repository_interface "LoanGuard/internal/repository/interfaces"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// The memory repositories below keep documents the way the Mongo ones do:
+// callers get copies, and conditional writes fail the same way. Methods a
+// test does not need panic through the embedded interface.
+
+type memoryLoanRepository struct {
+	repository_interface.ILoanRepository
+	loans map[primitive.ObjectID]models.Loan
+}
+
+func newMemoryLoanRepository(loans ...*models.Loan) *memoryLoanRepository {
+	repo := &memoryLoanRepository{loans: map[primitive.ObjectID]models.Loan{}}
+	for _, loan := range loans {
+		repo.loans[loan.ID] = *loan
+	}
+	return repo
+}
+
+func (r *memoryLoanRepository) GetLoanByID(loanID string) (*models.Loan, error) {
+	id, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		return nil, err
+	}
+	loan, ok := r.loans[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	loan.StatusHistory = append([]models.StatusChange{}, loan.StatusHistory...)
+	return &loan, nil
+}
+
+func (r *memoryLoanRepository) GetLoansByStatus(statuses []string) ([]models.Loan, error) {
+	loans := []models.Loan{}
+	for id := range r.loans {
+		loan, _ := r.GetLoanByID(id.Hex())
+		if containsString(statuses, loan.Status) {
+			loans = append(loans, *loan)
+		}
+	}
+	return loans, nil
+}
+
+func (r *memoryLoanRepository) UpdateLoanStatus(loanID string, change models.StatusChange) error {
+	id, _ := primitive.ObjectIDFromHex(loanID)
+	loan, ok := r.loans[id]
+	if !ok || loan.Status != change.From {
+		return models.ErrStatusConflict
+	}
+	loan.Status = change.To
+	loan.StatusHistory = append(loan.StatusHistory, change)
+	r.loans[id] = loan
+	return nil
+}
+
+func (r *memoryLoanRepository) UpdateLoanBalances(loan *models.Loan, previousBalance models.Money) error {
+	stored, ok := r.loans[loan.ID]
+	if !ok || stored.OutstandingBalance.Amount != previousBalance.Amount {
+		return errors.New("loan balance changed concurrently, please retry")
+	}
+	stored.OutstandingPrincipal = loan.OutstandingPrincipal
+	stored.OutstandingInterest = loan.OutstandingInterest
+	stored.OutstandingFees = loan.OutstandingFees
+	stored.OutstandingBalance = loan.OutstandingBalance
+	r.loans[loan.ID] = stored
+	return nil
+}
+
+func (r *memoryLoanRepository) ApplyRepaymentBalances(loan *models.Loan, previousBalance models.Money, repaymentID string) (bool, error) {
+	for _, applied := range r.loans[loan.ID].AppliedRepayments {
+		if applied == repaymentID {
+			return false, nil
+		}
+	}
+	if err := r.UpdateLoanBalances(loan, previousBalance); err != nil {
+		return false, err
+	}
+	stored := r.loans[loan.ID]
+	stored.AppliedRepayments = append(append([]string{}, stored.AppliedRepayments...), repaymentID)
+	r.loans[loan.ID] = stored
+	return true, nil
+}
+
+func (r *memoryLoanRepository) ChargeLateFee(loan *models.Loan, previousBalance models.Money, key string) (bool, error) {
+	for _, charged := range r.loans[loan.ID].LateFeesCharged {
+		if charged == key {
+			return false, nil
+		}
+	}
+	if err := r.UpdateLoanBalances(loan, previousBalance); err != nil {
+		return false, err
+	}
+	stored := r.loans[loan.ID]
+	stored.LateFeesCharged = append(append([]string{}, stored.LateFeesCharged...), key)
+	r.loans[loan.ID] = stored
+	return true, nil
+}
+
+func (r *memoryLoanRepository) UpdateDelinquency(loanID string, delinquency *models.Delinquency) error {
+	id, _ := primitive.ObjectIDFromHex(loanID)
+	loan, ok := r.loans[id]
+	if !ok {
+		return mongo.ErrNoDocuments
+	}
+	copied := *delinquency
+	loan.Delinquency = &copied
+	r.loans[id] = loan
+	return nil
+}
+
+// memoryScheduleRepository fails the next GetScheduleByLoanID with failNext
+// when it is set.
+type memoryScheduleRepository struct {
+	schedules []models.RepaymentSchedule
+	failNext  error
+}
+
+func (r *memoryScheduleRepository) CreateSchedule(schedule *models.RepaymentSchedule) (*models.RepaymentSchedule, error) {
+	for _, existing := range r.schedules {
+		if existing.LoanID == schedule.LoanID && existing.Version == schedule.Version {
+			return nil, models.ErrStatusConflict
+		}
+	}
+	if schedule.ID.IsZero() {
+		schedule.ID = primitive.NewObjectID()
+	}
+	r.schedules = append(r.schedules, copySchedule(*schedule))
+	return schedule, nil
+}
+
+func (r *memoryScheduleRepository) GetScheduleByLoanID(loanID string) (*models.RepaymentSchedule, error) {
+	if err := r.failNext; err != nil {
+		r.failNext = nil
+		return nil, err
+	}
+	schedules, _ := r.GetSchedulesByLoanID(loanID)
+	if len(schedules) == 0 {
+		return nil, models.ErrScheduleNotFound
+	}
+	return &schedules[len(schedules)-1], nil
+}
+
+// GetSchedulesByLoanID returns the loan's schedules by version, as they were
+// created in version order.
+func (r *memoryScheduleRepository) GetSchedulesByLoanID(loanID string) ([]models.RepaymentSchedule, error) {
+	schedules := []models.RepaymentSchedule{}
+	for _, schedule := range r.schedules {
+		if schedule.LoanID.Hex() == loanID {
+			schedules = append(schedules, copySchedule(schedule))
+		}
+	}
+	return schedules, nil
+}
+
+func (r *memoryScheduleRepository) UpdateInstallments(scheduleID string, installments []models.Installment) error {
+	for i := range r.schedules {
+		if r.schedules[i].ID.Hex() == scheduleID {
+			r.schedules[i].Installments = append([]models.Installment{}, installments...)
+			return nil
+		}
+	}
+	return mongo.ErrNoDocuments
+}
+
+func copySchedule(schedule models.RepaymentSchedule) models.RepaymentSchedule {
+	schedule.Installments = append([]models.Installment{}, schedule.Installments...)
+	return schedule
+}
+
+// memoryLedgerRepository fails the next CreateEntries with failNext when it
+// is set.
+type memoryLedgerRepository struct {
+	entries  []models.LedgerEntry
+	failNext error
+}
+
+func (r *memoryLedgerRepository) CreateEntries(entries []models.LedgerEntry) error {
+	if err := r.failNext; err != nil {
+		r.failNext = nil
+		return err
+	}
+	for _, entry := range entries {
+		if entry.Key == "" {
+			continue
+		}
+		for _, posted := range r.entries {
+			if posted.Key == entry.Key && posted.Account == entry.Account {
+				return models.ErrDuplicateLedgerEntry
+			}
+		}
+	}
+	r.entries = append(r.entries, entries...)
+	return nil
+}
+
+func (r *memoryLedgerRepository) GetEntriesByLoanID(loanID string) ([]models.LedgerEntry, error) {
+	entries := []models.LedgerEntry{}
+	for _, entry := range r.entries {
+		if entry.LoanID.Hex() == loanID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// balance is what the account holds on the debit side.
+func (r *memoryLedgerRepository) balance(account string) int64 {
+	var balance int64
+	for _, entry := range r.entries {
+		if entry.Account == account {
+			balance += entry.Debit.Amount - entry.Credit.Amount
+		}
+	}
+	return balance
+}
+
+// transactions counts the ledger transactions described as description.
+func (r *memoryLedgerRepository) transactions(description string) int {
+	seen := map[primitive.ObjectID]bool{}
+	for _, entry := range r.entries {
+		if entry.Description == description {
+			seen[entry.TransactionID] = true
+		}
+	}
+	return len(seen)
+}
+
+type memoryLogRepository struct {
+	repository_interface.ILogRepository
+	logs []models.SystemLog
+}
+
+func (r *memoryLogRepository) CreateLog(log *models.SystemLog) error {
+	r.logs = append(r.logs, *log)
+	return nil
+}
+
+// memoryDisbursementRepository enforces the unique idempotency key and the
+// one active disbursement per loan.
+type memoryDisbursementRepository struct {
+	disbursements []models.Disbursement
+}
+
+func (r *memoryDisbursementRepository) conflicts(disbursement *models.Disbursement) bool {
+	for _, existing := range r.disbursements {
+		if existing.ID == disbursement.ID {
+			continue
+		}
+		if existing.IdempotencyKey == disbursement.IdempotencyKey ||
+			(existing.Active && disbursement.Active && existing.LoanID == disbursement.LoanID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memoryDisbursementRepository) CreateDisbursement(disbursement *models.Disbursement) (*models.Disbursement, error) {
+	if disbursement.ID.IsZero() {
+		disbursement.ID = primitive.NewObjectID()
+	}
+	if r.conflicts(disbursement) {
+		return nil, models.ErrDisbursementExists
+	}
+	r.disbursements = append(r.disbursements, copyDisbursement(*disbursement))
+	return disbursement, nil
+}
+
+func (r *memoryDisbursementRepository) GetDisbursement(disbursementID string) (*models.Disbursement, error) {
+	return r.findOne(func(d models.Disbursement) bool { return d.ID.Hex() == disbursementID })
+}
+
+func (r *memoryDisbursementRepository) GetDisbursementByKey(idempotencyKey string) (*models.Disbursement, error) {
+	return r.findOne(func(d models.Disbursement) bool { return d.IdempotencyKey == idempotencyKey })
+}
+
+func (r *memoryDisbursementRepository) GetDisbursementsByLoan(loanID string) ([]models.Disbursement, error) {
+	return r.find(func(d models.Disbursement) bool { return d.LoanID.Hex() == loanID }), nil
+}
+
+func (r *memoryDisbursementRepository) GetActiveDisbursement(loanID string) (*models.Disbursement, error) {
+	return r.findOne(func(d models.Disbursement) bool { return d.LoanID.Hex() == loanID && d.Active })
+}
+
+func (r *memoryDisbursementRepository) GetDueDisbursements(now time.Time) ([]models.Disbursement, error) {
+	return r.find(func(d models.Disbursement) bool {
+		return d.Status == models.DisbursementStatusPending && d.NextAttemptAt != nil && !d.NextAttemptAt.After(now)
+	}), nil
+}
+
+func (r *memoryDisbursementRepository) GetUnbookedDisbursements() ([]models.Disbursement, error) {
+	return r.find(func(d models.Disbursement) bool {
+		return d.Status == models.DisbursementStatusSucceeded && d.BookedAt == nil
+	}), nil
+}
+
+func (r *memoryDisbursementRepository) UpdateDisbursement(disbursement *models.Disbursement) error {
+	if r.conflicts(disbursement) {
+		return models.ErrDisbursementExists
+	}
+	for i := range r.disbursements {
+		if r.disbursements[i].ID == disbursement.ID {
+			r.disbursements[i] = copyDisbursement(*disbursement)
+			return nil
+		}
+	}
+	return mongo.ErrNoDocuments
+}
+
+func (r *memoryDisbursementRepository) findOne(match func(models.Disbursement) bool) (*models.Disbursement, error) {
+	found := r.find(match)
+	if len(found) == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &found[0], nil
+}
+
+func (r *memoryDisbursementRepository) find(match func(models.Disbursement) bool) []models.Disbursement {
+	found := []models.Disbursement{}
+	for _, disbursement := range r.disbursements {
+		if match(disbursement) {
+			found = append(found, copyDisbursement(disbursement))
+		}
+	}
+	return found
+}
+
+func copyDisbursement(disbursement models.Disbursement) models.Disbursement {
+	disbursement.Attempts = append([]models.PayoutAttempt{}, disbursement.Attempts...)
+	return disbursement
+}
+
+// memoryRepaymentRepository keeps references unique like the Mongo index.
+type memoryRepaymentRepository struct {
+	repayments []models.Repayment
+}
+
+func (r *memoryRepaymentRepository) CreateRepayment(repayment *models.Repayment) (*models.Repayment, error) {
+	for _, existing := range r.repayments {
+		if repayment.Reference != "" && existing.Reference == repayment.Reference {
+			return nil, models.ErrDuplicateRepayment
+		}
+	}
+	if repayment.ID.IsZero() {
+		repayment.ID = primitive.NewObjectID()
+	}
+	r.repayments = append(r.repayments, *repayment)
+	return repayment, nil
+}
+
+func (r *memoryRepaymentRepository) GetRepaymentsByLoanID(loanID string) ([]models.Repayment, error) {
+	return r.find(func(p models.Repayment) bool { return p.LoanID.Hex() == loanID }), nil
+}
+
+func (r *memoryRepaymentRepository) GetRepaymentByReference(reference string) (*models.Repayment, error) {
+	found := r.find(func(p models.Repayment) bool { return p.Reference == reference })
+	if len(found) == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &found[0], nil
+}
+
+func (r *memoryRepaymentRepository) GetPendingRepayments(loanID string) ([]models.Repayment, error) {
+	return r.find(func(p models.Repayment) bool { return p.LoanID.Hex() == loanID && p.Pending() }), nil
+}
+
+func (r *memoryRepaymentRepository) MarkRepaymentApplied(repaymentID string, at time.Time) error {
+	for i := range r.repayments {
+		if r.repayments[i].ID.Hex() == repaymentID {
+			r.repayments[i].AppliedAt = &at
+			return nil
+		}
+	}
+	return mongo.ErrNoDocuments
+}
+
+func (r *memoryRepaymentRepository) DeleteRepayment(repaymentID string) error {
+	for i := range r.repayments {
+		if r.repayments[i].ID.Hex() == repaymentID {
+			r.repayments = append(r.repayments[:i], r.repayments[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepaymentRepository) find(match func(models.Repayment) bool) []models.Repayment {
+	found := []models.Repayment{}
+	for _, repayment := range r.repayments {
+		if match(repayment) {
+			found = append(found, repayment)
+		}
+	}
+	return found
+}
@@ -0,0 +1,388 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/models"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// payoffPosition is where a loan stands on its schedule on a given day.
+// Installments before current have fallen due; current is the installment
+// whose period the day falls in, or len(installments) when every one has.
+type payoffPosition struct {
+	current          int
+	arrearsPrincipal models.Money
+	arrearsInterest  models.Money
+
+	// the interest the current installment has earned so far, at least what
+	// was already paid towards it or later installments, and what of it is
+	// still owed
+	accrued     models.Money
+	accruedOwed models.Money
+	// principal already paid towards the current and later installments
+	paidAhead models.Money
+
+	elapsedDays int
+	periodDays  int
+}
+
+func loanPosition(schedule *models.RepaymentSchedule, asOf time.Time) payoffPosition {
+	zero := models.NewMoney(0, schedule.Principal.Currency)
+	pos := payoffPosition{
+		current:          len(schedule.Installments),
+		arrearsPrincipal: zero,
+		arrearsInterest:  zero,
+		accrued:          zero,
+		accruedOwed:      zero,
+		paidAhead:        zero,
+	}
+	for i := range schedule.Installments {
+		inst := &schedule.Installments[i]
+		if models.DaysBetween(asOf, inst.DueDate) > 0 {
+			pos.current = i
+			break
+		}
+		if !inst.Paid {
+			pos.arrearsPrincipal = pos.arrearsPrincipal.Add(inst.Principal.Sub(inst.PaidPrincipal))
+			pos.arrearsInterest = pos.arrearsInterest.Add(inst.Interest.Sub(inst.PaidInterest))
+		}
+	}
+	if pos.current == len(schedule.Installments) {
+		return pos
+	}
+
+	paidInterest := zero
+	for _, inst := range schedule.Installments[pos.current:] {
+		paidInterest = paidInterest.Add(inst.PaidInterest)
+		pos.paidAhead = pos.paidAhead.Add(inst.PaidPrincipal)
+	}
+	inst := &schedule.Installments[pos.current]
+	start := periodStart(schedule, pos.current)
+	pos.periodDays = models.DaysBetween(start, inst.DueDate)
+	pos.elapsedDays = models.DaysBetween(start, asOf)
+	if pos.elapsedDays < 0 {
+		pos.elapsedDays = 0
+	}
+	if pos.periodDays > 0 {
+		pos.accrued = inst.Interest.Mul(big.NewRat(int64(pos.elapsedDays), int64(pos.periodDays)))
+	}
+	if paidInterest.Cmp(pos.accrued) > 0 {
+		pos.accrued = paidInterest
+	}
+	pos.accruedOwed = pos.accrued.Sub(paidInterest)
+	return pos
+}
+
+// periodStart is the day interest starts running for installment i: the
+// previous due date, or a month before the first one.
+func periodStart(schedule *models.RepaymentSchedule, i int) time.Time {
+	if i == 0 {
+		return models.AddMonths(schedule.Installments[0].DueDate, -1)
+	}
+	return schedule.Installments[i-1].DueDate
+}
+
+// GetPayoffQuote works out what closes the loan with a payment received on
+// date, which defaults to today and cannot be in the past. Nothing is
+// stored: the quote's ID is derived from the figures it quotes, so a payment
+// that names it is checked by quoting the loan again.
+func (ru *RepaymentUsecase) GetPayoffQuote(loanID string, date time.Time) (*models.PayoffQuote, error) {
+	today := time.Now().UTC()
+	if date.IsZero() {
+		date = today
+	}
+	asOf := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	if models.DaysBetween(today, asOf) < 0 {
+		return nil, fmt.Errorf("%w: date cannot be in the past", models.ErrInvalidQuery)
+	}
+
+	loan, err := ru.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, models.ErrLoanNotFound
+	}
+	switch loan.Status {
+	case models.LoanStatusDisbursed, models.LoanStatusActive, models.LoanStatusDefaulted:
+	default:
+		return nil, fmt.Errorf("%w: a %s loan cannot be paid off", models.ErrInvalidTransition, loan.Status)
+	}
+	schedule, err := ru.scheduleRepo.GetScheduleByLoanID(loanID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: loan has no repayment schedule", models.ErrInvalidTransition)
+	}
+
+	quote, _, err := ru.payoffQuote(loan, schedule, asOf)
+	return quote, err
+}
+
+func (ru *RepaymentUsecase) payoffQuote(loan *models.Loan, schedule *models.RepaymentSchedule, asOf time.Time) (*models.PayoffQuote, payoffPosition, error) {
+	pos := loanPosition(schedule, asOf)
+	penalty := models.NewMoney(0, loan.OutstandingBalance.Currency)
+	policy, err := ru.prepaymentPenalty(loan)
+	if err != nil {
+		return nil, pos, err
+	}
+	if prepaid := loan.OutstandingPrincipal.Sub(pos.arrearsPrincipal); policy != nil && prepaid.Amount > 0 {
+		penalty = policy.Fee(prepaid, pos.current)
+	}
+
+	quote := &models.PayoffQuote{
+		LoanID:            loan.ID,
+		AsOf:              asOf,
+		Principal:         loan.OutstandingPrincipal,
+		AccruedInterest:   pos.arrearsInterest.Add(pos.accruedOwed),
+		Fees:              loan.OutstandingFees,
+		PrepaymentPenalty: penalty,
+	}
+	quote.Total = quote.Principal.Add(quote.AccruedInterest).Add(quote.Fees).Add(quote.PrepaymentPenalty)
+	quote.ExpiresAt = asOf.AddDate(0, 0, 1)
+	quote.ID = quote.Fingerprint()
+	return quote, pos, nil
+}
+
+// repayEarly applies a payment that repays principal ahead of schedule,
+// either closing the loan on a payoff quote or prepaying part of it. The
+// payment settles the outstanding fees, the installments that have fallen
+// due and the interest the current installment has accrued; what is left,
+// less the product's prepayment penalty, repays principal. The rest of the
+// principal is rescheduled over the remaining due dates, in fewer
+// installments or smaller ones as the borrower chose, and scheduled interest
+// that is no longer charged is released. It works out how the payment changes
+// the loan, including the ledger credits it settles, without writing any of
+// it.
+func (ru *RepaymentUsecase) repayEarly(loan *models.Loan, schedule *models.RepaymentSchedule, repayment *models.Repayment) (*models.RepaymentApplication, error) {
+	var pos payoffPosition
+	var prepaid, penalty models.Money
+	notYetDue := func(pos payoffPosition) models.Money {
+		return loan.OutstandingPrincipal.Sub(pos.arrearsPrincipal)
+	}
+
+	if repayment.QuoteID != nil {
+		asOf := utcDay(repayment.ReceivedAt)
+		quote, currentPos, err := ru.payoffQuote(loan, schedule, asOf)
+		if err != nil {
+			return nil, err
+		}
+		if quote.ID != *repayment.QuoteID {
+			return nil, fmt.Errorf("%w: it does not match the loan's payoff on %s, get a new quote", models.ErrQuoteExpired, asOf.Format("2006-01-02"))
+		}
+		if repayment.Amount.Cmp(quote.Total) != 0 {
+			return nil, fmt.Errorf("%w: the payoff amount is %s", models.ErrInvalidPrepayment, quote.Total.String())
+		}
+		pos = currentPos
+		prepaid = notYetDue(pos)
+		penalty = quote.PrepaymentPenalty
+		repayment.Prepayment = ""
+	} else {
+		if repayment.Prepayment != models.PrepaymentShortenTerm && repayment.Prepayment != models.PrepaymentReduceInstallment {
+			return nil, fmt.Errorf("%w: prepayment must be shorten_term or reduce_installment", models.ErrInvalidPrepayment)
+		}
+		pos = loanPosition(schedule, repayment.ReceivedAt)
+		due := loan.OutstandingFees.Add(pos.arrearsPrincipal).Add(pos.arrearsInterest).Add(pos.accruedOwed)
+		rest := repayment.Amount.Sub(due)
+		if rest.Amount <= 0 {
+			return nil, fmt.Errorf("%w: the payment only covers the %s due, record it as a regular repayment", models.ErrInvalidPrepayment, due.String())
+		}
+		prepaid, penalty = rest, models.NewMoney(0, rest.Currency)
+		policy, err := ru.prepaymentPenalty(loan)
+		if err != nil {
+			return nil, err
+		}
+		if policy != nil {
+			prepaid, penalty = policy.Split(rest, pos.current)
+		}
+		if prepaid.Amount <= 0 {
+			return nil, fmt.Errorf("%w: the payment does not cover the prepayment penalty", models.ErrInvalidPrepayment)
+		}
+		if prepaid.Cmp(notYetDue(pos)) >= 0 {
+			return nil, fmt.Errorf("%w: the payment would repay the loan in full, request a payoff quote instead", models.ErrInvalidPrepayment)
+		}
+	}
+
+	remaining := notYetDue(pos).Sub(prepaid)
+	term := 0
+	if !remaining.IsZero() {
+		var err error
+		if term, err = ru.prepaymentTerm(schedule, pos, remaining, repayment.Prepayment); err != nil {
+			return nil, err
+		}
+	}
+	rescheduled, err := ru.reschedule(schedule, pos, prepaid, remaining, term)
+	if err != nil {
+		return nil, err
+	}
+
+	feesPaid := loan.OutstandingFees
+	interestPaid := pos.arrearsInterest.Add(pos.accruedOwed)
+	principalPaid := pos.arrearsPrincipal.Add(prepaid)
+	scheduledInterest := models.NewMoney(0, loan.OutstandingBalance.Currency)
+	for _, inst := range rescheduled.Installments {
+		scheduledInterest = scheduledInterest.Add(inst.Interest.Sub(inst.PaidInterest))
+	}
+	released := loan.OutstandingInterest.Sub(interestPaid).Sub(scheduledInterest)
+	rescheduled.ID = primitive.NewObjectID()
+
+	repayment.FeesPaid = feesPaid.Add(penalty)
+	repayment.InterestPaid = interestPaid
+	repayment.PrincipalPaid = principalPaid
+	return &models.RepaymentApplication{
+		BalanceBefore:        loan.OutstandingBalance,
+		OutstandingPrincipal: remaining,
+		OutstandingInterest:  scheduledInterest,
+		OutstandingFees:      models.NewMoney(0, loan.OutstandingBalance.Currency),
+		ScheduleID:           schedule.ID,
+		Schedule:             rescheduled,
+		ReleasedInterest:     released,
+		Postings: []models.LedgerEntry{
+			credit(models.AccountFeesReceivable, feesPaid),
+			credit(models.AccountPrepaymentFeeIncome, penalty),
+			credit(models.AccountInterestReceivable, interestPaid),
+			credit(models.AccountPrincipalReceivable, principalPaid),
+		},
+	}, nil
+}
+
+// prepaymentTerm is how many installments the remaining principal is
+// rescheduled over: as many as are left, or when shortening the term the
+// fewest whose installment is no larger than the current one.
+func (ru *RepaymentUsecase) prepaymentTerm(schedule *models.RepaymentSchedule, pos payoffPosition, remaining models.Money, option string) (int, error) {
+	left := len(schedule.Installments) - pos.current
+	if option != models.PrepaymentShortenTerm {
+		return left, nil
+	}
+	target := schedule.Installments[pos.current].Payment
+	for term := 1; term < left; term++ {
+		candidate, err := ru.scheduleSvc.GenerateSchedule(remaining, schedule.AnnualRate, term, schedule.Method, periodStart(schedule, pos.current))
+		if err != nil {
+			return 0, err
+		}
+		if candidate.Installments[0].Payment.Cmp(target) <= 0 {
+			return term, nil
+		}
+	}
+	return left, nil
+}
+
+// reschedule builds the schedule left after an early repayment. Installments
+// that had fallen due are settled as they were. The current installment
+// carries the interest accrued so far and the principal repaid early, both
+// paid, plus the first installment of the remaining principal rescheduled
+// over term installments, whose interest only runs for the rest of the
+// period. Rescheduled installments keep the original due dates.
+func (ru *RepaymentUsecase) reschedule(schedule *models.RepaymentSchedule, pos payoffPosition, prepaid models.Money, remaining models.Money, term int) (*models.RepaymentSchedule, error) {
+	installments := make([]models.Installment, 0, pos.current+term)
+	for _, inst := range schedule.Installments[:pos.current] {
+		inst.PaidPrincipal = inst.Principal
+		inst.PaidInterest = inst.Interest
+		inst.Paid = true
+		installments = append(installments, inst)
+	}
+
+	if pos.current < len(schedule.Installments) {
+		current := schedule.Installments[pos.current]
+		paidPrincipal := pos.paidAhead.Add(prepaid)
+		next := []models.Installment{{
+			Number:  current.Number,
+			DueDate: current.DueDate,
+			Balance: models.NewMoney(0, remaining.Currency),
+		}}
+		if !remaining.IsZero() {
+			generated, err := ru.scheduleSvc.GenerateSchedule(remaining, schedule.AnnualRate, term, schedule.Method, periodStart(schedule, pos.current))
+			if err != nil {
+				return nil, err
+			}
+			next = generated.Installments
+			if pos.periodDays > 0 {
+				next[0].Interest = next[0].Interest.Mul(big.NewRat(int64(pos.periodDays-pos.elapsedDays), int64(pos.periodDays)))
+			}
+		} else {
+			next[0].Principal = models.NewMoney(0, remaining.Currency)
+			next[0].Interest = models.NewMoney(0, remaining.Currency)
+		}
+		next[0].Principal = next[0].Principal.Add(paidPrincipal)
+		next[0].Interest = next[0].Interest.Add(pos.accrued)
+		next[0].PaidPrincipal = paidPrincipal
+		next[0].PaidInterest = pos.accrued
+		for i := range next {
+			inst := &next[i]
+			inst.Number = current.Number + i
+			inst.DueDate = schedule.Installments[pos.current+i].DueDate
+			inst.Payment = inst.Principal.Add(inst.Interest)
+			inst.Paid = inst.PaidPrincipal.Cmp(inst.Principal) >= 0 && inst.PaidInterest.Cmp(inst.Interest) >= 0
+		}
+		installments = append(installments, next...)
+	}
+
+	rescheduled := &models.RepaymentSchedule{
+		LoanID:        schedule.LoanID,
+		Method:        schedule.Method,
+		Principal:     schedule.Principal,
+		AnnualRate:    schedule.AnnualRate,
+		Term:          len(installments),
+		TotalInterest: models.NewMoney(0, schedule.Principal.Currency),
+		TotalPayment:  models.NewMoney(0, schedule.Principal.Currency),
+		Installments:  installments,
+		CreatedAt:     time.Now(),
+		Version:       schedule.NextVersion(),
+	}
+	for _, inst := range installments {
+		rescheduled.TotalInterest = rescheduled.TotalInterest.Add(inst.Interest)
+		rescheduled.TotalPayment = rescheduled.TotalPayment.Add(inst.Payment)
+	}
+	return rescheduled, nil
+}
+
+// releaseInterest reverses scheduled interest that the loan will no longer
+// earn out of unearned interest. Interest already recognised beyond what is
+// still unearned is reversed out of income, so unearned interest never goes
+// negative. A negative amount books extra interest. It is posted under key,
+// once.
+func (ru *RepaymentUsecase) releaseInterest(loan *models.Loan, released models.Money, key string) error {
+	if released.IsZero() {
+		return nil
+	}
+	unearned, err := unearnedInterest(ru.ledgerRepo, loan)
+	if err != nil {
+		return err
+	}
+	if unearned.IsNegative() {
+		unearned = models.NewMoney(0, released.Currency)
+	}
+	fromUnearned := released.Min(unearned)
+	postings := []models.LedgerEntry{
+		debit(models.AccountUnearnedInterest, fromUnearned),
+		debit(models.AccountInterestIncome, released.Sub(fromUnearned)),
+		credit(models.AccountInterestReceivable, released),
+	}
+	if released.IsNegative() {
+		booked := models.NewMoney(-released.Amount, released.Currency)
+		postings = []models.LedgerEntry{
+			debit(models.AccountInterestReceivable, booked),
+			credit(models.AccountUnearnedInterest, booked),
+		}
+	}
+	entries, err := newKeyedLedgerTransaction(loan.ID, key, "Scheduled interest released on early repayment", postings...)
+	if err != nil {
+		return err
+	}
+	if err := ru.ledgerRepo.CreateEntries(entries); err != nil && !errors.Is(err, models.ErrDuplicateLedgerEntry) {
+		return err
+	}
+	return nil
+}
+
+// prepaymentPenalty returns the penalty the loan's product charges on early
+// repayment, nil when it charges none.
+func (ru *RepaymentUsecase) prepaymentPenalty(loan *models.Loan) (*models.PrepaymentPenalty, error) {
+	if loan.ProductID.IsZero() {
+		return nil, nil
+	}
+	product, err := ru.productRepo.GetProductByID(loan.ProductID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	return product.PrepaymentPenalty, nil
+}
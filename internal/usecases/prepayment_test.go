@@ -0,0 +1,211 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/infrastructures/services"
+	repository_interface "LoanGuard/internal/repository/interfaces"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryProductRepository serves one product; every other method panics.
+type memoryProductRepository struct {
+	repository_interface.IProductRepository
+	product *models.LoanProduct
+}
+
+func (r *memoryProductRepository) GetProductByID(productID string) (*models.LoanProduct, error) {
+	return r.product, nil
+}
+
+func usd(minor int64) models.Money {
+	return models.NewMoney(minor, "USD")
+}
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+// bookedLoan returns a loan on a fresh monthly schedule starting from start,
+// with its first paid installments settled and the balances that leaves.
+func bookedLoan(t *testing.T, principal models.Money, rate float64, term int, start time.Time, paid int, penalty *models.PrepaymentPenalty) (*RepaymentUsecase, *models.Loan, *models.RepaymentSchedule) {
+	t.Helper()
+	svc := services.NewScheduleService()
+	schedule, err := svc.GenerateSchedule(principal, rate, term, models.RepaymentMethodAnnuity, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schedule.ID = primitive.NewObjectID()
+	schedule.Version = 1
+
+	loan := &models.Loan{ID: primitive.NewObjectID(), OutstandingPrincipal: usd(0), OutstandingInterest: usd(0), OutstandingFees: usd(0)}
+	for i := range schedule.Installments {
+		inst := &schedule.Installments[i]
+		inst.PaidPrincipal, inst.PaidInterest = usd(0), usd(0)
+		if i < paid {
+			inst.PaidPrincipal, inst.PaidInterest, inst.Paid = inst.Principal, inst.Interest, true
+			continue
+		}
+		loan.OutstandingPrincipal = loan.OutstandingPrincipal.Add(inst.Principal)
+		loan.OutstandingInterest = loan.OutstandingInterest.Add(inst.Interest)
+	}
+	loan.OutstandingBalance = loan.OutstandingPrincipal.Add(loan.OutstandingInterest)
+
+	ru := &RepaymentUsecase{scheduleSvc: svc}
+	if penalty != nil {
+		loan.ProductID = primitive.NewObjectID()
+		ru.productRepo = &memoryProductRepository{product: &models.LoanProduct{PrepaymentPenalty: penalty}}
+	}
+	return ru, loan, schedule
+}
+
+func TestPayoffQuote(t *testing.T) {
+	tests := []struct {
+		name     string
+		paid     int
+		asOf     time.Time
+		penalty  *models.PrepaymentPenalty
+		interest models.Money
+		fee      models.Money
+	}{
+		// 100.00 of January interest over 31 days, 10 of them elapsed
+		{"accrued interest rounds to the cent", 0, day(2026, 1, 11), nil, usd(3226), usd(0)},
+		{"on a due date the period has fully accrued", 0, day(2026, 2, 1), nil, usd(10000), usd(0)},
+		// 0.00025% of 10000.00 is 2.5 cents
+		{"penalty rate rounds half to even", 0, day(2026, 1, 1), &models.PrepaymentPenalty{FlatFee: usd(100), Rate: 0.0000025}, usd(0), usd(102)},
+		{"penalty waived once enough installments fell due", 1, day(2026, 2, 1), &models.PrepaymentPenalty{FlatFee: usd(2500), Rate: 0.01, WithinInstallments: 1}, usd(0), usd(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ru, loan, schedule := bookedLoan(t, usd(1000000), 0.12, 12, day(2026, 1, 1), tt.paid, tt.penalty)
+			quote, _, err := ru.payoffQuote(loan, schedule, tt.asOf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if quote.AccruedInterest.Cmp(tt.interest) != 0 || quote.PrepaymentPenalty.Cmp(tt.fee) != 0 {
+				t.Fatalf("interest %s, penalty %s, want %s and %s", quote.AccruedInterest, quote.PrepaymentPenalty, tt.interest, tt.fee)
+			}
+			want := loan.OutstandingPrincipal.Add(tt.interest).Add(tt.fee)
+			if quote.Total.Cmp(want) != 0 {
+				t.Fatalf("total %s, want %s", quote.Total, want)
+			}
+		})
+	}
+}
+
+func TestRepayEarly(t *testing.T) {
+	tests := []struct {
+		name    string
+		option  string
+		amount  models.Money
+		onDay   int
+		penalty *models.PrepaymentPenalty
+		term    int
+		err     error
+	}{
+		// the first installment, 788.49 of principal and 100.00 of interest,
+		// falls due on February 1
+		{"reduce installment keeps the term", models.PrepaymentReduceInstallment, usd(333333), 1, nil, 12, nil},
+		{"shorten term drops installments", models.PrepaymentShortenTerm, usd(333333), 1, nil, 9, nil},
+		{"penalty comes off the prepaid principal", models.PrepaymentReduceInstallment, usd(333333), 1, &models.PrepaymentPenalty{FlatFee: usd(1000), Rate: 0.02}, 12, nil},
+		{"prepayment at the balance needs a payoff quote", models.PrepaymentShortenTerm, usd(1010000), 1, nil, 0, models.ErrInvalidPrepayment},
+		{"prepayment above the balance needs a payoff quote", models.PrepaymentReduceInstallment, usd(1500000), 1, nil, 0, models.ErrInvalidPrepayment},
+		{"payment that only covers what is due", models.PrepaymentShortenTerm, usd(90000), 20, nil, 0, models.ErrInvalidPrepayment},
+		{"unknown option", "skip_installment", usd(333333), 1, nil, 0, models.ErrInvalidPrepayment},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ru, loan, schedule := bookedLoan(t, usd(1000000), 0.12, 12, day(2026, 1, 1), 0, tt.penalty)
+			repayment := &models.Repayment{Amount: tt.amount, Prepayment: tt.option, ReceivedAt: day(2026, 2, tt.onDay)}
+			application, err := ru.repayEarly(loan, schedule, repayment)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			paid := repayment.FeesPaid.Add(repayment.InterestPaid).Add(repayment.PrincipalPaid)
+			if paid.Cmp(tt.amount) != 0 {
+				t.Fatalf("payment split into %s, want %s", paid, tt.amount)
+			}
+			rescheduled := application.Schedule
+			if len(rescheduled.Installments) != tt.term {
+				t.Fatalf("%d installments, want %d", len(rescheduled.Installments), tt.term)
+			}
+			// the last installment takes the rounding residue, so the unpaid
+			// principal of the new schedule is exactly what is left
+			left := usd(0)
+			for _, inst := range rescheduled.Installments {
+				left = left.Add(inst.Principal.Sub(inst.PaidPrincipal))
+			}
+			if left.Cmp(application.OutstandingPrincipal) != 0 || left.Cmp(loan.OutstandingPrincipal.Sub(repayment.PrincipalPaid)) != 0 {
+				t.Fatalf("schedule leaves %s, balances %s, want %s", left, application.OutstandingPrincipal, loan.OutstandingPrincipal.Sub(repayment.PrincipalPaid))
+			}
+			last := rescheduled.Installments[len(rescheduled.Installments)-1]
+			if last.Balance.Amount != 0 {
+				t.Fatalf("final installment leaves %s", last.Balance)
+			}
+			if released := loan.OutstandingInterest.Sub(repayment.InterestPaid).Sub(application.OutstandingInterest); released.Cmp(application.ReleasedInterest) != 0 {
+				t.Fatalf("released %s, want %s", application.ReleasedInterest, released)
+			}
+		})
+	}
+}
+
+func TestRepayEarlyOnQuote(t *testing.T) {
+	tests := []struct {
+		name       string
+		receivedOn int
+		extra      models.Money
+		change     func(loan *models.Loan)
+		err        error
+	}{
+		{"closes the loan on the quoted day", 10, usd(0), nil, nil},
+		{"expires after its day", 11, usd(0), nil, models.ErrQuoteExpired},
+		{"pays exactly the total", 10, usd(1), nil, models.ErrInvalidPrepayment},
+		{"the loan changed since it was quoted", 10, usd(0), func(loan *models.Loan) {
+			loan.OutstandingFees = usd(2500)
+			loan.OutstandingBalance = loan.OutstandingBalance.Add(usd(2500))
+		}, models.ErrQuoteExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ru, loan, schedule := bookedLoan(t, usd(1000000), 0.12, 12, day(2026, 1, 1), 0, nil)
+			quote, _, err := ru.payoffQuote(loan, schedule, day(2026, 2, 10))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again, _, _ := ru.payoffQuote(loan, schedule, day(2026, 2, 10)); again.ID != quote.ID {
+				t.Fatalf("quoting again gave ID %s, want %s", again.ID.Hex(), quote.ID.Hex())
+			}
+			if tt.change != nil {
+				tt.change(loan)
+			}
+
+			repayment := &models.Repayment{Amount: quote.Total.Add(tt.extra), QuoteID: &quote.ID, ReceivedAt: day(2026, 2, tt.receivedOn).Add(15 * time.Hour)}
+			application, err := ru.repayEarly(loan, schedule, repayment)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+			if !application.OutstandingPrincipal.IsZero() || !application.OutstandingInterest.IsZero() {
+				t.Fatalf("leaves %s principal and %s interest", application.OutstandingPrincipal, application.OutstandingInterest)
+			}
+			if repayment.PrincipalPaid.Cmp(quote.Principal) != 0 || repayment.InterestPaid.Cmp(quote.AccruedInterest) != 0 {
+				t.Fatalf("paid %s principal and %s interest, want %s and %s", repayment.PrincipalPaid, repayment.InterestPaid, quote.Principal, quote.AccruedInterest)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type IProductUsecase interface {
+	CreateProduct(adminID string, product *models.LoanProduct) (*models.LoanProduct, error)
+	GetProducts(activeOnly bool) ([]models.LoanProduct, error)
+	GetProduct(productID string) (*models.LoanProduct, error)
+	UpdateProduct(adminID string, productID string, product *models.LoanProduct) (*models.LoanProduct, error)
+	ArchiveProduct(adminID string, productID string) error
+}
+
+type ProductUsecase struct {
+	productRepo repository_interface.IProductRepository
+	logRepo     repository_interface.ILogRepository
+}
+
+func NewProductUsecase(productRepo repository_interface.IProductRepository, logRepo repository_interface.ILogRepository) IProductUsecase {
+	return &ProductUsecase{
+		productRepo: productRepo,
+		logRepo:     logRepo,
+	}
+}
+
+func (pu *ProductUsecase) CreateProduct(adminID string, product *models.LoanProduct) (*models.LoanProduct, error) {
+	if err := validateProduct(product); err != nil {
+		return nil, err
+	}
+	product.ID = primitive.NilObjectID
+	product.CreatedAt = time.Now()
+	product.UpdatedAt = product.CreatedAt
+
+	result, err := pu.productRepo.CreateProduct(product)
+	if err != nil {
+		return nil, err
+	}
+	pu.logProductChange(adminID, "Loan product created: "+result.Name)
+	return result, nil
+}
+
+func (pu *ProductUsecase) GetProducts(activeOnly bool) ([]models.LoanProduct, error) {
+	return pu.productRepo.GetProducts(activeOnly)
+}
+
+func (pu *ProductUsecase) GetProduct(productID string) (*models.LoanProduct, error) {
+	product, err := pu.productRepo.GetProductByID(productID)
+	if err != nil {
+		return nil, errors.New("product not found")
+	}
+	return product, nil
+}
+
+func (pu *ProductUsecase) UpdateProduct(adminID string, productID string, product *models.LoanProduct) (*models.LoanProduct, error) {
+	existing, err := pu.productRepo.GetProductByID(productID)
+	if err != nil {
+		return nil, errors.New("product not found")
+	}
+	if err := validateProduct(product); err != nil {
+		return nil, err
+	}
+	product.CreatedAt = existing.CreatedAt
+	product.UpdatedAt = time.Now()
+
+	if err := pu.productRepo.UpdateProduct(productID, product); err != nil {
+		return nil, err
+	}
+	pu.logProductChange(adminID, "Loan product updated: "+product.Name)
+	return product, nil
+}
+
+// ArchiveProduct withdraws a product from new applications. Products are
+// never removed because existing loans keep referring to them.
+func (pu *ProductUsecase) ArchiveProduct(adminID string, productID string) error {
+	product, err := pu.productRepo.GetProductByID(productID)
+	if err != nil {
+		return errors.New("product not found")
+	}
+	product.Active = false
+	product.UpdatedAt = time.Now()
+
+	if err := pu.productRepo.UpdateProduct(productID, product); err != nil {
+		return err
+	}
+	pu.logProductChange(adminID, "Loan product archived: "+product.Name)
+	return nil
+}
+
+func (pu *ProductUsecase) logProductChange(adminID string, action string) {
+	userID, _ := primitive.ObjectIDFromHex(adminID)
+	pu.logRepo.CreateLog(&models.SystemLog{
+		Action:    action,
+		Timestamp: time.Now(),
+		UserID:    userID,
+	})
+}
+
+func validateProduct(product *models.LoanProduct) error {
+	if product.Name == "" {
+		return errors.New("product name is required")
+	}
+	product.Currency = strings.ToUpper(product.Currency)
+	if !models.IsCurrencyCode(product.Currency) {
+		return errors.New("product currency must be a three letter ISO 4217 code")
+	}
+	currency := product.Currency
+	if product.MinAmount.Currency != currency || product.MaxAmount.Currency != currency {
+		return fmt.Errorf("product amounts must be in %s", currency)
+	}
+	for _, tier := range product.RateTiers {
+		if tier.MinAmount.Currency != currency || tier.MaxAmount.Currency != currency {
+			return fmt.Errorf("product amounts must be in %s", currency)
+		}
+	}
+	if product.MinAmount.Amount <= 0 || product.MaxAmount.Cmp(product.MinAmount) < 0 {
+		return errors.New("product amount range is invalid")
+	}
+	if len(product.AllowedTerms) == 0 {
+		return errors.New("product must allow at least one term")
+	}
+	for _, term := range product.AllowedTerms {
+		if term <= 0 {
+			return errors.New("allowed terms must be positive numbers of months")
+		}
+	}
+	if threshold := product.SecondApprovalThreshold; threshold != nil {
+		if threshold.Currency != currency {
+			return fmt.Errorf("product amounts must be in %s", currency)
+		}
+		if threshold.IsNegative() {
+			return errors.New("second approval threshold cannot be negative")
+		}
+	}
+	seenDocuments := map[string]bool{}
+	for i, docType := range product.RequiredDocuments {
+		docType = strings.ToLower(strings.TrimSpace(docType))
+		if !documentTypePattern.MatchString(docType) {
+			return errors.New("required document types must be lower case words joined by underscores")
+		}
+		if seenDocuments[docType] {
+			return fmt.Errorf("document type %s is required twice", docType)
+		}
+		seenDocuments[docType] = true
+		product.RequiredDocuments[i] = docType
+	}
+	if fee := product.LateFee; fee != nil {
+		if fee.FlatFee.Currency == "" {
+			fee.FlatFee.Currency = currency
+		}
+		if fee.FlatFee.Currency != currency {
+			return fmt.Errorf("product amounts must be in %s", currency)
+		}
+		if fee.FlatFee.IsNegative() || fee.Rate < 0 || fee.Rate >= 1 || fee.GraceDays < 0 {
+			return errors.New("late fee needs a non-negative flat fee and grace days and a rate between 0 and 1")
+		}
+	}
+	if penalty := product.PrepaymentPenalty; penalty != nil {
+		if penalty.FlatFee.Currency == "" {
+			penalty.FlatFee.Currency = currency
+		}
+		if penalty.FlatFee.Currency != currency {
+			return fmt.Errorf("product amounts must be in %s", currency)
+		}
+		if penalty.FlatFee.IsNegative() || penalty.Rate < 0 || penalty.Rate >= 1 || penalty.WithinInstallments < 0 {
+			return errors.New("prepayment penalty needs a non-negative flat fee and installment count and a rate between 0 and 1")
+		}
+	}
+	if cadence := product.Reminders; cadence != nil {
+		for _, days := range cadence.DaysBefore {
+			if days < 0 {
+				return errors.New("reminder days before the due date cannot be negative")
+			}
+		}
+		for _, days := range cadence.OverdueDays {
+			if days <= 0 {
+				return errors.New("overdue reminder days must be positive")
+			}
+		}
+	}
+	if product.DayCount != "" && !containsString(models.DayCountConventions, product.DayCount) {
+		return errors.New("day count must be actual/365, actual/360 or 30/360")
+	}
+	if product.DefaultAfterDays < 0 {
+		return errors.New("default after days cannot be negative")
+	}
+	if product.MaxLTV < 0 || product.MaxLTV > 1 {
+		return errors.New("max ltv must be between 0 and 1")
+	}
+	if product.OriginationFeeRate < 0 || product.OriginationFeeRate >= 1 {
+		return errors.New("origination fee rate must be between 0 and 1")
+	}
+
+	switch product.RepaymentMethod {
+	case "":
+		product.RepaymentMethod = models.RepaymentMethodAnnuity
+	case models.RepaymentMethodAnnuity, models.RepaymentMethodFlat:
+	default:
+		return errors.New("unsupported repayment method")
+	}
+
+	switch product.RateType {
+	case models.RateTypeFixed:
+		if product.AnnualRate < 0 {
+			return errors.New("annual rate cannot be negative")
+		}
+		product.RateTiers = nil
+	case models.RateTypeTiered:
+		if len(product.RateTiers) == 0 {
+			return errors.New("tiered products need at least one rate tier")
+		}
+		sort.Slice(product.RateTiers, func(i, j int) bool {
+			return product.RateTiers[i].MinAmount.Cmp(product.RateTiers[j].MinAmount) < 0
+		})
+		for i, tier := range product.RateTiers {
+			if tier.AnnualRate < 0 || tier.MaxAmount.Cmp(tier.MinAmount) < 0 {
+				return errors.New("rate tier is invalid")
+			}
+			if i > 0 && tier.MinAmount.Cmp(product.RateTiers[i-1].MaxAmount) <= 0 {
+				return errors.New("rate tiers must not overlap")
+			}
+		}
+		product.AnnualRate = 0
+	default:
+		return errors.New("rate type must be fixed or tiered")
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var reasonCodePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+type IReasonCodeUsecase interface {
+	CreateReasonCode(adminID string, reasonCode *models.ReasonCode) (*models.ReasonCode, error)
+	GetReasonCodes(activeOnly bool) ([]models.ReasonCode, error)
+	UpdateReasonCode(adminID string, code string, reasonCode *models.ReasonCode) (*models.ReasonCode, error)
+	DeactivateReasonCode(adminID string, code string) error
+}
+
+type ReasonCodeUsecase struct {
+	reasonCodeRepo repository_interface.IReasonCodeRepository
+	logRepo        repository_interface.ILogRepository
+}
+
+func NewReasonCodeUsecase(reasonCodeRepo repository_interface.IReasonCodeRepository, logRepo repository_interface.ILogRepository) IReasonCodeUsecase {
+	return &ReasonCodeUsecase{
+		reasonCodeRepo: reasonCodeRepo,
+		logRepo:        logRepo,
+	}
+}
+
+func (ru *ReasonCodeUsecase) CreateReasonCode(adminID string, reasonCode *models.ReasonCode) (*models.ReasonCode, error) {
+	reasonCode.Code = strings.ToUpper(strings.TrimSpace(reasonCode.Code))
+	if err := validateReasonCode(reasonCode); err != nil {
+		return nil, err
+	}
+	if _, err := ru.reasonCodeRepo.GetReasonCode(reasonCode.Code); err == nil {
+		return nil, errors.New("reason code already exists")
+	}
+	reasonCode.ID = primitive.NilObjectID
+	reasonCode.Active = true
+	reasonCode.CreatedAt = time.Now()
+	reasonCode.UpdatedAt = reasonCode.CreatedAt
+
+	result, err := ru.reasonCodeRepo.CreateReasonCode(reasonCode)
+	if err != nil {
+		return nil, err
+	}
+	ru.logReasonCodeChange(adminID, "Reason code created: "+result.Code)
+	return result, nil
+}
+
+func (ru *ReasonCodeUsecase) GetReasonCodes(activeOnly bool) ([]models.ReasonCode, error) {
+	return ru.reasonCodeRepo.GetReasonCodes(activeOnly)
+}
+
+// UpdateReasonCode changes the label, statuses or active flag of a code. The
+// code itself is fixed because past decisions refer to it.
+func (ru *ReasonCodeUsecase) UpdateReasonCode(adminID string, code string, reasonCode *models.ReasonCode) (*models.ReasonCode, error) {
+	code = strings.ToUpper(code)
+	existing, err := ru.reasonCodeRepo.GetReasonCode(code)
+	if err != nil {
+		return nil, errors.New("reason code not found")
+	}
+	reasonCode.ID = existing.ID
+	reasonCode.Code = existing.Code
+	if err := validateReasonCode(reasonCode); err != nil {
+		return nil, err
+	}
+	reasonCode.CreatedAt = existing.CreatedAt
+	reasonCode.UpdatedAt = time.Now()
+
+	if err := ru.reasonCodeRepo.UpdateReasonCode(code, reasonCode); err != nil {
+		return nil, err
+	}
+	ru.logReasonCodeChange(adminID, "Reason code updated: "+code)
+	return reasonCode, nil
+}
+
+// DeactivateReasonCode stops a code from being used for new decisions.
+// Codes are never removed because decided loans keep referring to them.
+func (ru *ReasonCodeUsecase) DeactivateReasonCode(adminID string, code string) error {
+	code = strings.ToUpper(code)
+	reasonCode, err := ru.reasonCodeRepo.GetReasonCode(code)
+	if err != nil {
+		return errors.New("reason code not found")
+	}
+	reasonCode.Active = false
+	reasonCode.UpdatedAt = time.Now()
+
+	if err := ru.reasonCodeRepo.UpdateReasonCode(code, reasonCode); err != nil {
+		return err
+	}
+	ru.logReasonCodeChange(adminID, "Reason code deactivated: "+code)
+	return nil
+}
+
+func (ru *ReasonCodeUsecase) logReasonCodeChange(adminID string, action string) {
+	userID, _ := primitive.ObjectIDFromHex(adminID)
+	ru.logRepo.CreateLog(&models.SystemLog{
+		Action:    action,
+		Timestamp: time.Now(),
+		UserID:    userID,
+	})
+}
+
+func validateReasonCode(reasonCode *models.ReasonCode) error {
+	if !reasonCodePattern.MatchString(reasonCode.Code) {
+		return errors.New("reason code must be upper case letters, digits and underscores")
+	}
+	if reasonCode.Code == models.ReasonCodeAutomated {
+		return errors.New("reason code is reserved for automated decisions")
+	}
+	reasonCode.Label = strings.TrimSpace(reasonCode.Label)
+	if reasonCode.Label == "" {
+		return errors.New("reason code label is required")
+	}
+	for _, status := range reasonCode.AppliesTo {
+		if status != models.LoanStatusApproved && status != models.LoanStatusRejected {
+			return errors.New("reason codes can only apply to approved or rejected loans")
+		}
+	}
+	return nil
+}
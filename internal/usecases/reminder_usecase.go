@@ -0,0 +1,164 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/infrastructures/services/email_service"
+	"LoanGuard/internal/repository/interfaces"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type IReminderUsecase interface {
+	RunReminders(asOf time.Time) error
+	GetReminders(loanID string, userID string, role string) ([]models.Reminder, error)
+}
+
+type ReminderUsecase struct {
+	reminderRepo repository_interface.IReminderRepository
+	loanRepo     repository_interface.ILoanRepository
+	scheduleRepo repository_interface.IScheduleRepository
+	productRepo  repository_interface.IProductRepository
+	userRepo     repository_interface.IUserRepository
+	logRepo      repository_interface.ILogRepository
+	emailSvc     email_service.IEmailService
+}
+
+func NewReminderUsecase(reminderRepo repository_interface.IReminderRepository, loanRepo repository_interface.ILoanRepository, scheduleRepo repository_interface.IScheduleRepository, productRepo repository_interface.IProductRepository, userRepo repository_interface.IUserRepository, logRepo repository_interface.ILogRepository, emailSvc email_service.IEmailService) IReminderUsecase {
+	return &ReminderUsecase{
+		reminderRepo: reminderRepo,
+		loanRepo:     loanRepo,
+		scheduleRepo: scheduleRepo,
+		productRepo:  productRepo,
+		userRepo:     userRepo,
+		logRepo:      logRepo,
+		emailSvc:     emailSvc,
+	}
+}
+
+// RunReminders emails the borrower of every serviced loan about each unpaid
+// installment that has reached a milestone of its product's cadence as of
+// the given day. A reminder is recorded before it is sent and the record is
+// unique, so a restart or a second run on the same day never sends it twice.
+// The record is removed again when the email fails, for the next run to retry.
+func (ru *ReminderUsecase) RunReminders(asOf time.Time) error {
+	loans, err := ru.loanRepo.GetLoansByStatus(servicedStatuses)
+	if err != nil {
+		return err
+	}
+	products := map[string]*models.LoanProduct{}
+	var failed []string
+	for i := range loans {
+		if err := ru.remindLoan(&loans[i], products, asOf); err != nil {
+			failed = append(failed, loans[i].ID.Hex()+": "+err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("reminders failed for %d loans: %s", len(failed), strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// GetReminders lists the reminders sent about the loan, oldest first.
+func (ru *ReminderUsecase) GetReminders(loanID string, userID string, role string) ([]models.Reminder, error) {
+	loan, err := ru.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, models.ErrLoanNotFound
+	}
+	reminders, err := ru.reminderRepo.GetReminders(loanID)
+	if err != nil {
+		return nil, err
+	}
+	// guarantors and co-borrowers do not see the borrower's email address
+	if !models.IsStaffRole(role) && loan.UserId.Hex() != userID {
+		for i := range reminders {
+			reminders[i].Email = ""
+		}
+	}
+	return reminders, nil
+}
+
+func (ru *ReminderUsecase) remindLoan(loan *models.Loan, products map[string]*models.LoanProduct, asOf time.Time) error {
+	schedule, err := ru.scheduleRepo.GetScheduleByLoanID(loan.ID.Hex())
+	if err != nil {
+		return nil
+	}
+	cadence := models.DefaultReminderCadence
+	if !loan.ProductID.IsZero() {
+		product, ok := products[loan.ProductID.Hex()]
+		if !ok {
+			product, err = ru.productRepo.GetProductByID(loan.ProductID.Hex())
+			if err != nil {
+				return err
+			}
+			products[loan.ProductID.Hex()] = product
+		}
+		if product.Reminders != nil {
+			cadence = *product.Reminders
+		}
+	}
+
+	var user *models.User
+	for i := range schedule.Installments {
+		inst := &schedule.Installments[i]
+		if inst.Paid {
+			continue
+		}
+		daysUntilDue := models.DaysBetween(asOf, inst.DueDate)
+		kind, offset, found := cadence.Milestone(daysUntilDue)
+		if !found {
+			continue
+		}
+		if user == nil {
+			user, err = ru.userRepo.GetUserByID(loan.UserId.Hex())
+			if err != nil {
+				return err
+			}
+		}
+
+		reminder, err := ru.reminderRepo.CreateReminder(&models.Reminder{
+			Key:               models.ReminderKey(loan.ID, schedule.Version, inst.Number, kind, offset),
+			LoanID:            loan.ID,
+			InstallmentNumber: inst.Number,
+			Kind:              kind,
+			Offset:            offset,
+			DueDate:           inst.DueDate,
+			AmountDue:         inst.Overdue(),
+			Email:             user.Email,
+			SentAt:            time.Now(),
+		})
+		if errors.Is(err, models.ErrReminderAlreadySent) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		data := email_service.PaymentReminderEmail{
+			Name:              user.Name,
+			LoanID:            loan.ID.Hex(),
+			InstallmentNumber: inst.Number,
+			DueDate:           inst.DueDate.Format("2006-01-02"),
+			AmountDue:         reminder.AmountDue.String(),
+			Overdue:           kind == models.ReminderOverdue,
+			DaysPastDue:       -daysUntilDue,
+			DaysUntilDue:      daysUntilDue,
+		}
+		if err := ru.emailSvc.SendPaymentReminderEmail(user.Email, data); err != nil {
+			ru.reminderRepo.DeleteReminder(reminder.ID.Hex())
+			ru.logRepo.CreateLog(&models.SystemLog{
+				Action:    fmt.Sprintf("Failed to send %s reminder for installment %d: %v", kind, inst.Number, err),
+				Timestamp: time.Now(),
+				LoanID:    loan.ID.Hex(),
+			})
+			continue
+		}
+		ru.logRepo.CreateLog(&models.SystemLog{
+			Action:    fmt.Sprintf("Sent %s reminder for installment %d", kind, inst.Number),
+			Timestamp: time.Now(),
+			LoanID:    loan.ID.Hex(),
+		})
+	}
+	return nil
+}
@@ -0,0 +1,389 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/infrastructures/services"
+	"LoanGuard/internal/repository/interfaces"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type IRepaymentUsecase interface {
+	RecordRepayment(loanID string, actorID string, channel string, repayment *models.Repayment) (*models.Repayment, error)
+	GetRepayments(loanID string) ([]models.Repayment, error)
+	GetLedger(loanID string) ([]models.LedgerEntry, error)
+	GetPayoffQuote(loanID string, date time.Time) (*models.PayoffQuote, error)
+}
+
+type RepaymentUsecase struct {
+	loanRepo       repository_interface.ILoanRepository
+	scheduleRepo   repository_interface.IScheduleRepository
+	repaymentRepo  repository_interface.IRepaymentRepository
+	ledgerRepo     repository_interface.ILedgerRepository
+	collateralRepo repository_interface.ICollateralRepository
+	productRepo    repository_interface.IProductRepository
+	scheduleSvc    services.IScheduleService
+	logRepo        repository_interface.ILogRepository
+}
+
+func NewRepaymentUsecase(loanRepo repository_interface.ILoanRepository, scheduleRepo repository_interface.IScheduleRepository, repaymentRepo repository_interface.IRepaymentRepository, ledgerRepo repository_interface.ILedgerRepository, collateralRepo repository_interface.ICollateralRepository, productRepo repository_interface.IProductRepository, scheduleSvc services.IScheduleService, logRepo repository_interface.ILogRepository) IRepaymentUsecase {
+	return &RepaymentUsecase{
+		loanRepo:       loanRepo,
+		scheduleRepo:   scheduleRepo,
+		repaymentRepo:  repaymentRepo,
+		ledgerRepo:     ledgerRepo,
+		collateralRepo: collateralRepo,
+		productRepo:    productRepo,
+		scheduleSvc:    scheduleSvc,
+		logRepo:        logRepo,
+	}
+}
+
+// RecordRepayment applies a payment to a loan. Outstanding fees are settled
+// first, then each installment in due order has its interest and then its
+// principal paid. A payment on a payoff quote closes the loan, and one that
+// names a prepayment option repays principal early; see repayEarly. A
+// repeated reference returns the original repayment instead of posting the
+// money twice. How the payment changes the loan is worked out before anything
+// is written and recorded with it, so a payment whose application fails part
+// way is completed when it is reported again or before the loan's next
+// payment is recorded.
+func (ru *RepaymentUsecase) RecordRepayment(loanID string, actorID string, channel string, repayment *models.Repayment) (*models.Repayment, error) {
+	amount := repayment.Amount
+	if amount.Amount <= 0 {
+		return nil, errors.New("repayment amount must be greater than zero")
+	}
+
+	if repayment.Reference != "" {
+		existing, err := ru.repaymentRepo.GetRepaymentByReference(repayment.Reference)
+		if err == nil {
+			return ru.replayRepayment(existing, loanID, amount)
+		}
+	}
+	if err := ru.completePendingRepayments(loanID); err != nil {
+		return nil, err
+	}
+
+	loan, err := ru.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, models.ErrLoanNotFound
+	}
+	switch loan.Status {
+	case models.LoanStatusDisbursed, models.LoanStatusActive, models.LoanStatusDefaulted:
+	default:
+		return nil, fmt.Errorf("%w: repayments cannot be recorded on a %s loan", models.ErrInvalidTransition, loan.Status)
+	}
+	if amount.Currency != loan.OutstandingBalance.Currency {
+		return nil, fmt.Errorf("repayment must be made in %s", loan.OutstandingBalance.Currency)
+	}
+
+	schedule, err := ru.scheduleRepo.GetScheduleByLoanID(loanID)
+	if err != nil {
+		return nil, errors.New("loan has no repayment schedule")
+	}
+
+	now := time.Now()
+	if repayment.ReceivedAt.IsZero() {
+		repayment.ReceivedAt = now
+	}
+	var application *models.RepaymentApplication
+	if repayment.QuoteID != nil || repayment.Prepayment != "" {
+		application, err = ru.repayEarly(loan, schedule, repayment)
+	} else {
+		application, err = ru.repayScheduled(loan, schedule, repayment)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	repayment.LoanID = loan.ID
+	repayment.Amount = amount
+	repayment.Channel = channel
+	repayment.RecordedBy = actorID
+	repayment.CreatedAt = now
+	repayment.Application = application
+	result, err := ru.repaymentRepo.CreateRepayment(repayment)
+	if errors.Is(err, models.ErrDuplicateRepayment) {
+		// the same payment reported concurrently was recorded first
+		existing, err := ru.repaymentRepo.GetRepaymentByReference(repayment.Reference)
+		if err != nil {
+			return nil, err
+		}
+		return ru.replayRepayment(existing, loanID, amount)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ru.completeRepayment(result)
+}
+
+// replayRepayment answers a payment reported again under the reference of
+// an existing repayment, completing that one. The same reference for another
+// loan or amount is a different payment and gets ErrDuplicateRepayment.
+func (ru *RepaymentUsecase) replayRepayment(existing *models.Repayment, loanID string, amount models.Money) (*models.Repayment, error) {
+	if existing.LoanID.Hex() != loanID || existing.Amount != amount {
+		return nil, fmt.Errorf("%w: it was for %s on loan %s", models.ErrDuplicateRepayment, existing.Amount, existing.LoanID.Hex())
+	}
+	return ru.completeRepayment(existing)
+}
+
+// completePendingRepayments completes the loan's repayments whose
+// application failed part way, oldest first, so payments are applied in the
+// order they were recorded.
+func (ru *RepaymentUsecase) completePendingRepayments(loanID string) error {
+	pending, err := ru.repaymentRepo.GetPendingRepayments(loanID)
+	if err != nil {
+		return err
+	}
+	for i := range pending {
+		if _, err := ru.completeRepayment(&pending[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// completeRepayment applies what of a recorded repayment has not been
+// applied yet and returns it.
+func (ru *RepaymentUsecase) completeRepayment(repayment *models.Repayment) (*models.Repayment, error) {
+	if !repayment.Pending() {
+		return repayment, nil
+	}
+	if err := ru.applyRepayment(repayment); err != nil {
+		return nil, err
+	}
+	return repayment, nil
+}
+
+// applyRepayment writes a recorded repayment's application. The balances
+// come first and are written together with the repayment's id, so they are
+// changed once; the installments or new schedule, the interest released and
+// the ledger transaction follow, each a no-op when already written, and then
+// the status changes the payment brings. A repayment whose balances can no
+// longer be written because the loan changed since it was worked out is
+// discarded, to be reported again.
+func (ru *RepaymentUsecase) applyRepayment(repayment *models.Repayment) error {
+	application := repayment.Application
+	loanID := repayment.LoanID.Hex()
+	repaymentID := repayment.ID.Hex()
+	loan, err := ru.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return models.ErrLoanNotFound
+	}
+
+	loan.OutstandingPrincipal = application.OutstandingPrincipal
+	loan.OutstandingInterest = application.OutstandingInterest
+	loan.OutstandingFees = application.OutstandingFees
+	loan.OutstandingBalance = loan.OutstandingFees.Add(loan.OutstandingInterest).Add(loan.OutstandingPrincipal)
+	applied, err := ru.loanRepo.ApplyRepaymentBalances(loan, application.BalanceBefore, repaymentID)
+	if err != nil {
+		ru.repaymentRepo.DeleteRepayment(repaymentID)
+		return err
+	}
+	if !applied {
+		if loan, err = ru.loanRepo.GetLoanByID(loanID); err != nil {
+			return models.ErrLoanNotFound
+		}
+	}
+
+	if application.Schedule != nil {
+		if err := ru.ensureSchedule(application.Schedule); err != nil {
+			return err
+		}
+	} else if err := ru.scheduleRepo.UpdateInstallments(application.ScheduleID.Hex(), application.Installments); err != nil {
+		return err
+	}
+	if err := ru.releaseInterest(loan, application.ReleasedInterest, "repayment:"+repaymentID+":release"); err != nil {
+		return err
+	}
+	entries, err := newKeyedLedgerTransaction(loan.ID, "repayment:"+repaymentID, "Repayment "+repaymentID,
+		append([]models.LedgerEntry{debit(models.AccountCash, repayment.Amount)}, application.Postings...)...,
+	)
+	if err != nil {
+		return err
+	}
+	if err := ru.ledgerRepo.CreateEntries(entries); err != nil && !errors.Is(err, models.ErrDuplicateLedgerEntry) {
+		return err
+	}
+
+	if applied {
+		action := "Loan repayment recorded"
+		if application.Schedule != nil && repayment.QuoteID != nil {
+			action = "Loan paid off early"
+		} else if application.Schedule != nil {
+			action = fmt.Sprintf("Prepayment rescheduled to %d remaining installments", unpaidInstallments(application.Schedule))
+		}
+		userID, _ := primitive.ObjectIDFromHex(repayment.RecordedBy)
+		ru.logRepo.CreateLog(&models.SystemLog{
+			Action:    action,
+			Timestamp: time.Now(),
+			UserID:    userID,
+			LoanID:    loanID,
+		})
+	}
+
+	// the first repayment on a disbursed loan puts it into servicing
+	if loan.Status == models.LoanStatusDisbursed {
+		if err := transitionLoan(ru.loanRepo, ru.logRepo, loan, models.LoanStatusActive, repayment.RecordedBy, "first repayment received"); err != nil {
+			return err
+		}
+	}
+	if loan.OutstandingBalance.IsZero() {
+		if loan.Status != models.LoanStatusClosed {
+			if err := transitionLoan(ru.loanRepo, ru.logRepo, loan, models.LoanStatusClosed, repayment.RecordedBy, "outstanding balance fully repaid"); err != nil {
+				return err
+			}
+		}
+		if err := releaseLiens(ru.collateralRepo, ru.logRepo, loan, repayment.RecordedBy); err != nil {
+			return err
+		}
+		if err := recognizeUnearnedInterest(ru.ledgerRepo, loan); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	if err := ru.repaymentRepo.MarkRepaymentApplied(repaymentID, now); err != nil {
+		return err
+	}
+	repayment.AppliedAt = &now
+	return nil
+}
+
+// ensureSchedule stores the schedule version unless it already was.
+func (ru *RepaymentUsecase) ensureSchedule(schedule *models.RepaymentSchedule) error {
+	schedules, err := ru.scheduleRepo.GetSchedulesByLoanID(schedule.LoanID.Hex())
+	if err != nil {
+		return err
+	}
+	for _, stored := range schedules {
+		if stored.ID == schedule.ID {
+			return nil
+		}
+	}
+	_, err = ru.scheduleRepo.CreateSchedule(schedule)
+	return err
+}
+
+func unpaidInstallments(schedule *models.RepaymentSchedule) int {
+	count := 0
+	for _, inst := range schedule.Installments {
+		if !inst.Paid {
+			count++
+		}
+	}
+	return count
+}
+
+// repayScheduled works out how a payment applied in schedule order changes
+// the loan, including the ledger credits it settles.
+func (ru *RepaymentUsecase) repayScheduled(loan *models.Loan, schedule *models.RepaymentSchedule, repayment *models.Repayment) (*models.RepaymentApplication, error) {
+	amount := repayment.Amount
+	if amount.Cmp(loan.OutstandingBalance) > 0 {
+		return nil, errors.New("repayment exceeds the outstanding balance")
+	}
+
+	remaining := amount
+	feesPaid := remaining.Min(loan.OutstandingFees)
+	remaining = remaining.Sub(feesPaid)
+
+	interestPaid := models.NewMoney(0, amount.Currency)
+	principalPaid := models.NewMoney(0, amount.Currency)
+	for i := range schedule.Installments {
+		if remaining.Amount <= 0 {
+			break
+		}
+		inst := &schedule.Installments[i]
+		if inst.Paid {
+			continue
+		}
+
+		pay := remaining.Min(inst.Interest.Sub(inst.PaidInterest))
+		inst.PaidInterest = inst.PaidInterest.Add(pay)
+		interestPaid = interestPaid.Add(pay)
+		remaining = remaining.Sub(pay)
+
+		pay = remaining.Min(inst.Principal.Sub(inst.PaidPrincipal))
+		inst.PaidPrincipal = inst.PaidPrincipal.Add(pay)
+		principalPaid = principalPaid.Add(pay)
+		remaining = remaining.Sub(pay)
+
+		inst.Paid = inst.PaidInterest.Cmp(inst.Interest) >= 0 && inst.PaidPrincipal.Cmp(inst.Principal) >= 0
+	}
+	// anything left over after the schedule is settled reduces principal directly
+	principalPaid = principalPaid.Add(remaining)
+
+	repayment.FeesPaid = feesPaid
+	repayment.InterestPaid = interestPaid
+	repayment.PrincipalPaid = principalPaid
+	return &models.RepaymentApplication{
+		BalanceBefore:        loan.OutstandingBalance,
+		OutstandingPrincipal: loan.OutstandingPrincipal.Sub(principalPaid),
+		OutstandingInterest:  loan.OutstandingInterest.Sub(interestPaid),
+		OutstandingFees:      loan.OutstandingFees.Sub(feesPaid),
+		ScheduleID:           schedule.ID,
+		Installments:         schedule.Installments,
+		Postings: []models.LedgerEntry{
+			credit(models.AccountFeesReceivable, feesPaid),
+			credit(models.AccountInterestReceivable, interestPaid),
+			credit(models.AccountPrincipalReceivable, principalPaid),
+		},
+	}, nil
+}
+
+func (ru *RepaymentUsecase) GetRepayments(loanID string) ([]models.Repayment, error) {
+	return ru.repaymentRepo.GetRepaymentsByLoanID(loanID)
+}
+
+func (ru *RepaymentUsecase) GetLedger(loanID string) ([]models.LedgerEntry, error) {
+	return ru.ledgerRepo.GetEntriesByLoanID(loanID)
+}
+
+func debit(account string, amount models.Money) models.LedgerEntry {
+	return models.LedgerEntry{Account: account, Debit: amount, Credit: models.NewMoney(0, amount.Currency)}
+}
+
+func credit(account string, amount models.Money) models.LedgerEntry {
+	return models.LedgerEntry{Account: account, Debit: models.NewMoney(0, amount.Currency), Credit: amount}
+}
+
+// newLedgerTransaction stamps the postings with a shared transaction id and
+// refuses to build a transaction whose debits and credits do not balance.
+func newLedgerTransaction(loanID primitive.ObjectID, description string, postings ...models.LedgerEntry) ([]models.LedgerEntry, error) {
+	transactionID := primitive.NewObjectID()
+	now := time.Now()
+
+	var debits, credits models.Money
+	entries := make([]models.LedgerEntry, 0, len(postings))
+	for _, posting := range postings {
+		if posting.Debit.IsZero() && posting.Credit.IsZero() {
+			continue
+		}
+		posting.ID = primitive.NewObjectID()
+		posting.LoanID = loanID
+		posting.TransactionID = transactionID
+		posting.Description = description
+		posting.CreatedAt = now
+		debits = debits.Add(posting.Debit)
+		credits = credits.Add(posting.Credit)
+		entries = append(entries, posting)
+	}
+
+	if debits.Amount != credits.Amount || debits.Currency != credits.Currency {
+		return nil, errors.New("ledger transaction is not balanced")
+	}
+	return entries, nil
+}
+
+// newKeyedLedgerTransaction builds a transaction like newLedgerTransaction
+// that the ledger accepts only once under key.
+func newKeyedLedgerTransaction(loanID primitive.ObjectID, key string, description string, postings ...models.LedgerEntry) ([]models.LedgerEntry, error) {
+	entries, err := newLedgerTransaction(loanID, description, postings...)
+	for i := range entries {
+		entries[i].Key = key
+	}
+	return entries, err
+}
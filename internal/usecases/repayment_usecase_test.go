@@ -0,0 +1,200 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/models"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type repaymentFixture struct {
+	usecase    *RepaymentUsecase
+	loan       *models.Loan
+	loans      *memoryLoanRepository
+	schedules  *memoryScheduleRepository
+	ledger     *memoryLedgerRepository
+	repayments *memoryRepaymentRepository
+}
+
+// newRepaymentFixture sets up an active loan of 10,000.00 at 12% over 12
+// months from January 1 2026, with 25.00 of fees outstanding. Its first
+// installment is 788.49 of principal and 100.00 of interest.
+func newRepaymentFixture(t *testing.T) *repaymentFixture {
+	t.Helper()
+	ru, loan, schedule := bookedLoan(t, usd(1000000), 0.12, 12, day(2026, 1, 1), 0, nil)
+	loan.Status = models.LoanStatusActive
+	loan.OutstandingFees = usd(2500)
+	loan.OutstandingBalance = loan.OutstandingBalance.Add(loan.OutstandingFees)
+	schedule.LoanID = loan.ID
+
+	f := &repaymentFixture{
+		loan:       loan,
+		loans:      newMemoryLoanRepository(loan),
+		schedules:  &memoryScheduleRepository{},
+		ledger:     &memoryLedgerRepository{},
+		repayments: &memoryRepaymentRepository{},
+	}
+	f.schedules.CreateSchedule(schedule)
+	ru.loanRepo = f.loans
+	ru.scheduleRepo = f.schedules
+	ru.ledgerRepo = f.ledger
+	ru.repaymentRepo = f.repayments
+	ru.logRepo = &memoryLogRepository{}
+	f.usecase = ru
+	return f
+}
+
+func (f *repaymentFixture) repay(loanID string, amount models.Money, reference string) (*models.Repayment, error) {
+	return f.usecase.RecordRepayment(loanID, primitive.NewObjectID().Hex(), models.RepaymentChannelAdmin, &models.Repayment{
+		Amount:     amount,
+		Reference:  reference,
+		ReceivedAt: day(2026, 2, 1),
+	})
+}
+
+func TestRepayScheduledAllocation(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    models.Money
+		fees      models.Money
+		interest  models.Money
+		principal models.Money
+		// installments settled in full
+		paid int
+	}{
+		{"fees before anything else", usd(1500), usd(1500), usd(0), usd(0), 0},
+		{"interest before principal", usd(8500), usd(2500), usd(6000), usd(0), 0},
+		{"principal after the installment's interest", usd(62500), usd(2500), usd(10000), usd(50000), 0},
+		{"the next installment's interest before its principal", usd(96349), usd(2500), usd(15000), usd(78849), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newRepaymentFixture(t)
+			schedule, _ := f.schedules.GetScheduleByLoanID(f.loan.ID.Hex())
+			repayment := &models.Repayment{Amount: tt.amount, ReceivedAt: day(2026, 2, 1)}
+			application, err := f.usecase.repayScheduled(f.loan, schedule, repayment)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if repayment.FeesPaid.Cmp(tt.fees) != 0 || repayment.InterestPaid.Cmp(tt.interest) != 0 || repayment.PrincipalPaid.Cmp(tt.principal) != 0 {
+				t.Fatalf("paid fees %s, interest %s, principal %s, want %s, %s and %s",
+					repayment.FeesPaid, repayment.InterestPaid, repayment.PrincipalPaid, tt.fees, tt.interest, tt.principal)
+			}
+			if got := unpaidInstallments(&models.RepaymentSchedule{Installments: application.Installments}); got != 12-tt.paid {
+				t.Fatalf("%d installments left unpaid, want %d", got, 12-tt.paid)
+			}
+			if want := f.loan.OutstandingBalance.Sub(tt.amount); application.OutstandingFees.Add(application.OutstandingInterest).Add(application.OutstandingPrincipal).Cmp(want) != 0 {
+				t.Fatalf("balances leave %s, want %s", application.OutstandingFees.Add(application.OutstandingInterest).Add(application.OutstandingPrincipal), want)
+			}
+		})
+	}
+}
+
+func TestRecordRepaymentResumes(t *testing.T) {
+	tests := []struct {
+		name string
+		// the payment reported after the first one failed part way
+		amount    models.Money
+		reference string
+	}{
+		{"reported again", usd(50000), "ref-1"},
+		{"before the loan's next repayment", usd(20000), "ref-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newRepaymentFixture(t)
+			f.ledger.failNext = errors.New("ledger unavailable")
+			if _, err := f.repay(f.loan.ID.Hex(), usd(50000), "ref-1"); err == nil {
+				t.Fatal("ledger failure not reported")
+			}
+			pending, _ := f.repayments.GetPendingRepayments(f.loan.ID.Hex())
+			if len(pending) != 1 {
+				t.Fatalf("%d pending repayments, want 1", len(pending))
+			}
+			if pending[0].Reference != "ref-1" {
+				t.Fatalf("pending repayment %q, want ref-1", pending[0].Reference)
+			}
+
+			if _, err := f.repay(f.loan.ID.Hex(), tt.amount, tt.reference); err != nil {
+				t.Fatal(err)
+			}
+
+			if pending, _ := f.repayments.GetPendingRepayments(f.loan.ID.Hex()); len(pending) != 0 {
+				t.Fatalf("%d repayments still pending", len(pending))
+			}
+			repayments, _ := f.repayments.GetRepaymentsByLoanID(f.loan.ID.Hex())
+			received := usd(0)
+			for _, repayment := range repayments {
+				received = received.Add(repayment.Amount)
+				if n := f.ledger.transactions("Repayment " + repayment.ID.Hex()); n != 1 {
+					t.Fatalf("repayment %s posted %d times, want once", repayment.Reference, n)
+				}
+			}
+			if f.ledger.balance(models.AccountCash) != received.Amount {
+				t.Fatalf("cash %d, want %d", f.ledger.balance(models.AccountCash), received.Amount)
+			}
+
+			loan, _ := f.loans.GetLoanByID(f.loan.ID.Hex())
+			if want := f.loan.OutstandingBalance.Sub(received); loan.OutstandingBalance.Cmp(want) != 0 {
+				t.Fatalf("outstanding balance %s, want %s", loan.OutstandingBalance, want)
+			}
+			schedule, _ := f.schedules.GetScheduleByLoanID(f.loan.ID.Hex())
+			settled := f.loan.OutstandingFees.Sub(loan.OutstandingFees)
+			for _, inst := range schedule.Installments {
+				settled = settled.Add(inst.PaidInterest).Add(inst.PaidPrincipal)
+			}
+			if settled.Cmp(received) != 0 {
+				t.Fatalf("schedule and fees settle %s, want %s", settled, received)
+			}
+		})
+	}
+}
+
+func TestRecordRepaymentReference(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  models.Money
+		other   bool
+		replays bool
+		err     error
+	}{
+		{"same payment reported again", usd(50000), false, true, nil},
+		{"different amount", usd(40000), false, false, models.ErrDuplicateRepayment},
+		{"different loan", usd(50000), true, false, models.ErrDuplicateRepayment},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newRepaymentFixture(t)
+			first, err := f.repay(f.loan.ID.Hex(), usd(50000), "ref-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			loanID := f.loan.ID.Hex()
+			if tt.other {
+				other := *f.loan
+				other.ID = primitive.NewObjectID()
+				f.loans.loans[other.ID] = other
+				loanID = other.ID.Hex()
+			}
+
+			again, err := f.repay(loanID, tt.amount, "ref-1")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if tt.replays && again.ID != first.ID {
+				t.Fatalf("replay returned repayment %s, want %s", again.ID.Hex(), first.ID.Hex())
+			}
+			if len(f.repayments.repayments) != 1 {
+				t.Fatalf("%d repayments recorded, want 1", len(f.repayments.repayments))
+			}
+			loan, _ := f.loans.GetLoanByID(f.loan.ID.Hex())
+			if want := f.loan.OutstandingBalance.Sub(usd(50000)); loan.OutstandingBalance.Cmp(want) != 0 {
+				t.Fatalf("outstanding balance %s, want %s", loan.OutstandingBalance, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,479 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/dtos"
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/infrastructures/services"
+	"LoanGuard/internal/infrastructures/services/email_service"
+	"LoanGuard/internal/repository/interfaces"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type IRestructureUsecase interface {
+	ProposeRestructure(loanID string, adminID string, req dtos.RestructureDTO) (*models.Restructure, error)
+	GetRestructureOffer(restructureID string, token string) (*models.Restructure, error)
+	RespondToRestructure(restructureID string, token string, accept bool) (*models.Restructure, error)
+	GetRestructures(loanID string) ([]models.Restructure, error)
+}
+
+type RestructureUsecase struct {
+	restructureRepo repository_interface.IRestructureRepository
+	loanRepo        repository_interface.ILoanRepository
+	scheduleRepo    repository_interface.IScheduleRepository
+	ledgerRepo      repository_interface.ILedgerRepository
+	userRepo        repository_interface.IUserRepository
+	logRepo         repository_interface.ILogRepository
+	scheduleSvc     services.IScheduleService
+	emailSvc        email_service.IEmailService
+	baseUri         string
+}
+
+func NewRestructureUsecase(restructureRepo repository_interface.IRestructureRepository, loanRepo repository_interface.ILoanRepository, scheduleRepo repository_interface.IScheduleRepository, ledgerRepo repository_interface.ILedgerRepository, userRepo repository_interface.IUserRepository, logRepo repository_interface.ILogRepository, scheduleSvc services.IScheduleService, emailSvc email_service.IEmailService, baseUri string) IRestructureUsecase {
+	return &RestructureUsecase{
+		restructureRepo: restructureRepo,
+		loanRepo:        loanRepo,
+		scheduleRepo:    scheduleRepo,
+		ledgerRepo:      ledgerRepo,
+		userRepo:        userRepo,
+		logRepo:         logRepo,
+		scheduleSvc:     scheduleSvc,
+		emailSvc:        emailSvc,
+		baseUri:         baseUri,
+	}
+}
+
+// ProposeRestructure offers the borrower new terms for a loan in servicing.
+// Nothing changes until the borrower accepts through the link emailed to
+// them; the offer records the terms before, the projected terms after and
+// the projected schedule, which is what acceptance applies. A loan has at
+// most one open offer at a time.
+func (ru *RestructureUsecase) ProposeRestructure(loanID string, adminID string, req dtos.RestructureDTO) (*models.Restructure, error) {
+	terms := models.RestructureTerms{
+		ExtendMonths:      req.ExtendMonths,
+		AnnualRate:        req.AnnualRate,
+		CapitalizeArrears: req.CapitalizeArrears,
+		HolidayMonths:     req.HolidayMonths,
+	}
+	if err := validateRestructureTerms(terms); err != nil {
+		return nil, err
+	}
+
+	loan, schedule, err := ru.servicedLoan(loanID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := ru.restructureRepo.GetRestructuresByLoan(loanID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, offer := range existing {
+		if offer.Status == models.RestructureProposed && now.Before(offer.ExpiresAt) {
+			return nil, fmt.Errorf("%w: restructure %s is still awaiting the borrower", models.ErrStatusConflict, offer.ID.Hex())
+		}
+	}
+
+	restructured, err := ru.restructuredSchedule(loan, schedule, terms, now)
+	if err != nil {
+		return nil, err
+	}
+	restructured.ID = primitive.NewObjectID()
+	restructure := &models.Restructure{
+		LoanID:         loan.ID,
+		Terms:          terms,
+		Note:           strings.TrimSpace(req.Note),
+		Status:         models.RestructureProposed,
+		Before:         scheduleTerms(schedule, now),
+		After:          scheduleTerms(restructured, now),
+		Token:          services.GenerateLinkToken(),
+		ExpiresAt:      now.Add(models.RestructureOfferValidity),
+		ProposedBy:     adminID,
+		CreatedAt:      now,
+		BaseScheduleID: schedule.ID,
+		Schedule:       restructured,
+	}
+	if _, err := ru.restructureRepo.CreateRestructure(restructure); err != nil {
+		return nil, err
+	}
+	ru.logRestructure(adminID, loanID, "Restructure proposed: "+describeTermsChange(restructure.Before, restructure.After))
+	ru.sendOffer(loan, restructure)
+	return restructure, nil
+}
+
+// GetRestructureOffer returns the offer to the borrower holding its emailed
+// token, for them to review before answering.
+func (ru *RestructureUsecase) GetRestructureOffer(restructureID string, token string) (*models.Restructure, error) {
+	restructure, err := ru.restructureRepo.GetRestructure(restructureID)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(restructure.Token)) != 1 {
+		return nil, models.ErrRestructureNotFound
+	}
+	return restructure, nil
+}
+
+// RespondToRestructure accepts or declines an offer on behalf of the
+// borrower holding its emailed token. Accepting applies the schedule the
+// borrower was offered as a new version and adjusts the loan's balances and
+// ledger to it; an offer the loan has moved on from since, e.g. by a payment,
+// lapses instead. Once accepted the offer is never reopened: an acceptance
+// that fails part way is completed by answering again.
+func (ru *RestructureUsecase) RespondToRestructure(restructureID string, token string, accept bool) (*models.Restructure, error) {
+	restructure, err := ru.GetRestructureOffer(restructureID, token)
+	if err != nil {
+		return nil, err
+	}
+	loanID := restructure.LoanID.Hex()
+	if accept && restructure.Status == models.RestructureAccepted && restructure.AppliedAt == nil {
+		return ru.applyRestructure(restructure)
+	}
+	if restructure.Status != models.RestructureProposed {
+		return nil, fmt.Errorf("%w: the offer was already %s", models.ErrStatusConflict, restructure.Status)
+	}
+	now := time.Now()
+	if !now.Before(restructure.ExpiresAt) {
+		return nil, fmt.Errorf("%w: the offer expired on %s", models.ErrStatusConflict, restructure.ExpiresAt.Format("2006-01-02"))
+	}
+
+	if !accept {
+		if err := ru.restructureRepo.UpdateRestructureStatus(restructureID, models.RestructureProposed, models.RestructureDeclined, now); err != nil {
+			return nil, err
+		}
+		restructure.Status = models.RestructureDeclined
+		restructure.RespondedAt = &now
+		ru.logRestructure(systemActor, loanID, "Restructure declined by the borrower")
+		return restructure, nil
+	}
+
+	if err := ru.checkOfferCurrent(restructure); err != nil {
+		if lapseErr := ru.restructureRepo.UpdateRestructureStatus(restructureID, models.RestructureProposed, models.RestructureLapsed, now); lapseErr != nil {
+			return nil, lapseErr
+		}
+		ru.logRestructure(systemActor, loanID, "Restructure lapsed on acceptance: "+err.Error())
+		return nil, err
+	}
+	if err := ru.restructureRepo.UpdateRestructureStatus(restructureID, models.RestructureProposed, models.RestructureAccepted, now); err != nil {
+		return nil, err
+	}
+	restructure.Status = models.RestructureAccepted
+	restructure.RespondedAt = &now
+	return ru.applyRestructure(restructure)
+}
+
+func (ru *RestructureUsecase) GetRestructures(loanID string) ([]models.Restructure, error) {
+	if _, err := ru.loanRepo.GetLoanByID(loanID); err != nil {
+		return nil, models.ErrLoanNotFound
+	}
+	return ru.restructureRepo.GetRestructuresByLoan(loanID)
+}
+
+// checkOfferCurrent refuses an offer whose projected schedule no longer
+// fits the loan: the schedule it was computed from has been replaced or paid
+// towards, or the loan has left servicing.
+func (ru *RestructureUsecase) checkOfferCurrent(restructure *models.Restructure) error {
+	_, schedule, err := ru.servicedLoan(restructure.LoanID.Hex())
+	if err != nil {
+		return err
+	}
+	current := scheduleTerms(schedule, restructure.CreatedAt)
+	if schedule.ID != restructure.BaseScheduleID ||
+		current.OutstandingPrincipal != restructure.Before.OutstandingPrincipal ||
+		current.OutstandingInterest != restructure.Before.OutstandingInterest {
+		return fmt.Errorf("%w: the loan has changed since the offer was made, ask for a new one", models.ErrStatusConflict)
+	}
+	return nil
+}
+
+// applyRestructure puts an accepted offer's schedule in force. Each step is
+// skipped when an earlier attempt already made it, so it can be repeated
+// until the offer is marked applied: the new schedule version is stored
+// first, then the loan's balances, the ledger adjustment, its terms and, for
+// a defaulted loan, its return to active are brought in line with it.
+func (ru *RestructureUsecase) applyRestructure(restructure *models.Restructure) (*models.Restructure, error) {
+	loanID := restructure.LoanID.Hex()
+	restructured := restructure.Schedule
+	loan, schedule, err := ru.servicedLoan(loanID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.ID != restructured.ID {
+		if schedule.ID != restructure.BaseScheduleID {
+			return nil, fmt.Errorf("%w: the loan has changed since the offer was made", models.ErrStatusConflict)
+		}
+		restructured.CreatedAt = time.Now()
+		if _, err := ru.scheduleRepo.CreateSchedule(restructured); err != nil {
+			return nil, err
+		}
+	}
+
+	principal := models.NewMoney(0, loan.OutstandingBalance.Currency)
+	interest := models.NewMoney(0, loan.OutstandingBalance.Currency)
+	for _, inst := range restructured.Installments {
+		principal = principal.Add(inst.Principal.Sub(inst.PaidPrincipal))
+		interest = interest.Add(inst.Interest.Sub(inst.PaidInterest))
+	}
+	if loan.OutstandingPrincipal != principal || loan.OutstandingInterest != interest {
+		previousBalance := loan.OutstandingBalance
+		loan.OutstandingPrincipal = principal
+		loan.OutstandingInterest = interest
+		loan.OutstandingBalance = loan.OutstandingFees.Add(loan.OutstandingInterest).Add(loan.OutstandingPrincipal)
+		if err := ru.loanRepo.UpdateLoanBalances(loan, previousBalance); err != nil {
+			return nil, err
+		}
+	}
+
+	// capitalized and holiday interest moves into principal and interest the
+	// new schedule charges more or less of is added to or released from
+	// unearned interest, so the receivables match the new schedule
+	principalChange := principal.Sub(restructure.Before.OutstandingPrincipal)
+	interestChange := interest.Sub(restructure.Before.OutstandingInterest)
+	unearnedChange := principalChange.Add(interestChange)
+	entries, err := newKeyedLedgerTransaction(loan.ID, "restructure:"+restructure.ID.Hex(), fmt.Sprintf("Loan restructured to schedule version %d", restructured.Version),
+		adjustment(models.AccountPrincipalReceivable, principalChange),
+		adjustment(models.AccountInterestReceivable, interestChange),
+		adjustment(models.AccountUnearnedInterest, models.NewMoney(-unearnedChange.Amount, unearnedChange.Currency)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > 0 {
+		if err := ru.ledgerRepo.CreateEntries(entries); err != nil && !errors.Is(err, models.ErrDuplicateLedgerEntry) {
+			return nil, err
+		}
+	}
+
+	if err := ru.loanRepo.UpdateLoanTerms(loanID, restructured.AnnualRate, restructured.Term); err != nil {
+		return nil, err
+	}
+	if loan.Status == models.LoanStatusDefaulted {
+		if err := transitionLoan(ru.loanRepo, ru.logRepo, loan, models.LoanStatusActive, systemActor, "restructured on borrower acceptance"); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	restructure.AppliedAt = &now
+	if err := ru.restructureRepo.UpdateRestructure(restructure); err != nil {
+		return nil, err
+	}
+	ru.logRestructure(systemActor, loanID, "Loan restructured on borrower acceptance: "+describeTermsChange(restructure.Before, restructure.After))
+	return restructure, nil
+}
+
+// restructuredSchedule builds the next version of the loan's schedule under
+// the new terms. Installments that have fallen due are kept as they are, or
+// when arrears are capitalized closed at what was paid, with the rest added
+// to principal. Everything from the current installment on is replaced: the
+// principal is rescheduled at the new rate over the installments left plus
+// the extension, starting after the payment holiday, whose interest is
+// added to principal. Payments already made towards the replaced
+// installments are carried over to the first new one.
+func (ru *RestructureUsecase) restructuredSchedule(loan *models.Loan, schedule *models.RepaymentSchedule, terms models.RestructureTerms, asOf time.Time) (*models.RepaymentSchedule, error) {
+	if len(schedule.Installments) == 0 {
+		return nil, fmt.Errorf("%w: loan has no installments to restructure", models.ErrInvalidRestructure)
+	}
+	pos := loanPosition(schedule, asOf)
+	rate := schedule.AnnualRate
+	if terms.AnnualRate != nil {
+		rate = *terms.AnnualRate
+	}
+
+	installments := make([]models.Installment, 0, len(schedule.Installments)+terms.ExtendMonths)
+	principal := loan.OutstandingPrincipal
+	for _, inst := range schedule.Installments[:pos.current] {
+		if terms.CapitalizeArrears && !inst.Paid {
+			principal = principal.Add(inst.Interest.Sub(inst.PaidInterest))
+			inst.Principal = inst.PaidPrincipal
+			inst.Interest = inst.PaidInterest
+			inst.Payment = inst.Principal.Add(inst.Interest)
+			inst.Paid = true
+		}
+		installments = append(installments, inst)
+	}
+	if !terms.CapitalizeArrears {
+		principal = principal.Sub(pos.arrearsPrincipal)
+	}
+
+	start := schedule.Installments[len(schedule.Installments)-1].DueDate
+	if pos.current < len(schedule.Installments) {
+		start = periodStart(schedule, pos.current)
+	}
+	if terms.HolidayMonths > 0 {
+		holidayInterest := principal.MulRate(rate).Mul(big.NewRat(int64(terms.HolidayMonths), 12))
+		principal = principal.Add(holidayInterest)
+		start = models.AddMonths(start, terms.HolidayMonths)
+	}
+	term := len(schedule.Installments) - pos.current + terms.ExtendMonths
+	if term <= 0 {
+		return nil, fmt.Errorf("%w: every installment has fallen due, extend the term to reschedule", models.ErrInvalidRestructure)
+	}
+	if principal.Amount <= 0 {
+		return nil, fmt.Errorf("%w: no principal is left to reschedule", models.ErrInvalidRestructure)
+	}
+
+	generated, err := ru.scheduleSvc.GenerateSchedule(principal, rate, term, schedule.Method, start)
+	if err != nil {
+		return nil, err
+	}
+	next := generated.Installments
+	interestPaidAhead := pos.accrued.Sub(pos.accruedOwed)
+	next[0].Principal = next[0].Principal.Add(pos.paidAhead)
+	next[0].Interest = next[0].Interest.Add(interestPaidAhead)
+	next[0].Payment = next[0].Principal.Add(next[0].Interest)
+	next[0].PaidPrincipal = pos.paidAhead
+	next[0].PaidInterest = interestPaidAhead
+	for i := range next {
+		next[i].Number = len(installments) + 1
+		installments = append(installments, next[i])
+	}
+
+	restructured := &models.RepaymentSchedule{
+		LoanID:        schedule.LoanID,
+		Method:        schedule.Method,
+		Principal:     schedule.Principal,
+		AnnualRate:    rate,
+		Term:          len(installments),
+		TotalInterest: models.NewMoney(0, schedule.Principal.Currency),
+		TotalPayment:  models.NewMoney(0, schedule.Principal.Currency),
+		Installments:  installments,
+		CreatedAt:     time.Now(),
+		Version:       schedule.NextVersion(),
+	}
+	for _, inst := range installments {
+		restructured.TotalInterest = restructured.TotalInterest.Add(inst.Interest)
+		restructured.TotalPayment = restructured.TotalPayment.Add(inst.Payment)
+	}
+	return restructured, nil
+}
+
+func (ru *RestructureUsecase) servicedLoan(loanID string) (*models.Loan, *models.RepaymentSchedule, error) {
+	loan, err := ru.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, nil, models.ErrLoanNotFound
+	}
+	switch loan.Status {
+	case models.LoanStatusDisbursed, models.LoanStatusActive, models.LoanStatusDefaulted:
+	default:
+		return nil, nil, fmt.Errorf("%w: a %s loan cannot be restructured", models.ErrInvalidTransition, loan.Status)
+	}
+	schedule, err := ru.scheduleRepo.GetScheduleByLoanID(loanID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: loan has no repayment schedule", models.ErrInvalidTransition)
+	}
+	return loan, schedule, nil
+}
+
+func (ru *RestructureUsecase) sendOffer(loan *models.Loan, restructure *models.Restructure) {
+	user, err := ru.userRepo.GetUserByID(loan.UserId.Hex())
+	if err != nil {
+		ru.logRestructure(systemActor, loan.ID.Hex(), "Restructure offer not emailed: borrower not found")
+		return
+	}
+	before, after := restructure.Before, restructure.After
+	data := email_service.RestructureOfferEmail{
+		Name:               user.Name,
+		LoanID:             loan.ID.Hex(),
+		Note:               restructure.Note,
+		CurrentRate:        formatRate(before.AnnualRate),
+		NewRate:            formatRate(after.AnnualRate),
+		CurrentInstallment: before.Installment.String(),
+		NewInstallment:     after.Installment.String(),
+		CurrentRemaining:   before.RemainingInstallments,
+		NewRemaining:       after.RemainingInstallments,
+		CurrentMaturity:    before.MaturityDate.Format("2006-01-02"),
+		NewMaturity:        after.MaturityDate.Format("2006-01-02"),
+		HolidayMonths:      restructure.Terms.HolidayMonths,
+		CapitalizeArrears:  restructure.Terms.CapitalizeArrears,
+		ReviewLink:         fmt.Sprintf("%s/restructures/%s?token=%s", ru.baseUri, restructure.ID.Hex(), restructure.Token),
+		ExpiresAt:          restructure.ExpiresAt.Format("2006-01-02 15:04 MST"),
+	}
+	if err := ru.emailSvc.SendRestructureOfferEmail(user.Email, data); err != nil {
+		ru.logRestructure(systemActor, loan.ID.Hex(), "Failed to email restructure offer: "+err.Error())
+	}
+}
+
+func (ru *RestructureUsecase) logRestructure(actorID string, loanID string, action string) {
+	userID, _ := primitive.ObjectIDFromHex(actorID)
+	ru.logRepo.CreateLog(&models.SystemLog{
+		Action:    action,
+		Timestamp: time.Now(),
+		UserID:    userID,
+		LoanID:    loanID,
+	})
+}
+
+func validateRestructureTerms(terms models.RestructureTerms) error {
+	if terms.ExtendMonths < 0 || terms.ExtendMonths > 120 {
+		return fmt.Errorf("%w: extend_months must be between 0 and 120", models.ErrInvalidRestructure)
+	}
+	if terms.HolidayMonths < 0 || terms.HolidayMonths > 12 {
+		return fmt.Errorf("%w: holiday_months must be between 0 and 12", models.ErrInvalidRestructure)
+	}
+	if terms.AnnualRate != nil && (*terms.AnnualRate < 0 || *terms.AnnualRate >= 1) {
+		return fmt.Errorf("%w: annual_rate must be between 0 and 1", models.ErrInvalidRestructure)
+	}
+	if terms.ExtendMonths == 0 && terms.HolidayMonths == 0 && terms.AnnualRate == nil && !terms.CapitalizeArrears {
+		return fmt.Errorf("%w: nothing to change", models.ErrInvalidRestructure)
+	}
+	return nil
+}
+
+// scheduleTerms summarises the schedule as it stands on asOf.
+func scheduleTerms(schedule *models.RepaymentSchedule, asOf time.Time) models.LoanTerms {
+	zero := models.NewMoney(0, schedule.Principal.Currency)
+	pos := loanPosition(schedule, asOf)
+	terms := models.LoanTerms{
+		ScheduleVersion:      schedule.Version,
+		AnnualRate:           schedule.AnnualRate,
+		Installment:          zero,
+		OutstandingPrincipal: zero,
+		OutstandingInterest:  zero,
+		Arrears:              pos.arrearsPrincipal.Add(pos.arrearsInterest),
+	}
+	for i, inst := range schedule.Installments {
+		terms.OutstandingPrincipal = terms.OutstandingPrincipal.Add(inst.Principal.Sub(inst.PaidPrincipal))
+		terms.OutstandingInterest = terms.OutstandingInterest.Add(inst.Interest.Sub(inst.PaidInterest))
+		if inst.Paid {
+			continue
+		}
+		terms.RemainingInstallments++
+		terms.MaturityDate = inst.DueDate
+		if i >= pos.current && terms.Installment.IsZero() {
+			terms.Installment = inst.Payment
+		}
+	}
+	return terms
+}
+
+func describeTermsChange(before, after models.LoanTerms) string {
+	return fmt.Sprintf("schedule version %d to %d, rate %s to %s, %d installments left to %d, installment %s to %s, principal %s to %s, arrears %s to %s, maturity %s to %s",
+		before.ScheduleVersion, after.ScheduleVersion,
+		formatRate(before.AnnualRate), formatRate(after.AnnualRate),
+		before.RemainingInstallments, after.RemainingInstallments,
+		before.Installment.String(), after.Installment.String(),
+		before.OutstandingPrincipal.String(), after.OutstandingPrincipal.String(),
+		before.Arrears.String(), after.Arrears.String(),
+		before.MaturityDate.Format("2006-01-02"), after.MaturityDate.Format("2006-01-02"),
+	)
+}
+
+func formatRate(rate float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.4f", rate*100), "0"), ".") + "%"
+}
+
+// adjustment posts a change in an account's balance: a debit when it grows,
+// a credit when it shrinks.
+func adjustment(account string, change models.Money) models.LedgerEntry {
+	if change.IsNegative() {
+		return credit(account, models.NewMoney(-change.Amount, change.Currency))
+	}
+	return debit(account, change)
+}
@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/models"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestRestructuredSchedule(t *testing.T) {
+	rate := 0.06
+	tests := []struct {
+		name  string
+		paid  int
+		asOf  time.Time
+		terms models.RestructureTerms
+		// the principal the new installments repay, given where the loan
+		// stood and the principal it owed
+		rescheduled func(pos payoffPosition, owed models.Money) models.Money
+		term        int
+		firstDue    time.Time
+		err         error
+	}{
+		{
+			name:  "arrears stay due when not capitalized",
+			paid:  1,
+			asOf:  day(2026, 4, 15),
+			terms: models.RestructureTerms{ExtendMonths: 6},
+			rescheduled: func(pos payoffPosition, owed models.Money) models.Money {
+				return owed.Sub(pos.arrearsPrincipal)
+			},
+			term:     18,
+			firstDue: day(2026, 5, 1),
+		},
+		{
+			name:  "capitalized arrears interest joins the principal",
+			paid:  1,
+			asOf:  day(2026, 4, 15),
+			terms: models.RestructureTerms{CapitalizeArrears: true},
+			rescheduled: func(pos payoffPosition, owed models.Money) models.Money {
+				return owed.Add(pos.arrearsInterest)
+			},
+			term:     12,
+			firstDue: day(2026, 5, 1),
+		},
+		{
+			name:  "holiday interest is capitalized and pushes the dates out",
+			paid:  3,
+			asOf:  day(2026, 4, 15),
+			terms: models.RestructureTerms{HolidayMonths: 2, AnnualRate: &rate},
+			rescheduled: func(_ payoffPosition, owed models.Money) models.Money {
+				return owed.Add(owed.MulRate(rate).Mul(big.NewRat(2, 12)))
+			},
+			term:     12,
+			firstDue: day(2026, 7, 1),
+		},
+		{
+			name:  "a fully due loan needs an extension",
+			paid:  0,
+			asOf:  day(2027, 2, 1),
+			terms: models.RestructureTerms{CapitalizeArrears: true},
+			err:   models.ErrInvalidRestructure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repayments, loan, schedule := bookedLoan(t, usd(1200000), 0.12, 12, day(2026, 1, 1), tt.paid, nil)
+			ru := &RestructureUsecase{scheduleSvc: repayments.scheduleSvc}
+			pos := loanPosition(schedule, tt.asOf)
+
+			restructured, err := ru.restructuredSchedule(loan, schedule, tt.terms, tt.asOf)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(restructured.Installments) != tt.term {
+				t.Fatalf("%d installments, want %d", len(restructured.Installments), tt.term)
+			}
+			if restructured.Version != 2 {
+				t.Fatalf("version %d, want 2", restructured.Version)
+			}
+
+			kept := restructured.Installments[:pos.current]
+			for i, inst := range kept {
+				if tt.terms.CapitalizeArrears && !inst.Paid {
+					t.Fatalf("installment %d left in arrears after capitalizing", inst.Number)
+				}
+				if !tt.terms.CapitalizeArrears && inst.Principal.Cmp(schedule.Installments[i].Principal) != 0 {
+					t.Fatalf("installment %d changed", inst.Number)
+				}
+			}
+			next := restructured.Installments[pos.current:]
+			if !next[0].DueDate.Equal(tt.firstDue) {
+				t.Fatalf("first rescheduled installment due %s, want %s", next[0].DueDate, tt.firstDue)
+			}
+			for i, inst := range restructured.Installments {
+				if inst.Number != i+1 {
+					t.Fatalf("installment %d numbered %d", i+1, inst.Number)
+				}
+			}
+
+			rescheduled := models.NewMoney(0, loan.OutstandingPrincipal.Currency)
+			for _, inst := range next {
+				rescheduled = rescheduled.Add(inst.Principal)
+			}
+			want := tt.rescheduled(pos, loan.OutstandingPrincipal)
+			if rescheduled.Cmp(want) != 0 {
+				t.Fatalf("rescheduled principal %s, want %s", rescheduled, want)
+			}
+		})
+	}
+}
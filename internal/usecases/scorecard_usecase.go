@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"LoanGuard/internal/domain/models"
+	"LoanGuard/internal/repository/interfaces"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type IScorecardUsecase interface {
+	GetScorecard() (*models.Scorecard, error)
+	UpdateScorecard(adminID string, scorecard *models.Scorecard) (*models.Scorecard, error)
+}
+
+type ScorecardUsecase struct {
+	scorecardRepo repository_interface.IScorecardRepository
+	logRepo       repository_interface.ILogRepository
+}
+
+func NewScorecardUsecase(scorecardRepo repository_interface.IScorecardRepository, logRepo repository_interface.ILogRepository) IScorecardUsecase {
+	return &ScorecardUsecase{
+		scorecardRepo: scorecardRepo,
+		logRepo:       logRepo,
+	}
+}
+
+func (su *ScorecardUsecase) GetScorecard() (*models.Scorecard, error) {
+	return currentScorecard(su.scorecardRepo)
+}
+
+// UpdateScorecard saves the scorecard as a new version; earlier versions are
+// kept so existing scores stay explainable.
+func (su *ScorecardUsecase) UpdateScorecard(adminID string, scorecard *models.Scorecard) (*models.Scorecard, error) {
+	if err := validateScorecard(scorecard); err != nil {
+		return nil, err
+	}
+	current, err := currentScorecard(su.scorecardRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	scorecard.ID = primitive.NilObjectID
+	scorecard.Version = current.Version + 1
+	scorecard.UpdatedBy = adminID
+	scorecard.CreatedAt = time.Now()
+	result, err := su.scorecardRepo.CreateScorecard(scorecard)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, _ := primitive.ObjectIDFromHex(adminID)
+	su.logRepo.CreateLog(&models.SystemLog{
+		Action:    fmt.Sprintf("Credit scorecard updated to version %d", result.Version),
+		Timestamp: result.CreatedAt,
+		UserID:    userID,
+	})
+	return result, nil
+}
+
+func validateScorecard(scorecard *models.Scorecard) error {
+	if len(scorecard.Bands) == 0 {
+		return errors.New("scorecard needs at least one band")
+	}
+	names := map[string]bool{}
+	for _, band := range scorecard.Bands {
+		if band.Name == "" || names[band.Name] {
+			return errors.New("score bands need unique names")
+		}
+		names[band.Name] = true
+	}
+	sort.Slice(scorecard.Bands, func(i, j int) bool {
+		return scorecard.Bands[i].MinScore > scorecard.Bands[j].MinScore
+	})
+
+	for _, rules := range [][]models.ThresholdRule{scorecard.AgeRules, scorecard.OpenLoanRules, scorecard.IncomeRatioRules} {
+		sort.Slice(rules, func(i, j int) bool { return rules[i].UpTo < rules[j].UpTo })
+		for i := 1; i < len(rules); i++ {
+			if rules[i].UpTo == rules[i-1].UpTo {
+				return errors.New("threshold rules must not repeat a threshold")
+			}
+		}
+	}
+	if scorecard.MaxClosedLoanPoints < 0 {
+		return errors.New("max closed loan points cannot be negative")
+	}
+	return nil
+}
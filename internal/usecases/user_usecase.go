@@ -21,7 +21,7 @@ type IUserUsecase interface {
 	RefreshToken(refreshToken string) (string, error)
 	GetUserByID(userID string) (*models.User, error)
 	GetUserByEmail(email string) (*models.User, error)
-	GetUsers() ([]*models.User, error)
+	GetUsers(query models.ListQuery) ([]*models.User, string, error)
 	DeleteUser(userID string) error
 	UpdateUser(userID string, user *models.User) error
 	UpdateProfile(userID string, user *dtos.UpdateProfileDTO, image multipart.File) (*dtos.UpdateProfileDTO, error)
@@ -56,7 +56,8 @@ func NewUserUsecase(userRepo repository_interface.IUserRepository, passwordServi
 }
 
 func (u *UserUsecase) Register(user *models.User) (*models.User, error) {
-	users, err := u.userRepo.GetAllUsers()
+	// the very first account to register becomes the admin
+	users, _, err := u.userRepo.ListUsers(models.ListQuery{Limit: 1})
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +84,9 @@ func (u *UserUsecase) Register(user *models.User) (*models.User, error) {
 	}
 	user.Password = encryptedPassword
 	user.IsVerified = false
+	// identity details only come in through KYC submission
+	user.DateOfBirth = nil
+	user.KYC = models.KYCProfile{Status: models.KYCStatusUnverified}
 
 	regUser, err := u.userRepo.Register(user)
 	if err != nil {
@@ -257,6 +261,8 @@ func(u *UserUsecase) GetMyProfile(userID string) (*dtos.ProfileDTO, error) {
 		PhoneNum: user.PhoneNum,
 		Bio: user.Bio,
 		ProfilePicture: user.ProfilePicture,
+		DateOfBirth: user.DateOfBirth,
+		KYCStatus: user.KYCStatus(),
 	}, nil
 }
 
@@ -271,8 +277,8 @@ func (u *UserUsecase) DeleteUser(userID string) error {
 	return u.userRepo.DeleteUser(userID)
 }
 
-func (u *UserUsecase) GetUsers() ([]*models.User, error) {
-	return u.userRepo.GetAllUsers()
+func (u *UserUsecase) GetUsers(query models.ListQuery) ([]*models.User, string, error) {
+	return u.userRepo.ListUsers(query)
 }
 
 func (u *UserUsecase) UpdateUser(userID string, user *models.User) error {